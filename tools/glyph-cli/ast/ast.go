@@ -1,5 +1,47 @@
 package ast
 
+import "fmt"
+
+// SourcePos records where a node was parsed from. Line and Column are
+// 1-based and point at the first character of the node; Offset and
+// EndOffset are the byte offsets delimiting its source text.
+type SourcePos struct {
+	File      string
+	Line      int
+	Column    int
+	Offset    int
+	EndOffset int
+}
+
+// IsValid reports whether the position was filled in by the parser.
+func (p SourcePos) IsValid() bool {
+	return p.Line > 0
+}
+
+// Through returns a position starting at p and ending where end ends.
+func (p SourcePos) Through(end SourcePos) SourcePos {
+	p.EndOffset = end.EndOffset
+	return p
+}
+
+func (p SourcePos) String() string {
+	if !p.IsValid() {
+		if p.File != "" {
+			return p.File
+		}
+		return "-"
+	}
+	if p.File == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// Node is implemented by every AST node.
+type Node interface {
+	Position() SourcePos
+}
+
 type Program struct {
 	Package     *PackageDecl
 	Imports     []*ImportDecl
@@ -7,34 +49,43 @@ type Program struct {
 	Records     []*RecordDecl
 	SumTypes    []*SumTypeDecl
 	Functions   []*FunctionDecl
+	Pos         SourcePos
 }
 
 type PackageDecl struct {
 	Name string
+	Pos  SourcePos
 }
 
 type ImportDecl struct {
 	Name string
+	Pos  SourcePos
 }
 
 type TypeAliasDecl struct {
 	Name       string
 	TargetType string
+	Doc        string // text of the preceding /// comment, if any
+	Pos        SourcePos
 }
 
 type SumTypeDecl struct {
 	Name     string
 	Variants []*VariantDecl
+	Doc      string // text of the preceding /// comment, if any
+	Pos      SourcePos
 }
 
 type VariantDecl struct {
 	Name   string
 	Fields []*VariantField
+	Pos    SourcePos
 }
 
 type VariantField struct {
 	Name string
 	Type string
+	Pos  SourcePos
 }
 
 type FunctionDecl struct {
@@ -42,29 +93,52 @@ type FunctionDecl struct {
 	Params     []*Param
 	ReturnType string
 	Body       *Block
+	Doc        string // text of the preceding /// comment, if any
+	// Extern marks an `extern fun`, which has no Body. Native is the
+	// qualified name of the Go function project.Resolve bound it to,
+	// which runs instead of any Body.
+	Extern bool
+	Native string
+	// Slots is the number of local variable slots a call needs: the
+	// parameters, then the variables the body declares outside nested
+	// blocks. Resolved records that project.ResolveScopes has run.
+	Slots    int
+	Resolved bool
+	Pos      SourcePos
 }
 
 type Param struct {
 	Name string
 	Type string
+	Pos  SourcePos
 }
 
 type RecordDecl struct {
 	Name   string
 	Fields []*RecordField
+	Doc    string // text of the preceding /// comment, if any
+	Pos    SourcePos
 }
 
 type RecordField struct {
 	Name       string
 	Type       string
 	Mutability string // "val" or "var"
+	Doc        string // text of the preceding /// comment, if any
+	Pos        SourcePos
 }
 
 type Block struct {
 	Statements []Statement
+	// Slots is the number of variables the block declares. A block that
+	// declares none, and the body of a function, lambda or iteration,
+	// shares the scope around it.
+	Slots int
+	Pos   SourcePos
 }
 
 type Statement interface {
+	Node
 	stmtNode()
 }
 
@@ -73,125 +147,247 @@ type VarDecl struct {
 	Type       string
 	Mutability string // "const", "val", or "var"
 	Value      Expr
+	Slot       int // index in the declaring scope
+	Pos        SourcePos
 }
 
 type PrintStmt struct {
 	Expr Expr
+	Pos  SourcePos
 }
 
 type ExprStmt struct {
 	Expr Expr
+	Pos  SourcePos
 }
 
 type AssignStmt struct {
 	Target Expr
+	Op     string // "" for plain assignment, otherwise the operator of a compound one ("+" for +=)
 	Value  Expr
+	Pos    SourcePos
+}
+
+type WhileStmt struct {
+	Condition Expr
+	Body      *Block
+	Pos       SourcePos
+}
+
+type BreakStmt struct {
+	Pos SourcePos
+}
+
+type ContinueStmt struct {
+	Pos SourcePos
+}
+
+// IncDecStmt is x++ or x-- on any assignable target.
+type IncDecStmt struct {
+	Target Expr
+	Op     string // "++" or "--"
+	Pos    SourcePos
 }
 
 type ReturnStmt struct {
 	Expr Expr
+	Pos  SourcePos
 }
 
 type Expr interface {
+	Node
 	exprNode()
 }
 
 type IntLiteral struct {
 	Value int64
+	Pos   SourcePos
+}
+
+// LongLiteral is an integer literal with an L suffix.
+type LongLiteral struct {
+	Value int64
+	Pos   SourcePos
+}
+
+// FloatLiteral is a decimal literal with an f suffix.
+type FloatLiteral struct {
+	Value float32
+	Pos   SourcePos
+}
+
+// DoubleLiteral is a decimal literal without a suffix (or with d).
+type DoubleLiteral struct {
+	Value float64
+	Pos   SourcePos
+}
+
+type CharLiteral struct {
+	Value rune
+	Pos   SourcePos
 }
 
 type BoolLiteral struct {
 	Value bool
+	Pos   SourcePos
 }
 
-type NullLiteral struct{}
+type NullLiteral struct {
+	Pos SourcePos
+}
 
 type StringLiteral struct {
 	Value string
+	Pos   SourcePos
+}
+
+// StringTemplate is an interpolated string literal. Parts holds the
+// *StringLiteral text segments and the embedded ${...} expressions in source
+// order.
+type StringTemplate struct {
+	Parts []Expr
+	Pos   SourcePos
 }
 
 type BinaryOp struct {
 	Op          string
 	Left, Right Expr
+	Pos         SourcePos
+}
+
+// UnaryOp is a prefix operator: "!" or "-".
+type UnaryOp struct {
+	Op      string
+	Operand Expr
+	Pos     SourcePos
 }
 
 type VarRef struct {
 	Name string
+	Slot *Slot // nil when Name is not a local variable in scope
+	Pos  SourcePos
+}
+
+// Slot locates a local variable from the scope a reference is evaluated
+// in: Depth scopes out, at Index within that scope. Kind is the kind of
+// binding, "var" for a reassignable one and otherwise "val", "const",
+// "parameter" or "pattern variable".
+type Slot struct {
+	Depth int
+	Index int
+	Kind  string
 }
 
 type IfExpr struct {
 	Condition Expr
 	ThenBlock *Block
 	ElseBlock *Block
+	Pos       SourcePos
 }
 
 type TernaryExpr struct {
 	Condition Expr
 	IfTrue    Expr
 	IfFalse   Expr
+	Pos       SourcePos
 }
 
 type ElvisExpr struct {
 	Left  Expr
 	Right Expr
+	Pos   SourcePos
 }
 
 type MatchExpr struct {
 	Target   Expr
 	Cases    []*MatchCase
 	ElseExpr Expr
+	Pos      SourcePos
 }
 
 type MatchCase struct {
 	Pattern Pattern
 	Value   Expr
+	Slots   int // number of pattern variables; 0 opens no scope
+	Pos     SourcePos
 }
 
 type RecordLiteral struct {
 	TypeName string
 	Fields   map[string]Expr
+	Pos      SourcePos
 }
 
 type FieldAccess struct {
 	Target Expr
 	Field  string
+	Pos    SourcePos
 }
 
 type SafeFieldAccess struct {
 	Target Expr
 	Field  string
+	Pos    SourcePos
 }
 
 type IndexAccess struct {
 	Target Expr
 	Index  Expr
+	Pos    SourcePos
 }
 
 type ArrayAllocExpr struct {
 	ElementType string
 	Size        Expr
+	Pos         SourcePos
 }
 
 type MapAllocExpr struct {
 	KeyType   string
 	ValueType string
 	Capacity  Expr
+	Pos       SourcePos
 }
 
 type MapLiteralExpr struct {
 	KeyType   string
 	ValueType string
 	Entries   []*MapEntryExpr
+	Pos       SourcePos
 }
 
 type MapEntryExpr struct {
 	Key   Expr
 	Value Expr
+	Pos   SourcePos
 }
 
 type CallExpr struct {
 	Callee    string
 	Arguments []Expr
+	Slot      *Slot // set when Callee is a local holding a lambda
+	Pos       SourcePos
+}
+
+// MethodCall is the member-call form `recv.name(args)`, or `recv?.name(args)`
+// when Safe. It calls the free function name with recv as its first
+// argument.
+type MethodCall struct {
+	Receiver  Expr
+	Name      string
+	Arguments []Expr
+	Safe      bool
+	Pos       SourcePos
+}
+
+// IterateExpr is a trailing-block iteration such as `xs.each { ... }` or
+// `xs.withIndex { ... }`. The body sees the current element as `it`.
+type IterateExpr struct {
+	Target Expr
+	Method string // "each" or "withIndex"
+	Body   *Block
+	Slots  int // `it` in slot 0, then the body's variables
+	Pos    SourcePos
 }
 
 type LambdaExpr struct {
@@ -199,49 +395,74 @@ type LambdaExpr struct {
 	ReturnType string
 	Body       *Block
 	Captures   []string
+	// CaptureSlots locates each capture in the scope the lambda is
+	// created in. A call's scope holds the parameters, then the captures,
+	// then the variables the body declares: Slots in all.
+	CaptureSlots []*Slot
+	Slots        int
+	Pos          SourcePos
 }
 
 type Pattern interface {
+	Node
 	patternNode()
 }
 
-type WildcardPattern struct{}
+type WildcardPattern struct {
+	Pos SourcePos
+}
 
 type VarPattern struct {
 	Name string
+	Slot int // index in the match case's scope
+	Pos  SourcePos
 }
 
 type LiteralPattern struct {
 	Literal Expr
+	Pos     SourcePos
 }
 
 type RecordPattern struct {
 	TypeName string
 	Fields   []*RecordFieldPattern
+	Pos      SourcePos
 }
 
 type VariantPattern struct {
 	TypeName string
 	Variant  string
 	Fields   []Pattern
+	Pos      SourcePos
 }
 
 type RecordFieldPattern struct {
 	Field   string
 	Pattern Pattern
+	Pos     SourcePos
 }
 
-func (VarDecl) stmtNode()    {}
-func (PrintStmt) stmtNode()  {}
-func (ExprStmt) stmtNode()   {}
-func (AssignStmt) stmtNode() {}
-func (ReturnStmt) stmtNode() {}
+func (VarDecl) stmtNode()      {}
+func (PrintStmt) stmtNode()    {}
+func (ExprStmt) stmtNode()     {}
+func (AssignStmt) stmtNode()   {}
+func (ReturnStmt) stmtNode()   {}
+func (WhileStmt) stmtNode()    {}
+func (BreakStmt) stmtNode()    {}
+func (ContinueStmt) stmtNode() {}
+func (IncDecStmt) stmtNode()   {}
 
 func (IntLiteral) exprNode()      {}
+func (LongLiteral) exprNode()     {}
+func (FloatLiteral) exprNode()    {}
+func (DoubleLiteral) exprNode()   {}
+func (CharLiteral) exprNode()     {}
 func (BoolLiteral) exprNode()     {}
 func (NullLiteral) exprNode()     {}
 func (StringLiteral) exprNode()   {}
+func (StringTemplate) exprNode()  {}
 func (BinaryOp) exprNode()        {}
+func (UnaryOp) exprNode()         {}
 func (VarRef) exprNode()          {}
 func (IfExpr) exprNode()          {}
 func (TernaryExpr) exprNode()     {}
@@ -254,6 +475,8 @@ func (IndexAccess) exprNode()     {}
 func (ArrayAllocExpr) exprNode()  {}
 func (MapAllocExpr) exprNode()    {}
 func (MapLiteralExpr) exprNode()  {}
+func (MethodCall) exprNode()      {}
+func (IterateExpr) exprNode()     {}
 func (CallExpr) exprNode()        {}
 func (LambdaExpr) exprNode()      {}
 
@@ -263,3 +486,60 @@ func (LiteralPattern) patternNode()     {}
 func (RecordPattern) patternNode()      {}
 func (VariantPattern) patternNode()     {}
 func (RecordFieldPattern) patternNode() {}
+
+func (n Program) Position() SourcePos            { return n.Pos }
+func (n PackageDecl) Position() SourcePos        { return n.Pos }
+func (n ImportDecl) Position() SourcePos         { return n.Pos }
+func (n TypeAliasDecl) Position() SourcePos      { return n.Pos }
+func (n SumTypeDecl) Position() SourcePos        { return n.Pos }
+func (n VariantDecl) Position() SourcePos        { return n.Pos }
+func (n VariantField) Position() SourcePos       { return n.Pos }
+func (n FunctionDecl) Position() SourcePos       { return n.Pos }
+func (n Param) Position() SourcePos              { return n.Pos }
+func (n RecordDecl) Position() SourcePos         { return n.Pos }
+func (n RecordField) Position() SourcePos        { return n.Pos }
+func (n Block) Position() SourcePos              { return n.Pos }
+func (n VarDecl) Position() SourcePos            { return n.Pos }
+func (n PrintStmt) Position() SourcePos          { return n.Pos }
+func (n ExprStmt) Position() SourcePos           { return n.Pos }
+func (n AssignStmt) Position() SourcePos         { return n.Pos }
+func (n ReturnStmt) Position() SourcePos         { return n.Pos }
+func (n WhileStmt) Position() SourcePos          { return n.Pos }
+func (n BreakStmt) Position() SourcePos          { return n.Pos }
+func (n ContinueStmt) Position() SourcePos       { return n.Pos }
+func (n IncDecStmt) Position() SourcePos         { return n.Pos }
+func (n IntLiteral) Position() SourcePos         { return n.Pos }
+func (n LongLiteral) Position() SourcePos        { return n.Pos }
+func (n FloatLiteral) Position() SourcePos       { return n.Pos }
+func (n DoubleLiteral) Position() SourcePos      { return n.Pos }
+func (n CharLiteral) Position() SourcePos        { return n.Pos }
+func (n BoolLiteral) Position() SourcePos        { return n.Pos }
+func (n NullLiteral) Position() SourcePos        { return n.Pos }
+func (n StringLiteral) Position() SourcePos      { return n.Pos }
+func (n StringTemplate) Position() SourcePos     { return n.Pos }
+func (n BinaryOp) Position() SourcePos           { return n.Pos }
+func (n UnaryOp) Position() SourcePos            { return n.Pos }
+func (n VarRef) Position() SourcePos             { return n.Pos }
+func (n IfExpr) Position() SourcePos             { return n.Pos }
+func (n TernaryExpr) Position() SourcePos        { return n.Pos }
+func (n ElvisExpr) Position() SourcePos          { return n.Pos }
+func (n MatchExpr) Position() SourcePos          { return n.Pos }
+func (n MatchCase) Position() SourcePos          { return n.Pos }
+func (n RecordLiteral) Position() SourcePos      { return n.Pos }
+func (n FieldAccess) Position() SourcePos        { return n.Pos }
+func (n SafeFieldAccess) Position() SourcePos    { return n.Pos }
+func (n IndexAccess) Position() SourcePos        { return n.Pos }
+func (n ArrayAllocExpr) Position() SourcePos     { return n.Pos }
+func (n MapAllocExpr) Position() SourcePos       { return n.Pos }
+func (n MapLiteralExpr) Position() SourcePos     { return n.Pos }
+func (n MapEntryExpr) Position() SourcePos       { return n.Pos }
+func (n MethodCall) Position() SourcePos         { return n.Pos }
+func (n IterateExpr) Position() SourcePos        { return n.Pos }
+func (n CallExpr) Position() SourcePos           { return n.Pos }
+func (n LambdaExpr) Position() SourcePos         { return n.Pos }
+func (n WildcardPattern) Position() SourcePos    { return n.Pos }
+func (n VarPattern) Position() SourcePos         { return n.Pos }
+func (n LiteralPattern) Position() SourcePos     { return n.Pos }
+func (n RecordPattern) Position() SourcePos      { return n.Pos }
+func (n VariantPattern) Position() SourcePos     { return n.Pos }
+func (n RecordFieldPattern) Position() SourcePos { return n.Pos }
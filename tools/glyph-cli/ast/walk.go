@@ -0,0 +1,163 @@
+package ast
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Inspect traverses the tree rooted at node in depth-first order, calling f
+// for every node. If f returns false, the children of that node are
+// skipped. Nil nodes are ignored.
+func Inspect(node Node, f func(Node) bool) {
+	if node == nil || isNilNode(node) || !f(node) {
+		return
+	}
+	switch n := node.(type) {
+	case *Program:
+		for _, alias := range n.TypeAliases {
+			Inspect(alias, f)
+		}
+		for _, rec := range n.Records {
+			Inspect(rec, f)
+		}
+		for _, sum := range n.SumTypes {
+			Inspect(sum, f)
+		}
+		for _, fn := range n.Functions {
+			Inspect(fn, f)
+		}
+	case *SumTypeDecl:
+		for _, variant := range n.Variants {
+			Inspect(variant, f)
+		}
+	case *VariantDecl:
+		for _, field := range n.Fields {
+			Inspect(field, f)
+		}
+	case *RecordDecl:
+		for _, field := range n.Fields {
+			Inspect(field, f)
+		}
+	case *FunctionDecl:
+		for _, param := range n.Params {
+			Inspect(param, f)
+		}
+		Inspect(n.Body, f)
+	case *Block:
+		for _, stmt := range n.Statements {
+			Inspect(stmt, f)
+		}
+	case *VarDecl:
+		Inspect(n.Value, f)
+	case *PrintStmt:
+		Inspect(n.Expr, f)
+	case *ExprStmt:
+		Inspect(n.Expr, f)
+	case *AssignStmt:
+		Inspect(n.Target, f)
+		Inspect(n.Value, f)
+	case *ReturnStmt:
+		Inspect(n.Expr, f)
+	case *WhileStmt:
+		Inspect(n.Condition, f)
+		Inspect(n.Body, f)
+	case *IncDecStmt:
+		Inspect(n.Target, f)
+	case *StringTemplate:
+		for _, part := range n.Parts {
+			Inspect(part, f)
+		}
+	case *BinaryOp:
+		Inspect(n.Left, f)
+		Inspect(n.Right, f)
+	case *UnaryOp:
+		Inspect(n.Operand, f)
+	case *IfExpr:
+		Inspect(n.Condition, f)
+		Inspect(n.ThenBlock, f)
+		Inspect(n.ElseBlock, f)
+	case *TernaryExpr:
+		Inspect(n.Condition, f)
+		Inspect(n.IfTrue, f)
+		Inspect(n.IfFalse, f)
+	case *ElvisExpr:
+		Inspect(n.Left, f)
+		Inspect(n.Right, f)
+	case *MatchExpr:
+		Inspect(n.Target, f)
+		for _, c := range n.Cases {
+			Inspect(c, f)
+		}
+		Inspect(n.ElseExpr, f)
+	case *MatchCase:
+		Inspect(n.Pattern, f)
+		Inspect(n.Value, f)
+	case *RecordLiteral:
+		for _, name := range sortedFieldNames(n.Fields) {
+			Inspect(n.Fields[name], f)
+		}
+	case *FieldAccess:
+		Inspect(n.Target, f)
+	case *SafeFieldAccess:
+		Inspect(n.Target, f)
+	case *IndexAccess:
+		Inspect(n.Target, f)
+		Inspect(n.Index, f)
+	case *ArrayAllocExpr:
+		Inspect(n.Size, f)
+	case *MapAllocExpr:
+		Inspect(n.Capacity, f)
+	case *MapLiteralExpr:
+		for _, entry := range n.Entries {
+			Inspect(entry, f)
+		}
+	case *MapEntryExpr:
+		Inspect(n.Key, f)
+		Inspect(n.Value, f)
+	case *CallExpr:
+		for _, arg := range n.Arguments {
+			Inspect(arg, f)
+		}
+	case *MethodCall:
+		Inspect(n.Receiver, f)
+		for _, arg := range n.Arguments {
+			Inspect(arg, f)
+		}
+	case *IterateExpr:
+		Inspect(n.Target, f)
+		Inspect(n.Body, f)
+	case *LambdaExpr:
+		for _, param := range n.Params {
+			Inspect(param, f)
+		}
+		Inspect(n.Body, f)
+	case *LiteralPattern:
+		Inspect(n.Literal, f)
+	case *RecordPattern:
+		for _, field := range n.Fields {
+			Inspect(field, f)
+		}
+	case *RecordFieldPattern:
+		Inspect(n.Pattern, f)
+	case *VariantPattern:
+		for _, field := range n.Fields {
+			Inspect(field, f)
+		}
+	}
+}
+
+// isNilNode reports whether node is a typed nil pointer, such as the
+// ElseBlock of an if without else.
+func isNilNode(node Node) bool {
+	v := reflect.ValueOf(node)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+func sortedFieldNames(fields map[string]Expr) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
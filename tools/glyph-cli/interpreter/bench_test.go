@@ -0,0 +1,98 @@
+package interpreter
+
+import (
+	"context"
+	"testing"
+
+	"glyph-cli/parser"
+)
+
+// benchmarkSource runs source on the interpreter and, compiled once, on
+// the VM as sub-benchmarks, so the two can be compared directly.
+func benchmarkSource(b *testing.B, source string) {
+	b.Helper()
+	program, err := parser.ParseProgramSource("bench.gly", source)
+	if err != nil {
+		b.Fatalf("parse: %v", err)
+	}
+	symbols := inlineSymbols(program)
+	b.Run("eval", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := Eval(context.Background(), program, symbols, Options{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("vm", func(b *testing.B) {
+		code, err := Compile(program, symbols)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := code.Run(context.Background(), Options{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkRecursion(b *testing.B) {
+	benchmarkSource(b, `fun int fib(int n) {
+  if n < 2 {
+    return n
+  }
+  val a = fib(n - 1)
+  val b = fib(n - 2)
+  a + b
+}
+
+fun void main() {
+  fib(20)
+}
+`)
+}
+
+func BenchmarkDeepScopes(b *testing.B) {
+	benchmarkSource(b, `fun int depth(int n, int acc) {
+  if n == 0 {
+    return acc
+  }
+  var total = acc
+  if n % 2 == 0 {
+    if n % 3 == 0 {
+      total += n
+    }
+  }
+  depth(n - 1, total)
+}
+
+fun void main() {
+  var i = 0
+  while i < 20 {
+    depth(500, 0)
+    i++
+  }
+}
+`)
+}
+
+func BenchmarkClosures(b *testing.B) {
+	benchmarkSource(b, `fun void main() {
+  val xs = [int](1000)
+  val int offset = 7
+  var total = 0
+  var i = 0
+  while i < 2000 {
+    val add = fun int (int v) {
+      val scale = fun int (int w) { w * 2 + offset }
+      scale(v) + i
+    }
+    total = add(total) % 1000
+    i++
+  }
+}
+`)
+}
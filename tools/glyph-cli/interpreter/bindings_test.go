@@ -0,0 +1,81 @@
+package interpreter
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"glyph-cli/ast"
+	"glyph-cli/parser"
+	"glyph-cli/project"
+)
+
+func TestImmutableBindingsCannotBeReassigned(t *testing.T) {
+	cases := []struct {
+		name, body, want string
+	}{
+		{"val", "val x = 1\n  x = 2", "test.gly:3:3: cannot reassign val x"},
+		{"const", "const limit = 3\n  limit += 1", "test.gly:3:3: cannot reassign const limit"},
+		{"parameter", "n++", "test.gly:2:3: cannot reassign parameter n"},
+		{"pattern", "match n {\n    k -> if true { k = 2 }\n  }", "test.gly:3:20: cannot reassign pattern variable k"},
+		{"captured", "val y = 1\n  val f = fun () { y = 2 }\n  f()", "test.gly:3:20: cannot reassign val y"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			source := "fun void run(int n) {\n  " + tc.body + "\n}\n\nfun void main() {\n  run(1)\n}\n"
+			program, err := parser.ParseProgramSource("test.gly", source)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			_, err = output(Eval, program, inlineSymbols(program), Options{})
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("got error %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestReassigningAValDoesNotRunTheValue(t *testing.T) {
+	source := `fun int loud() {
+  print("evaluated")
+  2
+}
+
+fun void main() {
+  val x = 1
+  x = loud()
+}
+`
+	program, err := parser.ParseProgramSource("test.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	for name, eval := range map[string]func(context.Context, *ast.Program, *project.Symbols, Options) error{"interpreter": Eval, "vm": EvalVM} {
+		out, err := output(eval, program, inlineSymbols(program), Options{})
+		if err == nil || !strings.Contains(err.Error(), "test.gly:8:3: cannot reassign val x") {
+			t.Fatalf("%s: got error %v, want cannot reassign val x", name, err)
+		}
+		if out != "" {
+			t.Fatalf("%s printed %q before the error", name, out)
+		}
+	}
+}
+
+func TestVarBindingsAndShadowing(t *testing.T) {
+	source := `fun void main() {
+  var total = 1
+  total = 2
+  val x = 1
+  if true {
+    var x = 5
+    x++
+    print(x)
+  }
+  print(x)
+  print(total)
+}
+`
+	if got := runSource(t, source); got != "6\n1\n2" {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -0,0 +1,127 @@
+package interpreter
+
+import (
+	"context"
+	"fmt"
+
+	"glyph-cli/ast"
+)
+
+// DefaultMaxDepth is the call depth allowed when Options.MaxDepth is 0.
+// It keeps runaway recursion from exhausting the Go stack.
+const DefaultMaxDepth = 10000
+
+// StepLimitError reports a program that took more than Options.MaxSteps
+// steps.
+type StepLimitError struct {
+	Limit int64
+}
+
+func (e *StepLimitError) Error() string {
+	return fmt.Sprintf("step limit of %d exceeded", e.Limit)
+}
+
+// CallDepthError reports a call that would have nested more than
+// Options.MaxDepth calls.
+type CallDepthError struct {
+	Limit int
+}
+
+func (e *CallDepthError) Error() string {
+	return fmt.Sprintf("call depth limit of %d exceeded", e.Limit)
+}
+
+// DeadlineError reports a program stopped at Options.Deadline or at the
+// deadline of its context.
+type DeadlineError struct{}
+
+func (e *DeadlineError) Error() string {
+	return "deadline exceeded"
+}
+
+func (e *DeadlineError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// CanceledError reports a program stopped because its context was
+// canceled.
+type CanceledError struct{}
+
+func (e *CanceledError) Error() string {
+	return "execution canceled"
+}
+
+func (e *CanceledError) Unwrap() error {
+	return context.Canceled
+}
+
+// checkEvery is how many steps run between looks at the context.
+const checkEvery = 1024
+
+// budget counts the steps of a run and stops it when Options or the
+// context say so. Both backends count the same steps, so a limit stops
+// them at the same place.
+type budget struct {
+	ctx      context.Context
+	maxSteps int64
+	maxDepth int
+	steps    int64
+	// next is the step count at which check runs again.
+	next int64
+}
+
+// newBudget starts the budget of a run. The returned function releases
+// the deadline's timer and must be called once the run is over.
+func newBudget(ctx context.Context, opts Options) (*budget, context.CancelFunc) {
+	cancel := context.CancelFunc(func() {})
+	if !opts.Deadline.IsZero() {
+		ctx, cancel = context.WithDeadline(ctx, opts.Deadline)
+	}
+	b := &budget{ctx: ctx, maxSteps: opts.MaxSteps, maxDepth: opts.MaxDepth}
+	if b.maxDepth <= 0 {
+		b.maxDepth = DefaultMaxDepth
+	}
+	b.next = 1 // look at the context before the first step
+	return b, cancel
+}
+
+// tick counts a step taken at node.
+func (b *budget) tick(node ast.Node) error {
+	b.steps++
+	if b.steps < b.next {
+		return nil
+	}
+	return b.check(node)
+}
+
+func (b *budget) check(node ast.Node) error {
+	if b.maxSteps > 0 && b.steps > b.maxSteps {
+		return causeAt(node, &StepLimitError{Limit: b.maxSteps})
+	}
+	select {
+	case <-b.ctx.Done():
+		if b.ctx.Err() == context.DeadlineExceeded {
+			return causeAt(node, &DeadlineError{})
+		}
+		return causeAt(node, &CanceledError{})
+	default:
+	}
+	b.schedule()
+	return nil
+}
+
+func (b *budget) schedule() {
+	b.next = b.steps + checkEvery
+	if b.maxSteps > 0 && b.next > b.maxSteps+1 {
+		b.next = b.maxSteps + 1
+	}
+}
+
+// enter checks that a call may start while depth calls are in progress;
+// site is the call expression.
+func (b *budget) enter(depth int, site ast.Node) error {
+	if depth < b.maxDepth {
+		return nil
+	}
+	return causeAt(site, &CallDepthError{Limit: b.maxDepth})
+}
@@ -0,0 +1,92 @@
+package interpreter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"glyph-cli/parser"
+)
+
+const spin = `fun void main() {
+  var i = 0
+  while true {
+    print(i)
+    i++
+  }
+}
+`
+
+func TestStepLimitStopsBothBackendsAtTheSameStep(t *testing.T) {
+	program, err := parser.ParseProgramSource("spin.gly", spin)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	out, err := runBothWith(t, program, inlineSymbols(program), Options{MaxSteps: 10})
+	var limit *StepLimitError
+	if !errors.As(err, &limit) || limit.Limit != 10 {
+		t.Fatalf("got %v, want a step limit error", err)
+	}
+	// The var and the while, then a step for each round and one for each
+	// statement in it: the eleventh step is the third round's i++.
+	if out != "0\n1\n2" {
+		t.Fatalf("printed %q", out)
+	}
+}
+
+func TestRunawayRecursionHitsTheCallDepth(t *testing.T) {
+	source := `fun int down(int n) {
+  down(n + 1)
+}
+
+fun void main() {
+  down(0)
+}
+`
+	program, err := parser.ParseProgramSource("deep.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	symbols := inlineSymbols(program)
+	for _, tc := range []struct {
+		opts Options
+		want int
+	}{{Options{}, DefaultMaxDepth}, {Options{MaxDepth: 5}, 5}} {
+		_, err := runBothWith(t, program, symbols, tc.opts)
+		var depth *CallDepthError
+		if !errors.As(err, &depth) || depth.Limit != tc.want {
+			t.Fatalf("got %v, want a call depth error at %d", err, tc.want)
+		}
+		var rerr *RuntimeError
+		if errors.As(err, &rerr) && len(rerr.Frames) != tc.want {
+			t.Fatalf("got %d frames, want %d", len(rerr.Frames), tc.want)
+		}
+	}
+}
+
+func TestDeadlineAndCancellationStopThePrograms(t *testing.T) {
+	program, err := parser.ParseProgramSource("spin.gly", strings.Replace(spin, "print(i)\n", "", 1))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	symbols := inlineSymbols(program)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, run := range []func(context.Context, Options) error{
+		func(ctx context.Context, opts Options) error { return Eval(ctx, program, symbols, opts) },
+		func(ctx context.Context, opts Options) error { return EvalVM(ctx, program, symbols, opts) },
+	} {
+		err := run(context.Background(), Options{Deadline: time.Now().Add(20 * time.Millisecond)})
+		var deadline *DeadlineError
+		if !errors.As(err, &deadline) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("got %v, want a deadline error", err)
+		}
+		err = run(canceled, Options{})
+		var stopped *CanceledError
+		if !errors.As(err, &stopped) || !errors.Is(err, context.Canceled) {
+			t.Fatalf("got %v, want a canceled error", err)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package interpreter
+
+import (
+	"fmt"
+	"strings"
+
+	"glyph-cli/ast"
+	"glyph-cli/project"
+)
+
+// Bytecode is a program compiled for the stack VM. Each function is a flat
+// list of instructions over a frame of local slots and an operand stack.
+// The scopes of a function share its frame: project.ResolveScopes already
+// gives each block its own slots, so the compiler lays them out one after
+// another and a block's variables need no scope at run time. A lambda is a
+// function of its own whose frame holds its parameters, the variables its
+// body declares and the values it captured.
+type Bytecode struct {
+	functions []*function
+	byDecl    map[*ast.FunctionDecl]*function
+	main      *function
+	consts    []interface{} // literal Values, and the names, messages and nodes instructions refer to
+	records   []*ast.RecordDecl
+	variants  []variantRef
+}
+
+type variantRef struct {
+	sum     *ast.SumTypeDecl
+	variant *ast.VariantDecl
+}
+
+// function is the compiled code of a function or lambda.
+type function struct {
+	id     int32 // index in Bytecode.functions
+	name   string
+	decl   *ast.FunctionDecl // nil for a lambda
+	lambda *ast.LambdaExpr
+	params int
+	args   []string // argTypes of decl
+	// symbols are those the body sees, the Declaring symbols of decl or
+	// those of the function creating the lambda.
+	symbols *project.Symbols
+	locals  int // size of the frame
+	code    []instr
+	// nodes holds, for each instruction, the node runtime errors are
+	// reported at.
+	nodes []ast.Node
+	// closures are the lambdas the function creates, indexed by the a
+	// operand of opClosure.
+	closures []*closureCode
+}
+
+// closureCode describes a lambda created in a function.
+type closureCode struct {
+	fn       *function
+	captures []int // frame slots of the captured values
+}
+
+type instr struct {
+	op      opcode
+	a, b, c int32
+}
+
+type opcode uint8
+
+// Operands are written a, b and c. Jump targets are instruction indexes
+// in the current function; k is an index into Bytecode.consts.
+const (
+	opConst            opcode = iota // push consts[a]
+	opNil                            // push null
+	opPop                            // drop the top of the stack
+	opDup                            // push the top of the stack again
+	opDup2                           // push the top two values again
+	opLoad                           // push local a
+	opStore                          // pop into local a
+	opFail                           // fail with the error message consts[a]
+	opJump                           // jump to a
+	opJumpIfFalse                    // pop a bool, jump to a if false; b is the error for a non-bool
+	opJumpIfNil                      // jump to a if the top is null, keeping it
+	opJumpIfNotNil                   // jump to a if the top is not null, keeping it
+	opJumpIfNotClosure               // jump to a if the top is not a closure, keeping it
+	opShortCircuit                   // jump to a keeping the top if it equals bool b, otherwise pop it
+	opCheckBool                      // fail unless the top is a bool; consts[a] is the operator
+	opNot                            // logical not
+	opNeg                            // unary minus
+	opArith                          // binary arithmetic; a is the operator
+	opCompare                        // binary comparison; a is the operator
+	opEqual                          // ==; b is 1 for !=
+	opConcat                         // pop a values and push them formatted and joined
+	opPrint                          // pop and print a value
+	opCoerce                         // convert the top to the declared type consts[a]
+	opStep                           // apply ++ (a = 0) or -- (a = 1) to the top
+	opRecord                         // pop the fields of records[a] and push a new instance
+	opArray                          // pop a size and push a new array
+	opMap                            // pop a key/value pairs and push a new map
+	opField                          // replace the top with its field consts[a]
+	opSafeField                      // like opField, but null yields null
+	opIndex                          // pop an index and a container, push the element
+	opFieldTarget                    // check the top is a record whose field consts[a] is assignable
+	opRecordField                    // replace the record on top with its field consts[a]
+	opSetField                       // pop a value and a record, set field consts[a]
+	opIndexTarget                    // check container and index below the top can be assigned; consts[a] is the IndexAccess
+	opElement                        // pop a key and a container, push the element
+	opSetElement                     // pop a value, a key and a container, store the value
+	opClosure                        // push a closure for closures[a]
+	opCall                           // call functions[a] with b arguments
+	opCallValue                      // call the closure below b arguments
+	opLookupMember                   // replace the receiver with the index of the member function consts[a] and the receiver
+	opCallMember                     // call the function below b arguments
+	opVariant                        // pop b arguments and construct variants[a]
+	opRange                          // pop a arguments and push range(...)
+	opReturn                         // return the top from the current function
+	opIterItems                      // pop a target and store its items in local a, with 0 in local a+1
+	opIterNext                       // push the next item of local a, counting in local b, or jump to c when done
+	opMark                           // record the stack height in local a
+	opUnwind                         // restore the stack height recorded in local a
+	opMatchLiteral                   // jump to c unless local a equals consts[b]
+	opMatchRecord                    // jump to c unless local a is a record named consts[b]
+	opMatchField                     // push field consts[b] of record local a, or jump to c when it has none
+	opMatchVariant                   // jump to c unless local a is variants[b]
+	opVariantValue                   // push value b of the variant in local a
+	opTick                           // count a step against the budget
+)
+
+var opNames = [...]string{
+	opConst: "const", opNil: "nil", opPop: "pop", opDup: "dup", opDup2: "dup2",
+	opLoad: "load", opStore: "store", opFail: "fail", opJump: "jump",
+	opJumpIfFalse: "jump_if_false", opJumpIfNil: "jump_if_nil", opJumpIfNotNil: "jump_if_not_nil",
+	opJumpIfNotClosure: "jump_if_not_closure", opShortCircuit: "short_circuit", opCheckBool: "check_bool",
+	opNot: "not", opNeg: "neg", opArith: "arith", opCompare: "compare", opEqual: "equal",
+	opConcat: "concat", opPrint: "print", opCoerce: "coerce", opStep: "step",
+	opRecord: "record", opArray: "array", opMap: "map", opField: "field", opSafeField: "safe_field",
+	opIndex: "index", opFieldTarget: "field_target", opRecordField: "record_field", opSetField: "set_field",
+	opIndexTarget: "index_target", opElement: "element", opSetElement: "set_element",
+	opClosure: "closure", opCall: "call", opCallValue: "call_value", opLookupMember: "lookup_member",
+	opCallMember: "call_member", opVariant: "variant", opRange: "range", opReturn: "return",
+	opIterItems: "iter_items", opIterNext: "iter_next", opMark: "mark", opUnwind: "unwind",
+	opMatchLiteral: "match_literal", opMatchRecord: "match_record", opMatchField: "match_field",
+	opMatchVariant: "match_variant", opVariantValue: "variant_value", opTick: "tick",
+}
+
+func (op opcode) String() string {
+	if int(op) < len(opNames) && opNames[op] != "" {
+		return opNames[op]
+	}
+	return fmt.Sprintf("op(%d)", op)
+}
+
+// Operators of opArith and opCompare.
+var binaryOps = []string{"+", "-", "*", "/", "%", "<", "<=", ">", ">="}
+
+func binaryOp(op string) int32 {
+	for i, name := range binaryOps {
+		if name == op {
+			return int32(i)
+		}
+	}
+	return -1
+}
+
+// String disassembles the bytecode, one function after another.
+func (b *Bytecode) String() string {
+	var out strings.Builder
+	for _, fn := range b.functions {
+		fmt.Fprintf(&out, "%s (%d params, %d locals):\n", fn.name, fn.params, fn.locals)
+		for i, in := range fn.code {
+			fmt.Fprintf(&out, "  %4d  %-20s %d %d %d\n", i, in.op, in.a, in.b, in.c)
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,497 @@
+package interpreter
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"glyph-cli/ast"
+	"glyph-cli/project"
+)
+
+// Func is a Glyph function that Go code calls with Go values, as Lookup
+// found it.
+type Func struct {
+	// Name is the function's qualified name.
+	Name    string
+	decl    *ast.FunctionDecl
+	symbols *project.Symbols
+}
+
+// Lookup finds the function with the qualified name name, such as
+// rules.pricing.discount, in idx and resolves the program that declares
+// it. Resolving updates the program's AST, so look up every function
+// before calling any of them from several goroutines.
+func Lookup(idx *project.Index, name string) (*Func, error) {
+	if idx == nil {
+		return nil, fmt.Errorf("index is nil")
+	}
+	decl, ok := idx.Functions[name]
+	if !ok {
+		return nil, fmt.Errorf("function %s not found", name)
+	}
+	program := idx.Declaring(decl)
+	if program == nil {
+		return nil, fmt.Errorf("function %s is not declared by a program of the index", name)
+	}
+	symbols, err := project.Resolve(program, idx)
+	if err != nil {
+		return nil, err
+	}
+	return &Func{Name: name, decl: decl, symbols: symbols}, nil
+}
+
+// Call calls fn on the interpreter with args and returns its result, nil
+// for a void function. Each argument is converted to the type of its
+// parameter:
+//
+//   - Go integers and floats become int, long, char, float or double
+//     values, if they fit
+//   - bools and strings stay as they are
+//   - slices and arrays become arrays, and maps become maps
+//   - structs, and maps with string keys, become records. A record field
+//     takes the struct field tagged `glyph:"name"` or else the one with
+//     its name, ignoring case.
+//   - a Variant becomes the variant of the sum type it names, its Fields
+//     passed to the constructor by name
+//   - nil, nil pointers and nil interfaces become null
+//
+// Pointers and interfaces are followed to the values they hold. The result
+// is an int, int64, float32, float64, rune, bool or string, or a
+// []interface{}, map[interface{}]interface{}, map[string]interface{} or
+// Variant for an array, map, record or variant; a map whose keys are
+// arrays, maps, records or variants has no Go equivalent and is an error. The function runs with the limits
+// of rt, and a failure in it is a *RuntimeError, as from Eval.
+func (rt *Runtime) Call(ctx context.Context, fn *Func, args ...interface{}) (interface{}, error) {
+	result, err := rt.call(ctx, fn, args)
+	if err != nil {
+		return nil, err
+	}
+	out, err := goValue(result)
+	if err != nil {
+		return nil, fmt.Errorf("result of %s: %v", fn.Name, err)
+	}
+	return out, nil
+}
+
+// CallInto calls fn like Call and stores its result in the value out
+// points to. Arrays are stored in slices, maps in maps and records in
+// structs, matching fields as Call does, and variants in Variants. Null
+// stores the zero value.
+func (rt *Runtime) CallInto(ctx context.Context, fn *Func, out interface{}, args ...interface{}) error {
+	dst := reflect.ValueOf(out)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() {
+		return fmt.Errorf("CallInto needs a non-nil pointer, got %T", out)
+	}
+	result, err := rt.call(ctx, fn, args)
+	if err != nil {
+		return err
+	}
+	if err := storeGo(result, dst.Elem()); err != nil {
+		return fmt.Errorf("result of %s: %v", fn.Name, err)
+	}
+	return nil
+}
+
+func (rt *Runtime) call(ctx context.Context, fn *Func, args []interface{}) (Value, error) {
+	if fn == nil {
+		return nil, fmt.Errorf("function must not be nil")
+	}
+	params := fn.decl.Params
+	if len(args) != len(params) {
+		return nil, fmt.Errorf("function %s expects %d argument(s) but received %d", fn.Name, len(params), len(args))
+	}
+	values := make([]Value, len(args))
+	for i, arg := range args {
+		v, err := fn.glyphValue(reflect.ValueOf(arg), params[i].Type)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d of %s: %v", i+1, fn.Name, err)
+		}
+		values[i] = v
+	}
+	budget, done := newBudget(ctx, rt.opts)
+	defer done()
+	return invokeFunction(fn.decl, values, nil, rt.newState(fn.symbols, budget))
+}
+
+// glyphValue converts the Go value v to a Glyph value of type t.
+func (fn *Func) glyphValue(v reflect.Value, t string) (Value, error) {
+	t = fn.symbols.ExpandAlias(t)
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	nullable := strings.HasSuffix(t, "?")
+	t = strings.TrimSuffix(t, "?")
+	if !v.IsValid() || v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface ||
+		(nullable && (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil()) {
+		if nullable {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("null is not a %s", t)
+	}
+	switch t {
+	case "int":
+		n, err := goInt(v, t)
+		return intValue(n), err
+	case "long":
+		n, err := goInt(v, t)
+		return longValue(n), err
+	case "char":
+		n, err := goInt(v, t)
+		return charValue(n), err
+	case "float":
+		f, err := goFloat(v)
+		return floatValue(f), err
+	case "double":
+		f, err := goFloat(v)
+		return doubleValue(f), err
+	case "bool":
+		if v.Kind() == reflect.Bool {
+			return boolValue(v.Bool()), nil
+		}
+	case "string":
+		if v.Kind() == reflect.String {
+			return stringValue(v.String()), nil
+		}
+	}
+	if key, value, ok := project.MapType(t); ok && v.Kind() == reflect.Map {
+		out := newMap(v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			k, err := fn.glyphValue(iter.Key(), key)
+			if err != nil {
+				return nil, fmt.Errorf("key %v: %v", iter.Key(), err)
+			}
+			val, err := fn.glyphValue(iter.Value(), value)
+			if err != nil {
+				return nil, fmt.Errorf("key %v: %v", iter.Key(), err)
+			}
+			out.set(k, val)
+		}
+		return out, nil
+	}
+	if elem, ok := project.ArrayType(t); ok && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) {
+		out := make(arrayValue, v.Len())
+		for i := range out {
+			val, err := fn.glyphValue(v.Index(i), elem)
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %v", i, err)
+			}
+			out[i] = val
+		}
+		return out, nil
+	}
+	if sum, ok := fn.symbols.SumTypes[t]; ok && v.Type() == variantType {
+		return fn.variantValue(sum, v.Interface().(Variant))
+	}
+	if rec, ok := fn.symbols.Records[t]; ok && (v.Kind() == reflect.Struct || v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String) {
+		values := make([]Value, len(rec.Fields))
+		for i, field := range rec.Fields {
+			val, err := fn.glyphValue(goField(v, field.Name), field.Type)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %v", field.Name, err)
+			}
+			values[i] = val
+		}
+		return newRecord(rec, values), nil
+	}
+	return nil, fmt.Errorf("cannot pass %s as %s", v.Type(), t)
+}
+
+// Variant is a value of a Glyph sum type as Call passes and returns it:
+// the name of the variant and its fields by name. Type, the name of the
+// sum type, is filled in for results; the parameter's type is used for
+// arguments.
+type Variant struct {
+	Type   string
+	Name   string
+	Fields map[string]interface{}
+}
+
+var variantType = reflect.TypeOf(Variant{})
+
+// variantValue constructs the variant of sum that given names.
+func (fn *Func) variantValue(sum *ast.SumTypeDecl, given Variant) (Value, error) {
+	for _, variant := range sum.Variants {
+		if variant.Name != given.Name {
+			continue
+		}
+		values := make([]Value, len(variant.Fields))
+		for i, field := range variant.Fields {
+			val, err := fn.glyphValue(reflect.ValueOf(given.Fields[field.Name]), field.Type)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %v", field.Name, err)
+			}
+			values[i] = val
+		}
+		return constructVariant(sum, variant, values)
+	}
+	return nil, fmt.Errorf("%s is not a variant of %s", given.Name, sum.Name)
+}
+
+// goInt returns the Go integer v if it is in the range of the Glyph
+// integer type t.
+func goInt(v reflect.Value, t string) (int64, error) {
+	var n int64
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("%d is out of range for %s", v.Uint(), t)
+		}
+		n = int64(v.Uint())
+	default:
+		return 0, fmt.Errorf("cannot pass %s as an integer", v.Type())
+	}
+	if t != "long" && (n < math.MinInt32 || n > math.MaxInt32) {
+		return 0, fmt.Errorf("%d is out of range for %s", n, t)
+	}
+	return n, nil
+}
+
+// goFloat returns the Go number v as a float64.
+func goFloat(v reflect.Value) (float64, error) {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), nil
+	}
+	return 0, fmt.Errorf("cannot pass %s as a number", v.Type())
+}
+
+// goField finds the value for the record field name in the struct or
+// string-keyed map v. It is invalid when there is none.
+func goField(v reflect.Value, name string) reflect.Value {
+	if v.Kind() == reflect.Map {
+		return v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+	}
+	if i, ok := structField(v.Type(), name); ok {
+		return v.Field(i)
+	}
+	return reflect.Value{}
+}
+
+// structField finds the exported field of the struct type t that holds
+// the record field name: the one tagged `glyph:"name"`, or else the one
+// called name, ignoring case.
+func structField(t reflect.Type, name string) (int, bool) {
+	folded := -1
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		if tag, ok := f.Tag.Lookup("glyph"); ok {
+			if tag == name {
+				return i, true
+			}
+			continue
+		}
+		if folded < 0 && strings.EqualFold(f.Name, name) {
+			folded = i
+		}
+	}
+	return folded, folded >= 0
+}
+
+// goValue converts the Glyph value v to Go as Call describes.
+func goValue(v Value) (interface{}, error) {
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case intValue:
+		return int(val), nil
+	case longValue:
+		return int64(val), nil
+	case floatValue:
+		return float32(val), nil
+	case doubleValue:
+		return float64(val), nil
+	case charValue:
+		return rune(val), nil
+	case boolValue:
+		return bool(val), nil
+	case stringValue:
+		return string(val), nil
+	case arrayValue:
+		out := make([]interface{}, len(val))
+		for i, elem := range val {
+			g, err := goValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = g
+		}
+		return out, nil
+	case *mapValue:
+		out := make(map[interface{}]interface{}, val.count)
+		for _, k := range val.keys() {
+			key, err := goValue(k)
+			if err != nil {
+				return nil, err
+			}
+			if key != nil && !reflect.TypeOf(key).Comparable() {
+				return nil, fmt.Errorf("cannot return a map with %s keys to Go", typeName(k))
+			}
+			elem, _ := val.get(k)
+			g, err := goValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = g
+		}
+		return out, nil
+	case *recordInstance:
+		out := make(map[string]interface{}, len(val.fields))
+		for name, field := range val.fields {
+			g, err := goValue(field)
+			if err != nil {
+				return nil, err
+			}
+			out[name] = g
+		}
+		return out, nil
+	case *variantInstance:
+		fields := make(map[string]interface{}, len(val.fields))
+		for i, name := range val.fields {
+			g, err := goValue(val.values[i])
+			if err != nil {
+				return nil, err
+			}
+			fields[name] = g
+		}
+		return Variant{Type: val.sumType, Name: val.variant, Fields: fields}, nil
+	}
+	return nil, fmt.Errorf("cannot return a %s to Go", typeName(v))
+}
+
+// storeGo stores the Glyph value v in dst as CallInto describes.
+func storeGo(v Value, dst reflect.Value) error {
+	if v == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Interface:
+		g, err := goValue(v)
+		if err != nil {
+			return err
+		}
+		if rv := reflect.ValueOf(g); rv.Type().AssignableTo(dst.Type()) {
+			dst.Set(rv)
+			return nil
+		}
+	case reflect.Ptr:
+		p := reflect.New(dst.Type().Elem())
+		if err := storeGo(v, p.Elem()); err != nil {
+			return err
+		}
+		dst.Set(p)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if kind, ok := numericKind(v); ok && kind <= kindLong {
+			if n := asInt64(v); !dst.OverflowInt(n) {
+				dst.SetInt(n)
+				return nil
+			}
+			return fmt.Errorf("%v is out of range for %s", v, dst.Type())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if kind, ok := numericKind(v); ok && kind <= kindLong {
+			if n := asInt64(v); n >= 0 && !dst.OverflowUint(uint64(n)) {
+				dst.SetUint(uint64(n))
+				return nil
+			}
+			return fmt.Errorf("%v is out of range for %s", v, dst.Type())
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := numericKind(v); ok {
+			dst.SetFloat(asFloat64(v))
+			return nil
+		}
+	case reflect.Bool:
+		if b, ok := v.(boolValue); ok {
+			dst.SetBool(bool(b))
+			return nil
+		}
+	case reflect.String:
+		if s, ok := v.(stringValue); ok {
+			dst.SetString(string(s))
+			return nil
+		}
+	case reflect.Slice:
+		if elems, ok := v.(arrayValue); ok {
+			out := reflect.MakeSlice(dst.Type(), len(elems), len(elems))
+			for i, elem := range elems {
+				if err := storeGo(elem, out.Index(i)); err != nil {
+					return fmt.Errorf("element %d: %v", i, err)
+				}
+			}
+			dst.Set(out)
+			return nil
+		}
+	case reflect.Map:
+		switch val := v.(type) {
+		case *mapValue:
+			out := reflect.MakeMapWithSize(dst.Type(), val.count)
+			for _, k := range val.keys() {
+				elem, _ := val.get(k)
+				key := reflect.New(dst.Type().Key()).Elem()
+				if err := storeGo(k, key); err != nil {
+					return fmt.Errorf("key %v: %v", k, err)
+				}
+				if key.Kind() == reflect.Interface && !key.IsNil() && !key.Elem().Type().Comparable() {
+					return fmt.Errorf("key %v: %s cannot be a key of %s", k, typeName(k), dst.Type())
+				}
+				value := reflect.New(dst.Type().Elem()).Elem()
+				if err := storeGo(elem, value); err != nil {
+					return fmt.Errorf("key %v: %v", k, err)
+				}
+				out.SetMapIndex(key, value)
+			}
+			dst.Set(out)
+			return nil
+		case *recordInstance:
+			if dst.Type().Key().Kind() == reflect.String {
+				out := reflect.MakeMapWithSize(dst.Type(), len(val.fields))
+				for name, field := range val.fields {
+					value := reflect.New(dst.Type().Elem()).Elem()
+					if err := storeGo(field, value); err != nil {
+						return fmt.Errorf("field %s: %v", name, err)
+					}
+					out.SetMapIndex(reflect.ValueOf(name).Convert(dst.Type().Key()), value)
+				}
+				dst.Set(out)
+				return nil
+			}
+		}
+	case reflect.Struct:
+		if _, ok := v.(*variantInstance); ok && dst.Type() == variantType {
+			g, err := goValue(v)
+			if err != nil {
+				return err
+			}
+			dst.Set(reflect.ValueOf(g))
+			return nil
+		}
+		if rec, ok := v.(*recordInstance); ok {
+			for name, field := range rec.fields {
+				i, ok := structField(dst.Type(), name)
+				if !ok {
+					continue
+				}
+				if err := storeGo(field, dst.Field(i)); err != nil {
+					return fmt.Errorf("field %s: %v", name, err)
+				}
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot store a %s in %s", typeName(v), dst.Type())
+}
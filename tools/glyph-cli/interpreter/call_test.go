@@ -0,0 +1,216 @@
+package interpreter
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"glyph-cli/project"
+)
+
+const pricing = `package rules.pricing
+
+record Item {
+  string name
+  int quantity
+  double price
+}
+
+record Quote {
+  double total
+  [string: int] counts
+  string? note
+}
+
+fun double discount(double total, bool member) {
+  if member {
+    total * 0.5
+  } else {
+    total
+  }
+}
+
+fun Quote quote([Item] items, string? note) {
+  var total = 0.0
+  val counts = [string: int] {}
+  items.each {
+    total += it.price * it.quantity
+    counts[it.name] = it.quantity
+  }
+  Quote { total = total, counts = counts, note = note }
+}
+
+fun [string] names([Item] items) {
+  var n = 0
+  items.each {
+    n += 1
+  }
+  val out = [string] (n)
+  items.withIndex {
+    out[it.index] = it.value.name
+  }
+  out
+}
+
+fun int ratio(int a, int b) {
+  a / b
+}
+
+fun void nothing() {
+}
+
+type Grade = Pass(score: int) | Fail(reason: string)
+
+fun Grade grade(int score) {
+  if score >= 50 {
+    Pass(score)
+  } else {
+    Fail("too low")
+  }
+}
+
+fun int retake(Grade g) {
+  return match g {
+    Pass(s) -> s
+    Fail(_) -> 0
+  } else 0
+}
+`
+
+func lookup(t *testing.T, name string) *Func {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "pricing.gly"), []byte(pricing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	idx, err := project.BuildIndex(dir)
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	fn, err := Lookup(idx, name)
+	if err != nil {
+		t.Fatalf("lookup: %v", err)
+	}
+	return fn
+}
+
+type item struct {
+	Name  string
+	Qty   int64 `glyph:"quantity"`
+	Price float64
+}
+
+func TestCallConvertsGoValues(t *testing.T) {
+	rt := NewRuntime(Options{})
+	ctx := context.Background()
+
+	got, err := rt.Call(ctx, lookup(t, "rules.pricing.discount"), 80, true)
+	if err != nil || got != 40.0 {
+		t.Fatalf("discount: got %v, %v", got, err)
+	}
+
+	items := []item{{"pen", 2, 1.5}, {"pad", 1, 4}}
+	got, err = rt.Call(ctx, lookup(t, "rules.pricing.quote"), items, nil)
+	if err != nil {
+		t.Fatalf("quote: %v", err)
+	}
+	want := map[string]interface{}{
+		"total":  7.0,
+		"counts": map[interface{}]interface{}{"pen": 2, "pad": 1},
+		"note":   nil,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("quote: got %#v, want %#v", got, want)
+	}
+
+	var quote struct {
+		Total  float64
+		Counts map[string]int
+		Note   *string
+	}
+	note := "rush"
+	err = rt.CallInto(ctx, lookup(t, "rules.pricing.quote"), &quote, []map[string]interface{}{{"name": "ink", "quantity": 3, "price": 2}}, &note)
+	if err != nil {
+		t.Fatalf("quote into struct: %v", err)
+	}
+	if quote.Total != 6 || quote.Counts["ink"] != 3 || quote.Note == nil || *quote.Note != "rush" {
+		t.Fatalf("quote into struct: got %+v", quote)
+	}
+
+	var names []string
+	if err := rt.CallInto(ctx, lookup(t, "rules.pricing.names"), &names, items); err != nil {
+		t.Fatalf("names: %v", err)
+	}
+	if strings.Join(names, ",") != "pen,pad" {
+		t.Fatalf("names: got %v", names)
+	}
+
+	if got, err := rt.Call(ctx, lookup(t, "rules.pricing.nothing")); got != nil || err != nil {
+		t.Fatalf("nothing: got %v, %v", got, err)
+	}
+}
+
+func TestCallPassesVariants(t *testing.T) {
+	rt := NewRuntime(Options{})
+	ctx := context.Background()
+
+	got, err := rt.Call(ctx, lookup(t, "rules.pricing.grade"), 70)
+	want := Variant{Type: "Grade", Name: "Pass", Fields: map[string]interface{}{"score": 70}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Fatalf("grade: got %#v, %v", got, err)
+	}
+
+	var failed Variant
+	if err := rt.CallInto(ctx, lookup(t, "rules.pricing.grade"), &failed, 10); err != nil {
+		t.Fatalf("grade into Variant: %v", err)
+	}
+	if failed.Name != "Fail" || failed.Fields["reason"] != "too low" {
+		t.Fatalf("grade into Variant: got %+v", failed)
+	}
+
+	got, err = rt.Call(ctx, lookup(t, "rules.pricing.retake"), Variant{Name: "Pass", Fields: map[string]interface{}{"score": 3}})
+	if err != nil || got != 3 {
+		t.Fatalf("retake: got %v, %v", got, err)
+	}
+}
+
+func TestCallReportsErrors(t *testing.T) {
+	rt := NewRuntime(Options{})
+	ctx := context.Background()
+	cases := []struct {
+		name string
+		fn   string
+		args []interface{}
+		want string
+	}{
+		{"arity", "rules.pricing.ratio", []interface{}{1}, "function rules.pricing.ratio expects 2 argument(s) but received 1"},
+		{"type", "rules.pricing.ratio", []interface{}{"1", 2}, "argument 1 of rules.pricing.ratio: cannot pass string as an integer"},
+		{"range", "rules.pricing.ratio", []interface{}{int64(1) << 40, 2}, "argument 1 of rules.pricing.ratio: 1099511627776 is out of range for int"},
+		{"null", "rules.pricing.discount", []interface{}{nil, true}, "argument 1 of rules.pricing.discount: null is not a double"},
+		{"field", "rules.pricing.names", []interface{}{[]struct{ Name int }{{1}}}, "argument 1 of rules.pricing.names: element 0: field name: cannot pass int as string"},
+		{"runtime", "rules.pricing.ratio", []interface{}{1, 0}, "division by zero"},
+		{"variant", "rules.pricing.retake", []interface{}{Variant{Name: "Maybe"}}, "argument 1 of rules.pricing.retake: Maybe is not a variant of Grade"},
+		{"variant field", "rules.pricing.retake", []interface{}{Variant{Name: "Fail"}}, "argument 1 of rules.pricing.retake: field reason: null is not a string"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := rt.Call(ctx, lookup(t, tc.fn), tc.args...)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("got error %v, want %q", err, tc.want)
+			}
+		})
+	}
+
+	_, err := rt.Call(ctx, lookup(t, "rules.pricing.ratio"), 1, 0)
+	var re *RuntimeError
+	if !errors.As(err, &re) || len(re.Frames) != 1 || re.Frames[0].Function != "ratio" || re.Frames[0].Line != 46 {
+		t.Fatalf("got %v, want a runtime error in ratio", err)
+	}
+	if _, err := Lookup(&project.Index{}, "rules.pricing.missing"); err == nil {
+		t.Fatal("looked up a missing function")
+	}
+}
@@ -0,0 +1,79 @@
+package interpreter
+
+import (
+	"testing"
+
+	"glyph-cli/ast"
+)
+
+func TestClosuresCaptureOnlyResolvedVariables(t *testing.T) {
+	outer := newEnv(nil, 2)
+	outer.slots[0] = make(arrayValue, 1<<16)
+	outer.slots[1] = intValue(2)
+	inner := newEnv(outer, 1)
+	inner.slots[0] = intValue(0)
+
+	lambda := &ast.LambdaExpr{
+		Body:         &ast.Block{},
+		Captures:     []string{"n", "count"},
+		CaptureSlots: []*ast.Slot{{Depth: 1, Index: 1, Kind: "val"}, {Depth: 0, Index: 0, Kind: "var"}},
+		Slots:        2,
+	}
+	v, err := evalLambda(lambda, inner, &state{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	captured := v.(*closureValue).captured
+	if len(captured) != 2 || captured[0] != intValue(2) || captured[1] != intValue(0) {
+		t.Fatalf("unexpected captured values %v", captured)
+	}
+}
+
+func TestClosuresCaptureValuesWhenCreated(t *testing.T) {
+	source := `fun void main() {
+  var n = 1
+  val show = fun () { print(n) }
+  val bump = fun () {
+    n++
+    print(n)
+  }
+  n = 5
+  show()
+  bump()
+  bump()
+  print(n)
+  var count = 0
+  val next = fun int () {
+    count++
+    val inner = fun int () { count * 10 }
+    inner()
+  }
+  print(next())
+  print(next())
+}
+`
+	if got := runSource(t, source); got != "1\n2\n2\n5\n10\n10" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestNestedClosures(t *testing.T) {
+	source := `fun void main() {
+  val int offset = 7
+  var hits = 0
+  val outer = fun int (int base) {
+    val inner = fun int (int value) { value + base + offset }
+    inner(1)
+  }
+  val xs = [int](3)
+  xs.each {
+    hits++
+  }
+  print(outer(10))
+  print(hits)
+}
+`
+	if got := runSource(t, source); got != "18\n3" {
+		t.Fatalf("got %q", got)
+	}
+}
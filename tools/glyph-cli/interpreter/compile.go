@@ -0,0 +1,695 @@
+package interpreter
+
+import (
+	"fmt"
+	"sort"
+
+	"glyph-cli/ast"
+	"glyph-cli/project"
+)
+
+// Compile translates the functions of symbols into bytecode for the VM.
+// Like Eval it expects symbols from project.Resolve. Mistakes that Eval
+// only reports when the code runs, such as a call to an unknown function,
+// compile to instructions that fail in the same way, so both backends
+// behave alike.
+func Compile(program *ast.Program, symbols *project.Symbols) (*Bytecode, error) {
+	if symbols == nil {
+		return nil, fmt.Errorf("symbols must not be nil")
+	}
+	a := &assembler{
+		b:        &Bytecode{byDecl: map[*ast.FunctionDecl]*function{}},
+		consts:   map[interface{}]int32{},
+		records:  map[*ast.RecordDecl]int32{},
+		variants: map[*ast.VariantDecl]int32{},
+		declared: map[*project.Symbols]bool{},
+	}
+	a.declareAll(symbols)
+	// Compiling a function imported from another package declares the
+	// functions its package sees, so pending grows as it is worked through.
+	for i := 0; i < len(a.pending); i++ {
+		fn := a.pending[i]
+		if !fn.decl.Resolved {
+			return nil, fmt.Errorf("function %s has not been resolved (see project.ResolveScopes)", fn.decl.Name)
+		}
+		if fn.decl.Extern {
+			continue // the VM calls the native instead
+		}
+		c := &compiler{a: a, fn: fn, scopes: []int32{0}}
+		c.reserve(int32(fn.decl.Slots))
+		c.block(fn.decl.Body, true)
+		c.emit(fn.decl, opReturn, 0, 0, 0)
+	}
+	a.b.main = a.b.byDecl[symbols.Functions["main"]]
+	return a.b, nil
+}
+
+// declareAll declares every function symbols makes visible, in name
+// order, so that member calls looked up at run time find them compiled.
+func (a *assembler) declareAll(symbols *project.Symbols) {
+	if a.declared[symbols] {
+		return
+	}
+	a.declared[symbols] = true
+	names := make([]string, 0, len(symbols.Functions))
+	for name := range symbols.Functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		a.declare(symbols, symbols.Functions[name])
+	}
+}
+
+// declare returns the function compiled from decl, a function visible
+// through symbols, queueing it to be compiled the first time.
+func (a *assembler) declare(symbols *project.Symbols, decl *ast.FunctionDecl) *function {
+	if fn, ok := a.b.byDecl[decl]; ok {
+		return fn
+	}
+	fn := a.function(decl.Name, len(decl.Params))
+	fn.decl = decl
+	fn.symbols = symbols.Declaring(decl)
+	fn.args = argTypes(fn.symbols, decl)
+	a.b.byDecl[decl] = fn
+	a.pending = append(a.pending, fn)
+	if fn.symbols != symbols {
+		a.declareAll(fn.symbols)
+	}
+	return fn
+}
+
+// assembler holds what the functions of one Bytecode share.
+type assembler struct {
+	b        *Bytecode
+	consts   map[interface{}]int32
+	declared map[*project.Symbols]bool
+	pending  []*function // declared functions, compiled in this order
+	records  map[*ast.RecordDecl]int32
+	variants map[*ast.VariantDecl]int32
+}
+
+func (a *assembler) function(name string, params int) *function {
+	fn := &function{id: int32(len(a.b.functions)), name: name, params: params}
+	a.b.functions = append(a.b.functions, fn)
+	return fn
+}
+
+func (a *assembler) konst(v interface{}) int32 {
+	if i, ok := a.consts[v]; ok {
+		return i
+	}
+	i := int32(len(a.b.consts))
+	a.b.consts = append(a.b.consts, v)
+	a.consts[v] = i
+	return i
+}
+
+func (a *assembler) record(rec *ast.RecordDecl) int32 {
+	if i, ok := a.records[rec]; ok {
+		return i
+	}
+	i := int32(len(a.b.records))
+	a.b.records = append(a.b.records, rec)
+	a.records[rec] = i
+	return i
+}
+
+func (a *assembler) variant(sum *ast.SumTypeDecl, variant *ast.VariantDecl) int32 {
+	if i, ok := a.variants[variant]; ok {
+		return i
+	}
+	i := int32(len(a.b.variants))
+	a.b.variants = append(a.b.variants, variantRef{sum: sum, variant: variant})
+	a.variants[variant] = i
+	return i
+}
+
+// compiler compiles the body of one function or lambda.
+type compiler struct {
+	a      *assembler
+	fn     *function
+	scopes []int32 // first frame slot of each open scope, innermost last
+	top    int32   // first free frame slot
+	loops  []*loopLabels
+}
+
+type loopLabels struct {
+	head   int
+	mark   int32 // slot holding the stack height at the loop
+	breaks []int
+}
+
+func (c *compiler) emit(node ast.Node, op opcode, a, b, cc int32) int {
+	c.fn.code = append(c.fn.code, instr{op: op, a: a, b: b, c: cc})
+	c.fn.nodes = append(c.fn.nodes, node)
+	return len(c.fn.code) - 1
+}
+
+// fail compiles an instruction that fails with the error Eval reports.
+func (c *compiler) fail(err error) {
+	c.emit(nil, opFail, c.a.konst(runtimeError(err)), 0, 0)
+}
+
+// patch points the jump at instruction at to the next instruction.
+func (c *compiler) patch(at int) {
+	target := int32(len(c.fn.code))
+	switch in := &c.fn.code[at]; in.op {
+	case opIterNext, opMatchLiteral, opMatchRecord, opMatchField, opMatchVariant:
+		in.c = target
+	default:
+		in.a = target
+	}
+}
+
+// reserve takes n frame slots and returns the first.
+func (c *compiler) reserve(n int32) int32 {
+	first := c.top
+	c.top += n
+	if c.top > int32(c.fn.locals) {
+		c.fn.locals = int(c.top)
+	}
+	return first
+}
+
+// open starts a scope of n slots; close ends it and frees its slots along
+// with any reserved after it.
+func (c *compiler) open(n int) {
+	c.scopes = append(c.scopes, c.reserve(int32(n)))
+}
+
+func (c *compiler) close() {
+	c.top = c.scopes[len(c.scopes)-1]
+	c.scopes = c.scopes[:len(c.scopes)-1]
+}
+
+// slot returns the frame slot of a resolved variable.
+func (c *compiler) slot(s *ast.Slot) int32 {
+	return c.scopes[len(c.scopes)-1-s.Depth] + int32(s.Index)
+}
+
+// local returns the frame slot of index in the innermost scope.
+func (c *compiler) local(index int) int32 {
+	return c.scopes[len(c.scopes)-1] + int32(index)
+}
+
+// block compiles a block. With value set it leaves the block's value on
+// the stack: that of a final expression statement, and null otherwise.
+func (c *compiler) block(b *ast.Block, value bool) {
+	if b.Slots > 0 {
+		c.open(b.Slots)
+		defer c.close()
+	}
+	for i, stmt := range b.Statements {
+		last := value && i == len(b.Statements)-1
+		c.emit(stmt, opTick, 0, 0, 0)
+		if s, ok := stmt.(*ast.ExprStmt); ok {
+			c.expr(s.Expr)
+			if !last {
+				c.emit(s, opPop, 0, 0, 0)
+			}
+			continue
+		}
+		c.stmt(stmt)
+		if last {
+			c.emit(stmt, opNil, 0, 0, 0)
+		}
+	}
+	if value && len(b.Statements) == 0 {
+		c.emit(b, opNil, 0, 0, 0)
+	}
+}
+
+func (c *compiler) stmt(stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case *ast.VarDecl:
+		c.expr(s.Value)
+		if s.Type != "" {
+			c.emit(s, opCoerce, c.a.konst(s.Type), 0, 0)
+		}
+		c.emit(s, opStore, c.local(s.Slot), 0, 0)
+	case *ast.AssignStmt:
+		c.update(s.Target, s, s.Op != "", func() {
+			c.expr(s.Value)
+			if s.Op != "" {
+				c.arith(s, s.Op)
+			}
+		})
+	case *ast.IncDecStmt:
+		c.update(s.Target, s, true, func() {
+			var dec int32
+			if s.Op == "--" {
+				dec = 1
+			}
+			c.emit(s, opStep, dec, 0, 0)
+		})
+	case *ast.WhileStmt:
+		c.while(s)
+	case *ast.BreakStmt:
+		c.jumpOut(s, true)
+	case *ast.ContinueStmt:
+		c.jumpOut(s, false)
+	case *ast.PrintStmt:
+		c.expr(s.Expr)
+		c.emit(s, opPrint, 0, 0, 0)
+	case *ast.ExprStmt:
+		c.expr(s.Expr)
+		c.emit(s, opPop, 0, 0, 0)
+	case *ast.ReturnStmt:
+		if s.Expr != nil {
+			c.expr(s.Expr)
+		} else {
+			c.emit(s, opNil, 0, 0, 0)
+		}
+		c.emit(s, opReturn, 0, 0, 0)
+	default:
+		c.fail(fmt.Errorf("unsupported statement %T", s))
+	}
+}
+
+// update compiles a store into target. value compiles the new value; when
+// read is set it finds the value currently stored on the stack.
+func (c *compiler) update(target ast.Expr, stmt ast.Node, read bool, value func()) {
+	switch t := target.(type) {
+	case *ast.VarRef:
+		if t.Slot == nil {
+			c.fail(errorAt(t, "undefined variable %s", t.Name))
+			return
+		}
+		if t.Slot.Kind != "var" {
+			c.fail(errorAt(stmt, "cannot reassign %s %s", t.Slot.Kind, t.Name))
+			return
+		}
+		slot := c.slot(t.Slot)
+		if read {
+			c.emit(t, opLoad, slot, 0, 0)
+		}
+		value()
+		c.emit(stmt, opStore, slot, 0, 0)
+	case *ast.FieldAccess:
+		c.expr(t.Target)
+		field := c.a.konst(t.Field)
+		c.emit(stmt, opFieldTarget, field, 0, 0)
+		if read {
+			c.emit(stmt, opDup, 0, 0, 0)
+			c.emit(stmt, opRecordField, field, 0, 0)
+		}
+		value()
+		c.emit(stmt, opSetField, field, 0, 0)
+	case *ast.IndexAccess:
+		c.expr(t.Target)
+		c.expr(t.Index)
+		c.emit(stmt, opIndexTarget, c.a.konst(t), 0, 0)
+		if read {
+			c.emit(stmt, opDup2, 0, 0, 0)
+			c.emit(stmt, opElement, 0, 0, 0)
+		}
+		value()
+		c.emit(stmt, opSetElement, 0, 0, 0)
+	default:
+		c.fail(errorAt(stmt, "invalid assignment target"))
+	}
+}
+
+func (c *compiler) arith(node ast.Node, op string) {
+	if i := binaryOp(op); i >= 0 {
+		c.emit(node, opArith, i, 0, 0)
+		return
+	}
+	c.fail(errorAt(node, "unknown numeric operator %s", op))
+}
+
+// while compiles a loop. The stack height is recorded before the loop so
+// that break and continue can leave an expression half evaluated.
+func (c *compiler) while(s *ast.WhileStmt) {
+	mark := c.reserve(1)
+	defer func() { c.top = mark }()
+	c.emit(s, opMark, mark, 0, 0)
+	loop := &loopLabels{head: len(c.fn.code), mark: mark}
+	c.emit(s, opTick, 0, 0, 0)
+	c.expr(s.Condition)
+	exit := c.emit(s.Condition, opJumpIfFalse, 0, c.a.konst("while condition must be bool"), 0)
+	c.loops = append(c.loops, loop)
+	c.block(s.Body, false)
+	c.loops = c.loops[:len(c.loops)-1]
+	c.emit(s, opJump, int32(loop.head), 0, 0)
+	c.patch(exit)
+	for _, at := range loop.breaks {
+		c.patch(at)
+	}
+}
+
+func (c *compiler) jumpOut(s ast.Statement, isBreak bool) {
+	if len(c.loops) == 0 {
+		c.fail(&loopSignal{stmt: s})
+		return
+	}
+	loop := c.loops[len(c.loops)-1]
+	c.emit(s, opUnwind, loop.mark, 0, 0)
+	if isBreak {
+		loop.breaks = append(loop.breaks, c.emit(s, opJump, 0, 0, 0))
+		return
+	}
+	c.emit(s, opJump, int32(loop.head), 0, 0)
+}
+
+func (c *compiler) expr(e ast.Expr) {
+	switch ex := e.(type) {
+	case *ast.IntLiteral, *ast.LongLiteral, *ast.FloatLiteral, *ast.DoubleLiteral, *ast.CharLiteral,
+		*ast.BoolLiteral, *ast.NullLiteral, *ast.StringLiteral:
+		val, err := literalValue(ex)
+		switch {
+		case err != nil:
+			c.fail(err)
+		case val == nil:
+			c.emit(ex, opNil, 0, 0, 0)
+		default:
+			c.emit(ex, opConst, c.a.konst(val), 0, 0)
+		}
+	case *ast.StringTemplate:
+		c.exprs(ex.Parts)
+		c.emit(ex, opConcat, int32(len(ex.Parts)), 0, 0)
+	case *ast.VarRef:
+		if ex.Slot == nil {
+			c.fail(errorAt(ex, "undefined variable %s", ex.Name))
+			return
+		}
+		c.emit(ex, opLoad, c.slot(ex.Slot), 0, 0)
+	case *ast.RecordLiteral:
+		c.recordLiteral(ex)
+	case *ast.FieldAccess:
+		c.expr(ex.Target)
+		c.emit(ex, opField, c.a.konst(ex.Field), 0, 0)
+	case *ast.SafeFieldAccess:
+		c.expr(ex.Target)
+		c.emit(ex, opSafeField, c.a.konst(ex.Field), 0, 0)
+	case *ast.IndexAccess:
+		c.expr(ex.Target)
+		c.expr(ex.Index)
+		c.emit(ex, opIndex, 0, 0, 0)
+	case *ast.ArrayAllocExpr:
+		c.expr(ex.Size)
+		c.emit(ex, opArray, 0, 0, 0)
+	case *ast.MapAllocExpr:
+		c.expr(ex.Capacity)
+		c.emit(ex, opPop, 0, 0, 0)
+		c.emit(ex, opMap, 0, 0, 0)
+	case *ast.MapLiteralExpr:
+		for _, entry := range ex.Entries {
+			c.expr(entry.Key)
+			c.expr(entry.Value)
+		}
+		c.emit(ex, opMap, int32(len(ex.Entries)), 0, 0)
+	case *ast.IfExpr:
+		c.expr(ex.Condition)
+		otherwise := c.emit(ex.Condition, opJumpIfFalse, 0, c.a.konst("if condition must be bool"), 0)
+		c.block(ex.ThenBlock, true)
+		end := c.emit(ex, opJump, 0, 0, 0)
+		c.patch(otherwise)
+		if ex.ElseBlock != nil {
+			c.block(ex.ElseBlock, true)
+		} else {
+			c.emit(ex, opNil, 0, 0, 0)
+		}
+		c.patch(end)
+	case *ast.TernaryExpr:
+		c.expr(ex.Condition)
+		otherwise := c.emit(ex.Condition, opJumpIfFalse, 0, c.a.konst("ternary condition must be bool"), 0)
+		c.expr(ex.IfTrue)
+		end := c.emit(ex, opJump, 0, 0, 0)
+		c.patch(otherwise)
+		c.expr(ex.IfFalse)
+		c.patch(end)
+	case *ast.ElvisExpr:
+		c.expr(ex.Left)
+		end := c.emit(ex, opJumpIfNotNil, 0, 0, 0)
+		c.emit(ex, opPop, 0, 0, 0)
+		c.expr(ex.Right)
+		c.patch(end)
+	case *ast.MatchExpr:
+		c.match(ex)
+	case *ast.CallExpr:
+		c.call(ex)
+	case *ast.MethodCall:
+		c.expr(ex.Receiver)
+		safe := -1
+		if ex.Safe {
+			safe = c.emit(ex, opJumpIfNil, 0, 0, 0)
+		}
+		c.emit(ex, opLookupMember, c.a.konst(ex.Name), 0, 0)
+		c.exprs(ex.Arguments)
+		c.emit(ex, opCallMember, 0, int32(len(ex.Arguments)+1), 0)
+		if safe >= 0 {
+			c.patch(safe)
+		}
+	case *ast.IterateExpr:
+		c.iterate(ex)
+	case *ast.LambdaExpr:
+		c.lambda(ex)
+	case *ast.UnaryOp:
+		c.expr(ex.Operand)
+		switch ex.Op {
+		case "!":
+			c.emit(ex, opNot, 0, 0, 0)
+		case "-":
+			c.emit(ex, opNeg, 0, 0, 0)
+		default:
+			c.fail(fmt.Errorf("unknown operator %s", ex.Op))
+		}
+	case *ast.BinaryOp:
+		c.binary(ex)
+	default:
+		c.fail(fmt.Errorf("unsupported expression %T", ex))
+	}
+}
+
+func (c *compiler) exprs(list []ast.Expr) {
+	for _, e := range list {
+		c.expr(e)
+	}
+}
+
+func (c *compiler) binary(ex *ast.BinaryOp) {
+	if ex.Op == "&&" || ex.Op == "||" {
+		op := c.a.konst(ex.Op)
+		c.expr(ex.Left)
+		c.emit(ex.Left, opCheckBool, op, 0, 0)
+		var stopOn int32
+		if ex.Op == "||" {
+			stopOn = 1
+		}
+		end := c.emit(ex, opShortCircuit, 0, stopOn, 0)
+		c.expr(ex.Right)
+		c.emit(ex.Right, opCheckBool, op, 0, 0)
+		c.patch(end)
+		return
+	}
+	c.expr(ex.Left)
+	c.expr(ex.Right)
+	switch ex.Op {
+	case "+", "-", "*", "/", "%":
+		c.emit(ex, opArith, binaryOp(ex.Op), 0, 0)
+	case "<", "<=", ">", ">=":
+		c.emit(ex, opCompare, binaryOp(ex.Op), 0, 0)
+	case "==":
+		c.emit(ex, opEqual, 0, 0, 0)
+	case "!=":
+		c.emit(ex, opEqual, 0, 1, 0)
+	default:
+		c.fail(fmt.Errorf("unknown operator %s", ex.Op))
+	}
+}
+
+func (c *compiler) recordLiteral(ex *ast.RecordLiteral) {
+	rec, ok := c.fn.symbols.Records[ex.TypeName]
+	if !ok {
+		c.fail(errorAt(ex, "unknown record %s", ex.TypeName))
+		return
+	}
+	for _, field := range rec.Fields {
+		valExpr, ok := ex.Fields[field.Name]
+		if !ok {
+			c.fail(errorAt(ex, "missing field %s", field.Name))
+			return
+		}
+		c.expr(valExpr)
+	}
+	c.emit(ex, opRecord, c.a.record(rec), 0, 0)
+}
+
+// call compiles a call. A local of the callee's name shadows constructors
+// and functions of that name, and fails the call unless it holds a closure.
+func (c *compiler) call(ex *ast.CallExpr) {
+	if ex.Slot == nil {
+		c.staticCall(ex)
+		return
+	}
+	c.emit(ex, opLoad, c.slot(ex.Slot), 0, 0)
+	notClosure := c.emit(ex, opJumpIfNotClosure, 0, 0, 0)
+	c.exprs(ex.Arguments)
+	c.emit(ex, opCallValue, 0, int32(len(ex.Arguments)), 0)
+	end := c.emit(ex, opJump, 0, 0, 0)
+	c.patch(notClosure)
+	c.fail(errorAt(ex, "%s is not callable", ex.Callee))
+	c.patch(end)
+}
+
+func (c *compiler) staticCall(ex *ast.CallExpr) {
+	symbols := c.fn.symbols
+	argc := int32(len(ex.Arguments))
+	if sum, variant, ok := symbols.Variant(ex.Callee); ok {
+		c.exprs(ex.Arguments)
+		c.emit(ex, opVariant, c.a.variant(sum, variant), argc, 0)
+		return
+	}
+	decl, ok := symbols.Functions[ex.Callee]
+	if !ok && ex.Callee == "range" {
+		c.exprs(ex.Arguments)
+		c.emit(ex, opRange, argc, 0, 0)
+		return
+	}
+	if !ok {
+		c.fail(errorAt(ex, "unknown function %s", ex.Callee))
+		return
+	}
+	c.exprs(ex.Arguments)
+	c.emit(ex, opCall, c.a.declare(symbols, decl).id, argc, 0)
+}
+
+// lambda compiles the body of ex as a function of its own and the creation
+// of a closure over the captured slots of the current frame.
+func (c *compiler) lambda(ex *ast.LambdaExpr) {
+	fn := c.a.function(c.fn.name+".lambda", len(ex.Params))
+	fn.lambda = ex
+	fn.symbols = c.fn.symbols
+	body := &compiler{a: c.a, fn: fn, scopes: []int32{0}}
+	body.reserve(int32(ex.Slots))
+	body.block(ex.Body, true)
+	body.emit(ex, opReturn, 0, 0, 0)
+
+	code := &closureCode{fn: fn, captures: make([]int, len(ex.CaptureSlots))}
+	for i, s := range ex.CaptureSlots {
+		code.captures[i] = int(c.slot(s))
+	}
+	c.fn.closures = append(c.fn.closures, code)
+	c.emit(ex, opClosure, int32(len(c.fn.closures)-1), 0, 0)
+}
+
+// iterate compiles `each` and `withIndex` as a loop over the items, kept
+// in a hidden slot with the position reached.
+func (c *compiler) iterate(ex *ast.IterateExpr) {
+	c.expr(ex.Target)
+	start := c.top
+	items := c.reserve(2)
+	mark := c.reserve(1)
+	c.emit(ex, opIterItems, items, 0, 0)
+	c.emit(ex, opMark, mark, 0, 0)
+	c.open(ex.Slots)
+	loop := &loopLabels{head: len(c.fn.code), mark: mark}
+	next := c.emit(ex, opIterNext, items, items+1, 0)
+	c.emit(ex, opTick, 0, 0, 0)
+	c.emit(ex, opStore, c.local(0), 0, 0)
+	c.loops = append(c.loops, loop)
+	c.block(ex.Body, false)
+	c.loops = c.loops[:len(c.loops)-1]
+	c.emit(ex, opJump, int32(loop.head), 0, 0)
+	c.patch(next)
+	for _, at := range loop.breaks {
+		c.patch(at)
+	}
+	c.close()
+	c.top = start
+	c.emit(ex, opNil, 0, 0, 0)
+}
+
+// match compiles the cases as a chain of pattern tests on the subject,
+// kept in a hidden slot. A case's pattern variables get a scope of their
+// own.
+func (c *compiler) match(ex *ast.MatchExpr) {
+	c.expr(ex.Target)
+	start := c.top
+	defer func() { c.top = start }()
+	subject := c.reserve(1)
+	c.emit(ex, opStore, subject, 0, 0)
+	var ends []int
+	for _, mc := range ex.Cases {
+		caseStart := c.top
+		if mc.Slots > 0 {
+			c.open(mc.Slots)
+		}
+		var fails []int
+		c.pattern(mc.Pattern, subject, &fails)
+		c.expr(mc.Value)
+		ends = append(ends, c.emit(mc, opJump, 0, 0, 0))
+		for _, at := range fails {
+			c.patch(at)
+		}
+		if mc.Slots > 0 {
+			c.close()
+		}
+		c.top = caseStart
+	}
+	if ex.ElseExpr != nil {
+		c.expr(ex.ElseExpr)
+	} else {
+		c.fail(errorAt(ex, "match expression missing else branch"))
+	}
+	for _, at := range ends {
+		c.patch(at)
+	}
+}
+
+// pattern compiles a test of the value in slot src against p, binding its
+// variables. Instructions that jump when the value does not match are
+// added to fails.
+func (c *compiler) pattern(p ast.Pattern, src int32, fails *[]int) {
+	switch p := p.(type) {
+	case *ast.WildcardPattern:
+	case *ast.VarPattern:
+		c.emit(p, opLoad, src, 0, 0)
+		c.emit(p, opStore, c.local(p.Slot), 0, 0)
+	case *ast.LiteralPattern:
+		val, err := literalValue(p.Literal)
+		if err != nil {
+			c.fail(err)
+			return
+		}
+		*fails = append(*fails, c.emit(p, opMatchLiteral, src, c.a.konst(val), 0))
+	case *ast.RecordPattern:
+		*fails = append(*fails, c.emit(p, opMatchRecord, src, c.a.konst(p.TypeName), 0))
+		for _, fp := range p.Fields {
+			*fails = append(*fails, c.emit(fp, opMatchField, src, c.a.konst(fp.Field), 0))
+			field := c.reserve(1)
+			c.emit(fp, opStore, field, 0, 0)
+			c.pattern(fp.Pattern, field, fails)
+		}
+	case *ast.VariantPattern:
+		symbols := c.fn.symbols
+		sum, variant, known := symbols.Variant(p.Variant)
+		if !known {
+			if _, isRecord := symbols.Records[p.Variant]; isRecord && len(p.Fields) == 0 {
+				*fails = append(*fails, c.emit(p, opMatchRecord, src, c.a.konst(p.Variant), 0))
+				return
+			}
+			c.fail(errorAt(p, "unknown variant %s", p.Variant))
+			return
+		}
+		if p.TypeName != "" && p.TypeName != sum.Name {
+			c.fail(errorAt(p, "%s is not a variant of %s", p.Variant, p.TypeName))
+			return
+		}
+		if len(p.Fields) != len(variant.Fields) {
+			c.fail(errorAt(p, "pattern %s has %d field(s) but the variant declares %d", p.Variant, len(p.Fields), len(variant.Fields)))
+			return
+		}
+		*fails = append(*fails, c.emit(p, opMatchVariant, src, c.a.variant(sum, variant), 0))
+		for i, fp := range p.Fields {
+			c.emit(fp, opVariantValue, src, int32(i), 0)
+			field := c.reserve(1)
+			c.emit(fp, opStore, field, 0, 0)
+			c.pattern(fp, field, fails)
+		}
+	default:
+		c.fail(fmt.Errorf("unsupported pattern %T", p))
+	}
+}
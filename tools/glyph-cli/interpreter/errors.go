@@ -0,0 +1,145 @@
+package interpreter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"glyph-cli/ast"
+)
+
+// RuntimeError is an error raised while a Glyph program runs. Pos is where
+// it happened, when known, and Frames the Glyph calls that were active,
+// innermost first.
+type RuntimeError struct {
+	Pos     ast.SourcePos
+	Message string
+	Frames  []Frame
+	cause   error
+}
+
+// Frame is one call on the Glyph call stack: the function and the line it
+// had reached. Lambdas are named after the function they appear in, as in
+// main.lambda.
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+func (e *RuntimeError) Error() string {
+	if !e.Pos.IsValid() {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Pos, e.Message)
+}
+
+// Unwrap returns the Go error the runtime error was made from, if any.
+func (e *RuntimeError) Unwrap() error {
+	return e.cause
+}
+
+// traceEnds is how many frames Trace shows at each end of a long trace.
+const traceEnds = 10
+
+// Trace renders the frames, one "at function (file:line)" per line. The
+// middle of a trace longer than 2*traceEnds frames, as runaway recursion
+// leaves, is summarised in one line.
+func (e *RuntimeError) Trace() string {
+	frames, omitted := e.shownFrames()
+	var lines []string
+	for i, f := range frames {
+		if omitted > 0 && i == traceEnds {
+			lines = append(lines, fmt.Sprintf("  ... %d more frames", omitted))
+		}
+		lines = append(lines, "  at "+f.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// shownFrames returns the frames a trace shows and how many it leaves out
+// after the first traceEnds of them.
+func (e *RuntimeError) shownFrames() ([]Frame, int) {
+	if len(e.Frames) <= 2*traceEnds {
+		return e.Frames, 0
+	}
+	shown := make([]Frame, 0, 2*traceEnds)
+	shown = append(shown, e.Frames[:traceEnds]...)
+	shown = append(shown, e.Frames[len(e.Frames)-traceEnds:]...)
+	return shown, len(e.Frames) - 2*traceEnds
+}
+
+func (f Frame) String() string {
+	if f.Line == 0 {
+		return fmt.Sprintf("%s (%s)", f.Function, f.File)
+	}
+	return fmt.Sprintf("%s (%s:%d)", f.Function, f.File, f.Line)
+}
+
+// MarshalJSON encodes the error with its position and frames. A long
+// trace is shortened as Trace shortens it: "omitted" counts the frames
+// left out after the first traceEnds.
+func (e *RuntimeError) MarshalJSON() ([]byte, error) {
+	type position struct {
+		File   string `json:"file"`
+		Line   int    `json:"line"`
+		Column int    `json:"column"`
+	}
+	out := struct {
+		Message string    `json:"message"`
+		Pos     *position `json:"position,omitempty"`
+		Frames  []Frame   `json:"frames"`
+		Omitted int       `json:"omitted,omitempty"`
+	}{Message: e.Message}
+	out.Frames, out.Omitted = e.shownFrames()
+	if e.Pos.IsValid() {
+		out.Pos = &position{e.Pos.File, e.Pos.Line, e.Pos.Column}
+	}
+	if out.Frames == nil {
+		out.Frames = []Frame{}
+	}
+	// File names such as <inline> are written as they are.
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(out); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// errorAt formats a runtime error, positioned at the node when the parser
+// recorded where it is.
+func errorAt(node ast.Node, format string, args ...interface{}) error {
+	return &RuntimeError{Pos: node.Position(), Message: fmt.Sprintf(format, args...)}
+}
+
+// causeAt makes err a runtime error positioned at node, when there is one,
+// keeping err for errors.As.
+func causeAt(node ast.Node, err error) error {
+	re := &RuntimeError{Message: err.Error(), cause: err}
+	if node != nil {
+		re.Pos = node.Position()
+	}
+	return re
+}
+
+// runtimeError returns err as a *RuntimeError.
+func runtimeError(err error) *RuntimeError {
+	if re, ok := err.(*RuntimeError); ok {
+		return re
+	}
+	if sig, ok := err.(*loopSignal); ok {
+		return sig.err()
+	}
+	return &RuntimeError{Message: err.Error(), cause: err}
+}
+
+// lineOf returns the line of node, or 0 when there is none.
+func lineOf(node ast.Node) int {
+	if node == nil {
+		return 0
+	}
+	return node.Position().Line
+}
@@ -0,0 +1,116 @@
+package interpreter
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"glyph-cli/parser"
+)
+
+func TestRuntimeErrorsCarryGlyphFrames(t *testing.T) {
+	source := `fun int divide(int a, int b) {
+  a / b
+}
+
+fun int twice(int n) {
+  val f = fun int (int x) {
+    divide(x, n - 2)
+  }
+  f(n) + f(n)
+}
+
+fun void main() {
+  print(twice(4))
+  print(twice(2))
+}
+`
+	program, err := parser.ParseProgramSource("trace.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	out, err := runBoth(t, program, inlineSymbols(program))
+	if out != "4" {
+		t.Fatalf("printed %q before failing", out)
+	}
+	var rerr *RuntimeError
+	if !errors.As(err, &rerr) {
+		t.Fatalf("got %T %v, want a *RuntimeError", err, err)
+	}
+	if rerr.Error() != "trace.gly:2:3: division by zero" {
+		t.Fatalf("got message %q", rerr.Error())
+	}
+	want := []Frame{
+		{Function: "divide", File: "trace.gly", Line: 2},
+		{Function: "twice.lambda", File: "trace.gly", Line: 7},
+		{Function: "twice", File: "trace.gly", Line: 9},
+		{Function: "main", File: "trace.gly", Line: 14},
+	}
+	if !reflect.DeepEqual(rerr.Frames, want) {
+		t.Fatalf("got frames %+v", rerr.Frames)
+	}
+}
+
+func TestArityErrorsPointAtTheCaller(t *testing.T) {
+	source := `fun int one(int n) {
+  n
+}
+
+fun void main() {
+  val f = fun (int x) { x }
+  one(1)
+  f(1, 2)
+}
+`
+	program, err := parser.ParseProgramSource("arity.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	_, err = runBoth(t, program, inlineSymbols(program))
+	var rerr *RuntimeError
+	if !errors.As(err, &rerr) {
+		t.Fatalf("got %T %v, want a *RuntimeError", err, err)
+	}
+	want := []Frame{{Function: "main", File: "arity.gly", Line: 8}}
+	if !reflect.DeepEqual(rerr.Frames, want) {
+		t.Fatalf("got frames %+v", rerr.Frames)
+	}
+}
+
+func TestRuntimeErrorJSON(t *testing.T) {
+	err := &RuntimeError{Message: "division by zero", Frames: []Frame{{Function: "main", File: "a.gly", Line: 3}}}
+	err.Pos.File, err.Pos.Line, err.Pos.Column = "a.gly", 3, 5
+	out, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatal(jerr)
+	}
+	want := `{"message":"division by zero","position":{"file":"a.gly","line":3,"column":5},"frames":[{"function":"main","file":"a.gly","line":3}]}`
+	if string(out) != want {
+		t.Fatalf("got %s", out)
+	}
+}
+
+func TestRuntimeErrorJSONShortensLongTraces(t *testing.T) {
+	err := &RuntimeError{Message: "too deep"}
+	for i := 0; i < 2*traceEnds+5; i++ {
+		err.Frames = append(err.Frames, Frame{Function: "down", File: "a.gly", Line: i + 1})
+	}
+	out, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatal(jerr)
+	}
+	var got struct {
+		Frames  []Frame
+		Omitted int
+	}
+	if jerr := json.Unmarshal(out, &got); jerr != nil {
+		t.Fatal(jerr)
+	}
+	if len(got.Frames) != 2*traceEnds || got.Omitted != 5 {
+		t.Fatalf("got %d frames and %d omitted", len(got.Frames), got.Omitted)
+	}
+	if got.Frames[traceEnds].Line != traceEnds+6 {
+		t.Fatalf("frames after the gap start at line %d", got.Frames[traceEnds].Line)
+	}
+}
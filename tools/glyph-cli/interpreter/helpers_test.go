@@ -0,0 +1,45 @@
+package interpreter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"glyph-cli/ast"
+	"glyph-cli/parser"
+	"glyph-cli/project"
+)
+
+// runSource parses and evaluates source, returning everything main printed.
+// It also runs the program on the VM and fails the test if the output
+// differs.
+func runSource(t *testing.T, source string) string {
+	t.Helper()
+	program, err := parser.ParseProgramSource("test.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	symbols := inlineSymbols(program)
+	out, err := output(Eval, program, symbols, Options{})
+	if err != nil {
+		t.Fatalf("eval: %v", err)
+	}
+	vmOut, err := output(EvalVM, program, symbols, Options{})
+	if err != nil {
+		t.Fatalf("vm: %v", err)
+	}
+	if vmOut != out {
+		t.Errorf("vm printed %q, interpreter printed %q", vmOut, out)
+	}
+	return out
+}
+
+// output runs program with eval, which is Eval or EvalVM, and returns what
+// it printed.
+func output(eval func(context.Context, *ast.Program, *project.Symbols, Options) error, program *ast.Program, symbols *project.Symbols, opts Options) (string, error) {
+	var buf bytes.Buffer
+	opts.Stdout = &buf
+	err := eval(context.Background(), program, symbols, opts)
+	return strings.TrimSuffix(buf.String(), "\n"), err
+}
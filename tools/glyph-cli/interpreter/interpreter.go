@@ -1,43 +1,155 @@
+// Package interpreter runs resolved Glyph programs, by walking the AST
+// (Eval) or on a bytecode VM (Compile, then Run). A host embedding Glyph
+// makes a Runtime with the streams and limits it wants and runs programs
+// on it, or calls single functions it found with Lookup, passing and
+// getting back Go values.
 package interpreter
 
 import (
+	"context"
 	"fmt"
-	"reflect"
+	"strconv"
+	"strings"
 
 	"glyph-cli/ast"
 	"glyph-cli/project"
 )
 
+// environment is one lexical scope: the values of the variables it
+// declares, in the slots project.ResolveScopes gave them. Blocks that
+// declare variables get a child scope, so their declarations disappear
+// when they end.
 type environment struct {
-	vars map[string]interface{}
+	slots  []Value
+	parent *environment
+	// inline holds the slots of small scopes, saving an allocation.
+	inline [4]Value
+}
+
+func newEnv(parent *environment, size int) *environment {
+	e := &environment{parent: parent}
+	if size <= len(e.inline) {
+		e.slots = e.inline[:size]
+	} else {
+		e.slots = make([]Value, size)
+	}
+	return e
+}
+
+// scope returns the scope depth levels out from e.
+func (e *environment) scope(depth int) *environment {
+	for ; depth > 0; depth-- {
+		e = e.parent
+	}
+	return e
+}
+
+func (e *environment) get(s *ast.Slot) Value {
+	return e.scope(s.Depth).slots[s.Index]
+}
+
+func (e *environment) set(s *ast.Slot, val Value) {
+	e.scope(s.Depth).slots[s.Index] = val
 }
 
 type state struct {
-	records   map[string]*ast.RecordDecl
-	functions map[string]*ast.FunctionDecl
+	symbols *project.Symbols // those of the function running
+	calls   []call           // the Glyph call stack, innermost last
+	budget  *budget
+	rt      *Runtime
+}
+
+// call is a function or closure call in progress. A closure's call has
+// the name of the function it was created in and the number of lambdas
+// nested in it, spelling out its frame name, such as main.lambda, only
+// when a trace is taken.
+type call struct {
+	name    string
+	lambdas int
+	file    string
+	site    ast.Node         // the call expression, nil for main
+	caller  *project.Symbols // restored when the call returns
+}
+
+func (c call) function() string {
+	return c.name + strings.Repeat(".lambda", c.lambdas)
+}
+
+// trace gives err the frames of the calls in progress, unless a call
+// further in already did. at is where the innermost call had got to if err
+// does not say.
+func (st *state) trace(err error, at ast.Node) error {
+	re := runtimeError(err)
+	if re.Frames != nil {
+		return re
+	}
+	line := lineOf(at)
+	if re.Pos.IsValid() {
+		line = re.Pos.Line
+	}
+	re.Frames = make([]Frame, 0, len(st.calls))
+	for i := len(st.calls) - 1; i >= 0; i-- {
+		c := st.calls[i]
+		re.Frames = append(re.Frames, Frame{Function: c.function(), File: c.file, Line: line})
+		line = lineOf(c.site)
+	}
+	return re
 }
 
 type recordInstance struct {
 	name            string
-	fields          map[string]interface{}
+	fields          map[string]Value
 	immutableFields map[string]struct{}
 }
 
 type returnSignal struct {
-	value interface{}
+	value Value
 }
 
 func (r *returnSignal) Error() string {
 	return "return"
 }
 
+// loopSignal unwinds to the innermost enclosing while loop.
+type loopSignal struct {
+	stmt ast.Statement // *ast.BreakStmt or *ast.ContinueStmt
+}
+
+func (l *loopSignal) Error() string {
+	return l.err().Error()
+}
+
+// err is the error for a break or continue that is not in a loop.
+func (l *loopSignal) err() *RuntimeError {
+	if _, ok := l.stmt.(*ast.BreakStmt); ok {
+		return errorAt(l.stmt, "break outside of a loop").(*RuntimeError)
+	}
+	return errorAt(l.stmt, "continue outside of a loop").(*RuntimeError)
+}
+
 type closureValue struct {
 	lambda   *ast.LambdaExpr
-	captured map[string]interface{}
+	captured []Value // values of lambda.Captures
+	// creator and lambdas name the closure in stack traces, as for call.
+	creator string
+	lambdas int
+	code    *function // set for closures created by the VM
+	// symbols are those of the function that created the closure, which
+	// its body sees wherever it is called from.
+	symbols *project.Symbols
+}
+
+// Eval executes the program using the provided resolved symbols. It stops
+// the program with a *StepLimitError, *CallDepthError, *DeadlineError or
+// *CanceledError, wrapped in a *RuntimeError, once it exceeds opts or ctx
+// is done.
+func Eval(ctx context.Context, program *ast.Program, symbols *project.Symbols, opts Options) error {
+	return NewRuntime(opts).Eval(ctx, program, symbols)
 }
 
-// Eval executes the program using the provided resolved symbols.
-func Eval(program *ast.Program, symbols *project.Symbols) error {
+// Eval executes the program on the tree-walking interpreter, like the
+// package-level Eval.
+func (rt *Runtime) Eval(ctx context.Context, program *ast.Program, symbols *project.Symbols) error {
 	if symbols == nil {
 		return fmt.Errorf("symbols must not be nil")
 	}
@@ -45,37 +157,67 @@ func Eval(program *ast.Program, symbols *project.Symbols) error {
 	if !ok {
 		return fmt.Errorf("main function not found")
 	}
-	st := &state{
-		records:   symbols.Records,
-		functions: symbols.Functions,
-	}
-	if _, err := invokeFunction(mainFn, nil, st); err != nil {
+	budget, done := newBudget(ctx, rt.opts)
+	defer done()
+	if _, err := invokeFunction(mainFn, nil, nil, rt.newState(symbols, budget)); err != nil {
 		return err
 	}
 	return nil
 }
 
-func invokeFunction(fn *ast.FunctionDecl, args []interface{}, st *state) (interface{}, error) {
+// newState starts a run of the functions of symbols on rt.
+func (rt *Runtime) newState(symbols *project.Symbols, budget *budget) *state {
+	return &state{symbols: symbols, budget: budget, rt: rt}
+}
+
+// invokeFunction calls fn from the call expression site, which is nil for
+// main.
+func invokeFunction(fn *ast.FunctionDecl, args []Value, site ast.Node, st *state) (Value, error) {
 	if fn == nil {
-		return nil, fmt.Errorf("attempted to invoke nil function")
+		return nil, st.trace(fmt.Errorf("attempted to invoke nil function"), site)
 	}
 	if args == nil {
-		args = []interface{}{}
+		args = []Value{}
 	}
 	if len(fn.Params) != len(args) {
-		return nil, fmt.Errorf("function %s expects %d argument(s) but received %d", fn.Name, len(fn.Params), len(args))
+		return nil, st.trace(causeAt(site, fmt.Errorf("function %s expects %d argument(s) but received %d", fn.Name, len(fn.Params), len(args))), site)
+	}
+	if !fn.Resolved {
+		return nil, st.trace(fmt.Errorf("function %s has not been resolved (see project.ResolveScopes)", fn.Name), site)
 	}
-	env := &environment{vars: make(map[string]interface{})}
-	for i, param := range fn.Params {
-		env.vars[param.Name] = args[i]
+	if runsNative(fn) {
+		// A native is Go code, so it has no frame of its own.
+		val, err := st.rt.callNative(st.symbols, fn, args)
+		if err != nil {
+			return nil, st.trace(causeAt(site, err), site)
+		}
+		return val, nil
+	}
+	if err := st.budget.enter(len(st.calls), site); err != nil {
+		return nil, st.trace(err, site)
 	}
-	if err := evalBlock(fn.Body, env, st); err != nil {
+	st.calls = append(st.calls, call{name: fn.Name, file: fn.Pos.File, site: site, caller: st.symbols})
+	defer st.pop()
+	st.symbols = st.symbols.Declaring(fn)
+	env := newEnv(nil, fn.Slots)
+	copy(env.slots, args)
+	convertArgs(env.slots, argTypes(st.symbols, fn))
+	// Like the Groovy interpreter, a body that ends in an expression
+	// returns its value.
+	val, err := evalBlockValue(fn.Body, env, st)
+	if err != nil {
 		if ret, ok := err.(*returnSignal); ok {
 			return ret.value, nil
 		}
-		return nil, err
+		return nil, st.trace(err, nil)
 	}
-	return nil, nil
+	return val, nil
+}
+
+// pop ends the innermost call, going back to the symbols of its caller.
+func (st *state) pop() {
+	st.symbols = st.calls[len(st.calls)-1].caller
+	st.calls = st.calls[:len(st.calls)-1]
 }
 
 func evalBlock(block *ast.Block, env *environment, st *state) error {
@@ -86,23 +228,42 @@ func evalBlock(block *ast.Block, env *environment, st *state) error {
 			if err != nil {
 				return err
 			}
-			env.vars[s.Name] = val
+			if s.Type != "" {
+				if val, err = coerceDeclared(s.Type, val); err != nil {
+					return errorAt(s, "%v", err)
+				}
+			}
+			env.slots[s.Slot] = val
 		case *ast.AssignStmt:
 			if err := applyAssign(s, env, st); err != nil {
 				return err
 			}
+		case *ast.IncDecStmt:
+			if err := applyIncDec(s, env, st); err != nil {
+				return err
+			}
+		case *ast.WhileStmt:
+			if err := evalWhile(s, env, st); err != nil {
+				return err
+			}
+		case *ast.BreakStmt:
+			return &loopSignal{stmt: s}
+		case *ast.ContinueStmt:
+			return &loopSignal{stmt: s}
 		case *ast.PrintStmt:
 			val, err := evalExpr(s.Expr, env, st)
 			if err != nil {
 				return err
 			}
-			fmt.Println(val)
+			if err := st.rt.print(val); err != nil {
+				return errorAt(s, "%v", err)
+			}
 		case *ast.ExprStmt:
 			if _, err := evalExpr(s.Expr, env, st); err != nil {
 				return err
 			}
 		case *ast.ReturnStmt:
-			var val interface{}
+			var val Value
 			var err error
 			if s.Expr != nil {
 				val, err = evalExpr(s.Expr, env, st)
@@ -118,22 +279,18 @@ func evalBlock(block *ast.Block, env *environment, st *state) error {
 	return nil
 }
 
-func evalExpr(e ast.Expr, env *environment, st *state) (interface{}, error) {
+func evalExpr(e ast.Expr, env *environment, st *state) (Value, error) {
 	switch ex := e.(type) {
-	case *ast.IntLiteral:
-		return ex.Value, nil
-	case *ast.BoolLiteral:
-		return ex.Value, nil
-	case *ast.NullLiteral:
-		return nil, nil
-	case *ast.StringLiteral:
-		return ex.Value, nil
+	case *ast.IntLiteral, *ast.LongLiteral, *ast.FloatLiteral, *ast.DoubleLiteral, *ast.CharLiteral,
+		*ast.BoolLiteral, *ast.NullLiteral, *ast.StringLiteral:
+		return literalValue(ex)
+	case *ast.StringTemplate:
+		return evalStringTemplate(ex, env, st)
 	case *ast.VarRef:
-		val, ok := env.vars[ex.Name]
-		if !ok {
-			return nil, fmt.Errorf("undefined variable %s", ex.Name)
+		if ex.Slot == nil {
+			return nil, errorAt(ex, "undefined variable %s", ex.Name)
 		}
-		return val, nil
+		return env.get(ex.Slot), nil
 	case *ast.RecordLiteral:
 		return evalRecordLiteral(ex, env, st)
 	case *ast.FieldAccess:
@@ -165,9 +322,18 @@ func evalExpr(e ast.Expr, env *environment, st *state) (interface{}, error) {
 		return evalMatch(ex, env, st)
 	case *ast.CallExpr:
 		return evalCall(ex, env, st)
+	case *ast.MethodCall:
+		return evalMethodCall(ex, env, st)
+	case *ast.IterateExpr:
+		return evalIterate(ex, env, st)
 	case *ast.LambdaExpr:
-		return evalLambda(ex, env)
+		return evalLambda(ex, env, st)
+	case *ast.UnaryOp:
+		return evalUnary(ex, env, st)
 	case *ast.BinaryOp:
+		if ex.Op == "&&" || ex.Op == "||" {
+			return evalLogical(ex, env, st)
+		}
 		left, err := evalExpr(ex.Left, env, st)
 		if err != nil {
 			return nil, err
@@ -177,14 +343,22 @@ func evalExpr(e ast.Expr, env *environment, st *state) (interface{}, error) {
 			return nil, err
 		}
 		switch ex.Op {
-		case "+", "-", "*", "/":
-			return numericBinary(left, right, ex.Op)
+		case "+", "-", "*", "/", "%":
+			val, err := numericBinary(left, right, ex.Op)
+			if err != nil {
+				return nil, errorAt(ex, "%v", err)
+			}
+			return val, nil
 		case "<", "<=", ">", ">=":
-			return comparisonBinary(left, right, ex.Op)
+			val, err := comparisonBinary(left, right, ex.Op)
+			if err != nil {
+				return nil, errorAt(ex, "%v", err)
+			}
+			return val, nil
 		case "==":
-			return reflect.DeepEqual(left, right), nil
+			return boolValue(Equal(left, right)), nil
 		case "!=":
-			return !reflect.DeepEqual(left, right), nil
+			return boolValue(!Equal(left, right)), nil
 		default:
 			return nil, fmt.Errorf("unknown operator %s", ex.Op)
 		}
@@ -193,157 +367,299 @@ func evalExpr(e ast.Expr, env *environment, st *state) (interface{}, error) {
 	}
 }
 
-func numericBinary(left, right interface{}, op string) (interface{}, error) {
-	lv, lok := left.(int64)
-	rv, rok := right.(int64)
-	if !lok || !rok {
-		return nil, fmt.Errorf("binary op %s expects ints", op)
+func applyAssign(stmt *ast.AssignStmt, env *environment, st *state) error {
+	return updateTarget(stmt.Target, stmt, stmt.Op != "", env, st, func(current Value) (Value, error) {
+		val, err := evalExpr(stmt.Value, env, st)
+		if err != nil {
+			return nil, err
+		}
+		if stmt.Op == "" {
+			return val, nil
+		}
+		result, err := numericBinary(current, val, stmt.Op)
+		if err != nil {
+			return nil, errorAt(stmt, "%v", err)
+		}
+		return result, nil
+	})
+}
+
+func applyIncDec(stmt *ast.IncDecStmt, env *environment, st *state) error {
+	return updateTarget(stmt.Target, stmt, true, env, st, func(current Value) (Value, error) {
+		val, err := step(current, stmt.Op)
+		if err != nil {
+			return nil, errorAt(stmt, "%v", err)
+		}
+		return val, nil
+	})
+}
+
+// argTypes lists the number type each parameter of fn converts its
+// argument to on entry, so that an int passed for a long parameter is a
+// long inside the function. Other parameters have "".
+func argTypes(symbols *project.Symbols, fn *ast.FunctionDecl) []string {
+	types := make([]string, len(fn.Params))
+	for i, p := range fn.Params {
+		switch t := strings.TrimSuffix(symbols.ExpandAlias(p.Type), "?"); t {
+		case "int", "long", "float", "double":
+			types[i] = t
+		}
 	}
-	switch op {
-	case "+":
-		return lv + rv, nil
-	case "-":
-		return lv - rv, nil
-	case "*":
-		return lv * rv, nil
-	case "/":
-		if rv == 0 {
-			return nil, fmt.Errorf("division by zero")
-		}
-		return lv / rv, nil
-	default:
-		return nil, fmt.Errorf("unknown numeric operator %s", op)
+	return types
+}
+
+// convertArgs converts args to the types argTypes listed. An argument that
+// would narrow is left as it is; the typechecker rejects those calls.
+func convertArgs(args []Value, types []string) {
+	for i, t := range types {
+		if t == "" {
+			continue
+		}
+		if v, err := coerceDeclared(t, args[i]); err == nil {
+			args[i] = v
+		}
 	}
 }
 
-func comparisonBinary(left, right interface{}, op string) (interface{}, error) {
-	lv, lok := left.(int64)
-	rv, rok := right.(int64)
-	if !lok || !rok {
-		return nil, fmt.Errorf("binary op %s expects ints", op)
+// step applies ++ or -- to current.
+func step(current Value, op string) (Value, error) {
+	binop := "+"
+	if op == "--" {
+		binop = "-"
 	}
-	switch op {
-	case "<":
-		return lv < rv, nil
-	case "<=":
-		return lv <= rv, nil
-	case ">":
-		return lv > rv, nil
-	case ">=":
-		return lv >= rv, nil
-	default:
-		return nil, fmt.Errorf("unknown comparison operator %s", op)
+	if _, ok := numericKind(current); !ok {
+		return nil, fmt.Errorf("operator %s expects a number, got %s", op, typeName(current))
 	}
+	if _, isChar := current.(charValue); isChar {
+		result, _ := numericBinary(current, intValue(1), binop)
+		return charValue(result.(intValue)), nil
+	}
+	// Step by one of the target's own kind so x++ keeps x's type.
+	one, _ := coerceDeclared(typeName(current), intValue(1))
+	return numericBinary(current, one, binop)
 }
 
-func applyAssign(stmt *ast.AssignStmt, env *environment, st *state) error {
-	switch target := stmt.Target.(type) {
+// updateTarget stores into an assignable expression. The target's
+// container and index are evaluated once; when read is set, update receives
+// the value currently stored there (for compound assignment and ++/--).
+func updateTarget(target ast.Expr, stmt ast.Node, read bool, env *environment, st *state, update func(current Value) (Value, error)) error {
+	switch t := target.(type) {
 	case *ast.VarRef:
-		val, err := evalExpr(stmt.Value, env, st)
+		if t.Slot == nil {
+			return errorAt(t, "undefined variable %s", t.Name)
+		}
+		if t.Slot.Kind != "var" {
+			return errorAt(stmt, "cannot reassign %s %s", t.Slot.Kind, t.Name)
+		}
+		var current Value
+		if read {
+			current = env.get(t.Slot)
+		}
+		val, err := update(current)
 		if err != nil {
 			return err
 		}
-		env.vars[target.Name] = val
+		env.set(t.Slot, val)
 		return nil
 	case *ast.FieldAccess:
-		obj, err := evalExpr(target.Target, env, st)
+		obj, err := evalExpr(t.Target, env, st)
 		if err != nil {
 			return err
 		}
-		rec, ok := obj.(*recordInstance)
-		if !ok {
-			return fmt.Errorf("field assignment on non-record")
-		}
-		if _, imm := rec.immutableFields[target.Field]; imm {
-			return fmt.Errorf("field %s is immutable", target.Field)
+		rec, err := fieldTarget(obj, t.Field, stmt)
+		if err != nil {
+			return err
 		}
-		val, err := evalExpr(stmt.Value, env, st)
+		val, err := update(rec.fields[t.Field])
 		if err != nil {
 			return err
 		}
-		rec.fields[target.Field] = val
+		rec.fields[t.Field] = val
 		return nil
 	case *ast.IndexAccess:
-		container, err := evalExpr(target.Target, env, st)
+		container, err := evalExpr(t.Target, env, st)
 		if err != nil {
 			return err
 		}
-		index, err := evalExpr(target.Index, env, st)
+		index, err := evalExpr(t.Index, env, st)
 		if err != nil {
 			return err
 		}
-		val, err := evalExpr(stmt.Value, env, st)
+		if index, err = indexTarget(container, index, t, stmt); err != nil {
+			return err
+		}
+		var current Value
+		if read {
+			current = elementAt(container, index)
+		}
+		val, err := update(current)
 		if err != nil {
 			return err
 		}
-		switch c := container.(type) {
-		case []interface{}:
-			i := int(index.(int64))
-			c[i] = val
-			return nil
-		case map[interface{}]interface{}:
-			c[index] = val
-			return nil
-		default:
-			return fmt.Errorf("index assignment on non-collection")
+		setElement(container, index, val)
+		return nil
+	default:
+		return errorAt(stmt, "invalid assignment target")
+	}
+}
+
+// fieldTarget checks that obj is a record whose field can be assigned.
+func fieldTarget(obj Value, field string, stmt ast.Node) (*recordInstance, error) {
+	rec, ok := obj.(*recordInstance)
+	if !ok {
+		return nil, errorAt(stmt, "field assignment on non-record")
+	}
+	if _, imm := rec.immutableFields[field]; imm {
+		return nil, errorAt(stmt, "field %s is immutable", field)
+	}
+	return rec, nil
+}
+
+// indexTarget checks that container can be assigned at index, returning
+// the index as the key elementAt and setElement expect.
+func indexTarget(container, index Value, target *ast.IndexAccess, stmt ast.Node) (Value, error) {
+	switch c := container.(type) {
+	case arrayValue:
+		i, ok := indexValue(index)
+		if !ok {
+			return nil, errorAt(target.Index, "array index must be an int, got %s", typeName(index))
+		}
+		if i < 0 || i >= len(c) {
+			return nil, errorAt(target.Index, "array index %d is out of bounds for length %d", i, len(c))
 		}
+		return intValue(i), nil
+	case *mapValue:
+		return index, nil
 	default:
-		return fmt.Errorf("invalid assignment target")
+		return nil, errorAt(stmt, "index assignment on non-collection")
+	}
+}
+
+func elementAt(container, key Value) Value {
+	if arr, ok := container.(arrayValue); ok {
+		return arr[key.(intValue)]
+	}
+	v, _ := container.(*mapValue).get(key)
+	return v
+}
+
+func setElement(container, key, val Value) {
+	if arr, ok := container.(arrayValue); ok {
+		arr[key.(intValue)] = val
+		return
+	}
+	container.(*mapValue).set(key, val)
+}
+
+func evalWhile(stmt *ast.WhileStmt, env *environment, st *state) error {
+	for {
+		if err := st.budget.tick(stmt); err != nil {
+			return err
+		}
+		condVal, err := evalExpr(stmt.Condition, env, st)
+		if err != nil {
+			return err
+		}
+		cond, ok := condVal.(boolValue)
+		if !ok {
+			return errorAt(stmt.Condition, "while condition must be bool")
+		}
+		if !cond {
+			return nil
+		}
+		if _, err := evalBlockValue(stmt.Body, env, st); err != nil {
+			sig, ok := err.(*loopSignal)
+			if !ok {
+				return err
+			}
+			if _, isBreak := sig.stmt.(*ast.BreakStmt); isBreak {
+				return nil
+			}
+		}
 	}
 }
 
-func evalRecordLiteral(expr *ast.RecordLiteral, env *environment, st *state) (interface{}, error) {
-	rec, ok := st.records[expr.TypeName]
+func evalRecordLiteral(expr *ast.RecordLiteral, env *environment, st *state) (Value, error) {
+	rec, ok := st.symbols.Records[expr.TypeName]
 	if !ok {
-		return nil, fmt.Errorf("unknown record %s", expr.TypeName)
+		return nil, errorAt(expr, "unknown record %s", expr.TypeName)
 	}
-	fields := make(map[string]interface{}, len(rec.Fields))
-	immutable := make(map[string]struct{})
-	for _, field := range rec.Fields {
+	values := make([]Value, len(rec.Fields))
+	for i, field := range rec.Fields {
 		valExpr, ok := expr.Fields[field.Name]
 		if !ok {
-			return nil, fmt.Errorf("missing field %s", field.Name)
+			return nil, errorAt(expr, "missing field %s", field.Name)
 		}
 		val, err := evalExpr(valExpr, env, st)
 		if err != nil {
 			return nil, err
 		}
-		fields[field.Name] = val
+		values[i] = val
+	}
+	return newRecord(rec, values), nil
+}
+
+// newRecord builds an instance of rec from the values of its fields, in
+// declaration order.
+func newRecord(rec *ast.RecordDecl, values []Value) *recordInstance {
+	fields := make(map[string]Value, len(rec.Fields))
+	immutable := make(map[string]struct{})
+	for i, field := range rec.Fields {
+		fields[field.Name] = values[i]
 		if field.Mutability == "val" {
 			immutable[field.Name] = struct{}{}
 		}
 	}
-	return &recordInstance{name: rec.Name, fields: fields, immutableFields: immutable}, nil
+	return &recordInstance{name: rec.Name, fields: fields, immutableFields: immutable}
 }
 
-func evalFieldAccess(expr *ast.FieldAccess, env *environment, st *state) (interface{}, error) {
+func evalFieldAccess(expr *ast.FieldAccess, env *environment, st *state) (Value, error) {
 	target, err := evalExpr(expr.Target, env, st)
 	if err != nil {
 		return nil, err
 	}
+	return fieldValue(target, expr.Field, expr)
+}
+
+func fieldValue(target Value, field string, node ast.Node) (Value, error) {
+	if inst, ok := target.(*variantInstance); ok {
+		val, found := inst.field(field)
+		if !found {
+			return nil, errorAt(node, "variant %s has no field %s", inst.variant, field)
+		}
+		return val, nil
+	}
 	rec, ok := target.(*recordInstance)
 	if !ok {
-		return nil, fmt.Errorf("field access on non-record")
+		return nil, errorAt(node, "field access on non-record")
 	}
-	return rec.fields[expr.Field], nil
+	return rec.fields[field], nil
 }
 
-func evalSafeFieldAccess(expr *ast.SafeFieldAccess, env *environment, st *state) (interface{}, error) {
+func evalSafeFieldAccess(expr *ast.SafeFieldAccess, env *environment, st *state) (Value, error) {
 	target, err := evalExpr(expr.Target, env, st)
 	if err != nil {
 		return nil, err
 	}
+	return safeFieldValue(target, expr.Field, expr)
+}
+
+func safeFieldValue(target Value, field string, node ast.Node) (Value, error) {
 	if target == nil {
 		return nil, nil
 	}
+	if inst, ok := target.(*variantInstance); ok {
+		val, _ := inst.field(field)
+		return val, nil
+	}
 	rec, ok := target.(*recordInstance)
 	if !ok {
-		return nil, fmt.Errorf("safe field access on non-record")
+		return nil, errorAt(node, "safe field access on non-record")
 	}
-	return rec.fields[expr.Field], nil
+	return rec.fields[field], nil
 }
 
-func evalIndexAccess(expr *ast.IndexAccess, env *environment, st *state) (interface{}, error) {
+func evalIndexAccess(expr *ast.IndexAccess, env *environment, st *state) (Value, error) {
 	target, err := evalExpr(expr.Target, env, st)
 	if err != nil {
 		return nil, err
@@ -352,35 +668,143 @@ func evalIndexAccess(expr *ast.IndexAccess, env *environment, st *state) (interf
 	if err != nil {
 		return nil, err
 	}
+	return indexValueAt(target, index, expr)
+}
+
+func indexValueAt(target, index Value, expr *ast.IndexAccess) (Value, error) {
 	switch c := target.(type) {
-	case []interface{}:
-		return c[int(index.(int64))], nil
-	case map[interface{}]interface{}:
-		return c[index], nil
+	case arrayValue:
+		i, ok := indexValue(index)
+		if !ok {
+			return nil, errorAt(expr.Index, "array index must be an int, got %s", typeName(index))
+		}
+		if i < 0 || i >= len(c) {
+			return nil, errorAt(expr.Index, "array index %d is out of bounds for length %d", i, len(c))
+		}
+		return c[i], nil
+	case *mapValue:
+		v, _ := c.get(index)
+		return v, nil
+	default:
+		return nil, errorAt(expr, "index access on non-collection")
+	}
+}
+
+// evalLogical evaluates && and ||, only evaluating the right operand when
+// the left one does not already decide the result.
+func evalLogical(expr *ast.BinaryOp, env *environment, st *state) (Value, error) {
+	left, err := evalExpr(expr.Left, env, st)
+	if err != nil {
+		return nil, err
+	}
+	lv, ok := left.(boolValue)
+	if !ok {
+		return nil, errorAt(expr.Left, "operator %s expects bool, got %s", expr.Op, typeName(left))
+	}
+	if (expr.Op == "&&" && !lv) || (expr.Op == "||" && lv) {
+		return lv, nil
+	}
+	right, err := evalExpr(expr.Right, env, st)
+	if err != nil {
+		return nil, err
+	}
+	rv, ok := right.(boolValue)
+	if !ok {
+		return nil, errorAt(expr.Right, "operator %s expects bool, got %s", expr.Op, typeName(right))
+	}
+	return rv, nil
+}
+
+func evalUnary(expr *ast.UnaryOp, env *environment, st *state) (Value, error) {
+	val, err := evalExpr(expr.Operand, env, st)
+	if err != nil {
+		return nil, err
+	}
+	switch expr.Op {
+	case "!":
+		b, ok := val.(boolValue)
+		if !ok {
+			return nil, errorAt(expr, "operator ! expects bool, got %s", typeName(val))
+		}
+		return !b, nil
+	case "-":
+		neg, err := negate(val)
+		if err != nil {
+			return nil, errorAt(expr, "%v", err)
+		}
+		return neg, nil
+	default:
+		return nil, fmt.Errorf("unknown operator %s", expr.Op)
+	}
+}
+
+func evalStringTemplate(expr *ast.StringTemplate, env *environment, st *state) (Value, error) {
+	var buf strings.Builder
+	for _, part := range expr.Parts {
+		val, err := evalExpr(part, env, st)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(formatValue(val))
+	}
+	return stringValue(buf.String()), nil
+}
+
+// formatValue renders a value the way print and string interpolation show
+// it.
+func formatValue(val Value) string {
+	switch v := val.(type) {
+	case nil:
+		return "null"
+	case stringValue:
+		return string(v)
+	case floatValue:
+		return formatFloat(float64(v), 32)
+	case doubleValue:
+		return formatFloat(float64(v), 64)
 	default:
-		return nil, fmt.Errorf("index access on non-collection")
+		return fmt.Sprint(v)
+	}
+}
+
+// formatFloat keeps a decimal point on whole numbers so that 2.0 does not
+// print like the int 2.
+func formatFloat(f float64, bits int) string {
+	text := strconv.FormatFloat(f, 'g', -1, bits)
+	if !strings.ContainsAny(text, ".eIN") {
+		text += ".0"
 	}
+	return text
 }
 
-func evalArrayAlloc(expr *ast.ArrayAllocExpr, env *environment, st *state) (interface{}, error) {
+func evalArrayAlloc(expr *ast.ArrayAllocExpr, env *environment, st *state) (Value, error) {
 	sizeVal, err := evalExpr(expr.Size, env, st)
 	if err != nil {
 		return nil, err
 	}
-	size := int(sizeVal.(int64))
-	out := make([]interface{}, size)
-	return out, nil
+	return newArray(sizeVal, expr)
+}
+
+func newArray(sizeVal Value, expr *ast.ArrayAllocExpr) (Value, error) {
+	size, ok := indexValue(sizeVal)
+	if !ok {
+		return nil, errorAt(expr.Size, "array size must be an int, got %s", typeName(sizeVal))
+	}
+	if size < 0 {
+		return nil, errorAt(expr.Size, "array size must not be negative, got %d", size)
+	}
+	return make(arrayValue, size), nil
 }
 
-func evalMapAlloc(expr *ast.MapAllocExpr, env *environment, st *state) (interface{}, error) {
+func evalMapAlloc(expr *ast.MapAllocExpr, env *environment, st *state) (Value, error) {
 	if _, err := evalExpr(expr.Capacity, env, st); err != nil {
 		return nil, err
 	}
-	return map[interface{}]interface{}{}, nil
+	return newMap(0), nil
 }
 
-func evalMapLiteral(expr *ast.MapLiteralExpr, env *environment, st *state) (interface{}, error) {
-	out := map[interface{}]interface{}{}
+func evalMapLiteral(expr *ast.MapLiteralExpr, env *environment, st *state) (Value, error) {
+	out := newMap(len(expr.Entries))
 	for _, entry := range expr.Entries {
 		key, err := evalExpr(entry.Key, env, st)
 		if err != nil {
@@ -390,19 +814,19 @@ func evalMapLiteral(expr *ast.MapLiteralExpr, env *environment, st *state) (inte
 		if err != nil {
 			return nil, err
 		}
-		out[key] = val
+		out.set(key, val)
 	}
 	return out, nil
 }
 
-func evalIf(expr *ast.IfExpr, env *environment, st *state) (interface{}, error) {
+func evalIf(expr *ast.IfExpr, env *environment, st *state) (Value, error) {
 	condVal, err := evalExpr(expr.Condition, env, st)
 	if err != nil {
 		return nil, err
 	}
-	cond, ok := condVal.(bool)
+	cond, ok := condVal.(boolValue)
 	if !ok {
-		return nil, fmt.Errorf("if condition must be bool")
+		return nil, errorAt(expr.Condition, "if condition must be bool")
 	}
 	if cond {
 		return evalBlockValue(expr.ThenBlock, env, st)
@@ -413,14 +837,14 @@ func evalIf(expr *ast.IfExpr, env *environment, st *state) (interface{}, error)
 	return nil, nil
 }
 
-func evalTernary(expr *ast.TernaryExpr, env *environment, st *state) (interface{}, error) {
+func evalTernary(expr *ast.TernaryExpr, env *environment, st *state) (Value, error) {
 	condVal, err := evalExpr(expr.Condition, env, st)
 	if err != nil {
 		return nil, err
 	}
-	cond, ok := condVal.(bool)
+	cond, ok := condVal.(boolValue)
 	if !ok {
-		return nil, fmt.Errorf("ternary condition must be bool")
+		return nil, errorAt(expr.Condition, "ternary condition must be bool")
 	}
 	if cond {
 		return evalExpr(expr.IfTrue, env, st)
@@ -428,45 +852,52 @@ func evalTernary(expr *ast.TernaryExpr, env *environment, st *state) (interface{
 	return evalExpr(expr.IfFalse, env, st)
 }
 
-func evalMatch(expr *ast.MatchExpr, env *environment, st *state) (interface{}, error) {
+func evalMatch(expr *ast.MatchExpr, env *environment, st *state) (Value, error) {
 	target, err := evalExpr(expr.Target, env, st)
 	if err != nil {
 		return nil, err
 	}
 	for _, c := range expr.Cases {
-		bindings, matched, err := matchPattern(c.Pattern, target, st)
+		scope := env
+		if c.Slots > 0 {
+			scope = newEnv(env, c.Slots)
+		}
+		matched, err := matchPattern(c.Pattern, target, scope, st)
 		if err != nil {
 			return nil, err
 		}
-		if !matched {
-			continue
-		}
-		child := cloneEnv(env)
-		for name, value := range bindings {
-			child.vars[name] = value
+		if matched {
+			return evalExpr(c.Value, scope, st)
 		}
-		return evalExpr(c.Value, child, st)
 	}
 	if expr.ElseExpr != nil {
 		return evalExpr(expr.ElseExpr, env, st)
 	}
-	return nil, fmt.Errorf("match expression missing else branch")
+	return nil, errorAt(expr, "match expression missing else branch")
 }
 
-func evalBlockValue(block *ast.Block, env *environment, st *state) (interface{}, error) {
-	local := &environment{vars: make(map[string]interface{})}
-	for k, v := range env.vars {
-		local.vars[k] = v
+func evalBlockValue(block *ast.Block, env *environment, st *state) (Value, error) {
+	local := env
+	if block.Slots > 0 {
+		local = newEnv(env, block.Slots)
 	}
-	var last interface{}
+	var last Value
 	for _, stmt := range block.Statements {
+		if err := st.budget.tick(stmt); err != nil {
+			return nil, err
+		}
 		switch s := stmt.(type) {
 		case *ast.VarDecl:
 			val, err := evalExpr(s.Value, local, st)
 			if err != nil {
 				return nil, err
 			}
-			local.vars[s.Name] = val
+			if s.Type != "" {
+				if val, err = coerceDeclared(s.Type, val); err != nil {
+					return nil, errorAt(s, "%v", err)
+				}
+			}
+			local.slots[s.Slot] = val
 			last = nil
 		case *ast.AssignStmt:
 			if err := applyAssign(s, local, st); err != nil {
@@ -478,7 +909,9 @@ func evalBlockValue(block *ast.Block, env *environment, st *state) (interface{},
 			if err != nil {
 				return nil, err
 			}
-			fmt.Println(val)
+			if err := st.rt.print(val); err != nil {
+				return nil, errorAt(s, "%v", err)
+			}
 			last = nil
 		case *ast.ExprStmt:
 			val, err := evalExpr(s.Expr, local, st)
@@ -487,7 +920,7 @@ func evalBlockValue(block *ast.Block, env *environment, st *state) (interface{},
 			}
 			last = val
 		case *ast.ReturnStmt:
-			var val interface{}
+			var val Value
 			var err error
 			if s.Expr != nil {
 				val, err = evalExpr(s.Expr, local, st)
@@ -498,9 +931,6 @@ func evalBlockValue(block *ast.Block, env *environment, st *state) (interface{},
 			return nil, &returnSignal{value: val}
 		default:
 			if err := evalBlock(&ast.Block{Statements: []ast.Statement{s}}, local, st); err != nil {
-				if ret, ok := err.(*returnSignal); ok {
-					return ret.value, nil
-				}
 				return nil, err
 			}
 			last = nil
@@ -509,127 +939,186 @@ func evalBlockValue(block *ast.Block, env *environment, st *state) (interface{},
 	return last, nil
 }
 
-func evalCall(expr *ast.CallExpr, env *environment, st *state) (interface{}, error) {
-	if val, ok := env.vars[expr.Callee]; ok {
-		if closure, ok := val.(*closureValue); ok {
-			args := make([]interface{}, len(expr.Arguments))
-			for i, argExpr := range expr.Arguments {
-				v, err := evalExpr(argExpr, env, st)
-				if err != nil {
-					return nil, err
-				}
-				args[i] = v
-			}
-			return invokeClosure(closure, args, st)
+func evalCall(expr *ast.CallExpr, env *environment, st *state) (Value, error) {
+	if expr.Slot != nil {
+		closure, ok := env.get(expr.Slot).(*closureValue)
+		if !ok {
+			return nil, errorAt(expr, "%s is not callable", expr.Callee)
+		}
+		args, err := evalArgs(expr.Arguments, env, st)
+		if err != nil {
+			return nil, err
+		}
+		return invokeClosure(closure, args, expr, st)
+	}
+	if sum, variant, ok := st.symbols.Variant(expr.Callee); ok {
+		args, err := evalArgs(expr.Arguments, env, st)
+		if err != nil {
+			return nil, err
+		}
+		inst, err := constructVariant(sum, variant, args)
+		if err != nil {
+			return nil, errorAt(expr, "%v", err)
+		}
+		return inst, nil
+	}
+	fn, ok := st.symbols.Functions[expr.Callee]
+	if !ok && expr.Callee == "range" {
+		args, err := evalArgs(expr.Arguments, env, st)
+		if err != nil {
+			return nil, err
+		}
+		out, err := builtinRange(args)
+		if err != nil {
+			return nil, errorAt(expr, "%v", err)
 		}
+		return out, nil
 	}
-	fn, ok := st.functions[expr.Callee]
 	if !ok {
-		return nil, fmt.Errorf("unknown function %s", expr.Callee)
+		return nil, errorAt(expr, "unknown function %s", expr.Callee)
 	}
-	args := make([]interface{}, len(expr.Arguments))
-	for i, argExpr := range expr.Arguments {
+	args, err := evalArgs(expr.Arguments, env, st)
+	if err != nil {
+		return nil, err
+	}
+	return invokeFunction(fn, args, expr, st)
+}
+
+// evalArgs evaluates call arguments from left to right.
+func evalArgs(exprs []ast.Expr, env *environment, st *state) ([]Value, error) {
+	args := make([]Value, len(exprs))
+	for i, argExpr := range exprs {
 		val, err := evalExpr(argExpr, env, st)
 		if err != nil {
 			return nil, err
 		}
 		args[i] = val
 	}
-	return invokeFunction(fn, args, st)
+	return args, nil
 }
 
-func cloneEnv(env *environment) *environment {
-	copyEnv := &environment{vars: make(map[string]interface{}, len(env.vars))}
-	for k, v := range env.vars {
-		copyEnv.vars[k] = v
+// evalMethodCall evaluates recv.name(args) as name(recv, args). A safe
+// call on a null receiver yields null without evaluating the arguments.
+func evalMethodCall(expr *ast.MethodCall, env *environment, st *state) (Value, error) {
+	receiver, err := evalExpr(expr.Receiver, env, st)
+	if err != nil {
+		return nil, err
+	}
+	if receiver == nil && expr.Safe {
+		return nil, nil
+	}
+	fn, err := st.symbols.LookupMember(expr.Name, typeName(receiver))
+	if err != nil {
+		return nil, errorAt(expr, "%v", err)
+	}
+	args := make([]Value, 0, len(expr.Arguments)+1)
+	args = append(args, receiver)
+	for _, argExpr := range expr.Arguments {
+		val, err := evalExpr(argExpr, env, st)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, val)
 	}
-	return copyEnv
+	return invokeFunction(fn, args, expr, st)
 }
 
-func evalLambda(expr *ast.LambdaExpr, env *environment) (interface{}, error) {
-	captured := make(map[string]interface{}, len(env.vars))
-	for k, v := range env.vars {
-		captured[k] = v
+// evalLambda creates a closure over copies of the variables the lambda
+// captures. The closure is named after the function creating it.
+func evalLambda(expr *ast.LambdaExpr, env *environment, st *state) (Value, error) {
+	captured := make([]Value, len(expr.CaptureSlots))
+	for i, slot := range expr.CaptureSlots {
+		captured[i] = env.get(slot)
 	}
-	return &closureValue{lambda: expr, captured: captured}, nil
+	closure := &closureValue{lambda: expr, captured: captured, symbols: st.symbols}
+	if len(st.calls) > 0 {
+		c := st.calls[len(st.calls)-1]
+		closure.creator, closure.lambdas = c.name, c.lambdas+1
+	}
+	return closure, nil
 }
 
-func invokeClosure(closure *closureValue, args []interface{}, st *state) (interface{}, error) {
-	if len(closure.lambda.Params) != len(args) {
-		return nil, fmt.Errorf("callable expects %d argument(s) but received %d", len(closure.lambda.Params), len(args))
-	}
-	child := &environment{vars: make(map[string]interface{})}
-	for k, v := range closure.captured {
-		child.vars[k] = v
+func invokeClosure(closure *closureValue, args []Value, site ast.Node, st *state) (Value, error) {
+	lambda := closure.lambda
+	if len(lambda.Params) != len(args) {
+		return nil, st.trace(causeAt(site, fmt.Errorf("callable expects %d argument(s) but received %d", len(lambda.Params), len(args))), site)
 	}
-	for i, param := range closure.lambda.Params {
-		child.vars[param.Name] = args[i]
+	if err := st.budget.enter(len(st.calls), site); err != nil {
+		return nil, st.trace(err, site)
 	}
-	val, err := evalBlockValue(closure.lambda.Body, child, st)
-	if ret, ok := err.(*returnSignal); ok {
-		return ret.value, nil
+	child := newEnv(nil, lambda.Slots)
+	copy(child.slots, args)
+	copy(child.slots[lambda.Slots-len(closure.captured):], closure.captured)
+	st.calls = append(st.calls, call{name: closure.creator, lambdas: closure.lambdas, file: lambda.Pos.File, site: site, caller: st.symbols})
+	defer st.pop()
+	st.symbols = closure.symbols
+	val, err := evalBlockValue(lambda.Body, child, st)
+	if err != nil {
+		if ret, ok := err.(*returnSignal); ok {
+			return ret.value, nil
+		}
+		return nil, st.trace(err, nil)
 	}
-	return val, err
+	return val, nil
 }
 
-func matchPattern(pattern ast.Pattern, value interface{}, st *state) (map[string]interface{}, bool, error) {
+// matchPattern reports whether value matches pattern, binding the
+// pattern's variables in scope.
+func matchPattern(pattern ast.Pattern, value Value, scope *environment, st *state) (bool, error) {
 	switch p := pattern.(type) {
 	case *ast.WildcardPattern:
-		return map[string]interface{}{}, true, nil
+		return true, nil
 	case *ast.VarPattern:
-		return map[string]interface{}{p.Name: value}, true, nil
+		scope.slots[p.Slot] = value
+		return true, nil
 	case *ast.LiteralPattern:
 		expected, err := literalValue(p.Literal)
 		if err != nil {
-			return nil, false, err
+			return false, err
 		}
-		if reflect.DeepEqual(value, expected) {
-			return map[string]interface{}{}, true, nil
-		}
-		return nil, false, nil
+		return Equal(value, expected), nil
 	case *ast.RecordPattern:
-		return matchRecordPattern(p, value, st)
+		return matchRecordPattern(p, value, scope, st)
+	case *ast.VariantPattern:
+		return matchVariantPattern(p, value, scope, st)
 	default:
-		return nil, false, fmt.Errorf("unsupported pattern %T", pattern)
+		return false, fmt.Errorf("unsupported pattern %T", pattern)
 	}
 }
 
-func matchRecordPattern(pattern *ast.RecordPattern, value interface{}, st *state) (map[string]interface{}, bool, error) {
+func matchRecordPattern(pattern *ast.RecordPattern, value Value, scope *environment, st *state) (bool, error) {
 	rec, ok := value.(*recordInstance)
-	if !ok {
-		return nil, false, nil
+	if !ok || rec.name != pattern.TypeName {
+		return false, nil
 	}
-	if rec.name != pattern.TypeName {
-		return nil, false, nil
-	}
-	bindings := map[string]interface{}{}
 	for _, fieldPattern := range pattern.Fields {
 		fieldValue, exists := rec.fields[fieldPattern.Field]
 		if !exists {
-			return nil, false, nil
-		}
-		nested, matched, err := matchPattern(fieldPattern.Pattern, fieldValue, st)
-		if err != nil {
-			return nil, false, err
-		}
-		if !matched {
-			return nil, false, nil
+			return false, nil
 		}
-		for k, v := range nested {
-			bindings[k] = v
+		if matched, err := matchPattern(fieldPattern.Pattern, fieldValue, scope, st); err != nil || !matched {
+			return false, err
 		}
 	}
-	return bindings, true, nil
+	return true, nil
 }
 
-func literalValue(expr ast.Expr) (interface{}, error) {
+func literalValue(expr ast.Expr) (Value, error) {
 	switch lit := expr.(type) {
 	case *ast.IntLiteral:
-		return lit.Value, nil
+		return intValue(lit.Value), nil
+	case *ast.LongLiteral:
+		return longValue(lit.Value), nil
+	case *ast.FloatLiteral:
+		return floatValue(lit.Value), nil
+	case *ast.DoubleLiteral:
+		return doubleValue(lit.Value), nil
+	case *ast.CharLiteral:
+		return charValue(lit.Value), nil
 	case *ast.StringLiteral:
-		return lit.Value, nil
+		return stringValue(lit.Value), nil
 	case *ast.BoolLiteral:
-		return lit.Value, nil
+		return boolValue(lit.Value), nil
 	case *ast.NullLiteral:
 		return nil, nil
 	default:
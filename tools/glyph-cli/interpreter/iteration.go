@@ -0,0 +1,145 @@
+package interpreter
+
+import (
+	"fmt"
+	"sort"
+
+	"glyph-cli/ast"
+)
+
+// evalIterate runs the body of `each` / `withIndex` once per element with
+// the element bound to `it`. Arrays are visited in index order. Maps are
+// visited in ascending key order (numbers by value, then strings, then
+// bools with false first); Go maps are unordered, so sorting is what keeps
+// the output of a program stable from run to run.
+//
+// break and continue inside the body behave as they do in a while loop.
+func evalIterate(expr *ast.IterateExpr, env *environment, st *state) (Value, error) {
+	target, err := evalExpr(expr.Target, env, st)
+	if err != nil {
+		return nil, err
+	}
+	items, err := iterationItems(expr, target)
+	if err != nil {
+		return nil, err
+	}
+	for _, it := range items {
+		if err := st.budget.tick(expr); err != nil {
+			return nil, err
+		}
+		scope := newEnv(env, expr.Slots)
+		scope.slots[0] = it
+		if _, err := evalBlockValue(expr.Body, scope, st); err != nil {
+			sig, ok := err.(*loopSignal)
+			if !ok {
+				return nil, err
+			}
+			if _, isBreak := sig.stmt.(*ast.BreakStmt); isBreak {
+				break
+			}
+		}
+	}
+	return nil, nil
+}
+
+// iterationItems lists the values `it` takes when iterating over target.
+func iterationItems(expr *ast.IterateExpr, target Value) ([]Value, error) {
+	var items []Value
+	switch c := target.(type) {
+	case arrayValue:
+		items = make([]Value, len(c))
+		for i, v := range c {
+			if expr.Method == "withIndex" {
+				items[i] = syntheticRecord("IndexedValue", "index", intValue(i), "value", v)
+			} else {
+				items[i] = v
+			}
+		}
+	case *mapValue:
+		if expr.Method == "withIndex" {
+			return nil, errorAt(expr, "withIndex is only supported on arrays")
+		}
+		for _, key := range c.keys() {
+			value, _ := c.get(key)
+			items = append(items, syntheticRecord("MapEntry", "key", key, "value", value))
+		}
+	default:
+		return nil, errorAt(expr, "%s expects an array or map, got %s", expr.Method, typeName(target))
+	}
+	return items, nil
+}
+
+// syntheticRecord builds the read-only `it` value used by map and indexed
+// iteration.
+func syntheticRecord(name, k1 string, v1 Value, k2 string, v2 Value) *recordInstance {
+	return &recordInstance{
+		name:            name,
+		fields:          map[string]Value{k1: v1, k2: v2},
+		immutableFields: map[string]struct{}{k1: {}, k2: {}},
+	}
+}
+
+// sortKeys puts map keys in iteration order.
+func sortKeys(keys []Value) {
+	sort.Slice(keys, func(i, j int) bool {
+		return keyLess(keys[i], keys[j])
+	})
+}
+
+func keyLess(a, b Value) bool {
+	ra, rb := keyRank(a), keyRank(b)
+	if ra != rb {
+		return ra < rb
+	}
+	switch ra {
+	case 0:
+		less, _ := comparisonBinary(a, b, "<")
+		return bool(less.(boolValue))
+	case 1:
+		return a.(stringValue) < b.(stringValue)
+	case 2:
+		return bool(!a.(boolValue) && b.(boolValue))
+	default:
+		return fmt.Sprint(a) < fmt.Sprint(b)
+	}
+}
+
+func keyRank(v Value) int {
+	if _, ok := numericKind(v); ok {
+		return 0
+	}
+	switch v.(type) {
+	case stringValue:
+		return 1
+	case boolValue:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// builtinRange implements range(start, end[, step]): the ints from start
+// towards end (exclusive), stepping by step (default 1).
+func builtinRange(args []Value) (Value, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("range expects 2 or 3 argument(s) but received %d", len(args))
+	}
+	bounds := make([]intValue, 3)
+	bounds[2] = 1
+	for i, arg := range args {
+		n, ok := arg.(intValue)
+		if !ok {
+			return nil, fmt.Errorf("range expects int arguments, got %s", typeName(arg))
+		}
+		bounds[i] = n
+	}
+	start, end, step := bounds[0], bounds[1], bounds[2]
+	if step == 0 {
+		return nil, fmt.Errorf("range step must not be zero")
+	}
+	out := arrayValue{}
+	for n := int64(start); (step > 0 && n < int64(end)) || (step < 0 && n > int64(end)); n += int64(step) {
+		out = append(out, intValue(n))
+	}
+	return out, nil
+}
@@ -0,0 +1,48 @@
+package interpreter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEachWithIndexAndRange(t *testing.T) {
+	source := `fun int main() {
+  val names = [string] (3)
+  names[0] = "Alice"
+  names[1] = "Bob"
+  names[2] = "Cleo"
+  names.each {
+    print("Hello, ${it}")
+  }
+  names.withIndex {
+    print("${it.index}: ${it.value}")
+  }
+  val scores = [string: int] { "Bob": 82, "Alice": 95, "Cleo": 70 }
+  scores.each {
+    print("${it.key} scored ${it.value}")
+  }
+  var sum = 0
+  range(10, 0, -3).each {
+    sum += it
+  }
+  print(sum)
+  range(0, 10).each {
+    if it == 3 {
+      break
+    }
+    print(it)
+  }
+  0
+}
+`
+	want := []string{
+		"Hello, Alice", "Hello, Bob", "Hello, Cleo",
+		"0: Alice", "1: Bob", "2: Cleo",
+		"Alice scored 95", "Bob scored 82", "Cleo scored 70",
+		"22", "0", "1", "2",
+	}
+	got := strings.Split(runSource(t, source), "\n")
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package interpreter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWhileLoopsAndCompoundAssignment(t *testing.T) {
+	source := `fun int main() {
+  var i = 0
+  var total = 0
+  val counts = [int] (2)
+  counts[0] = 0
+  counts[1] = 0
+  while i < 10 {
+    i++
+    if i % 2 == 0 {
+      continue
+    }
+    if i > 7 {
+      break
+    }
+    val doubled = i * 2
+    total += doubled
+    counts[i % 2] += 1
+  }
+  print(i)
+  print(total)
+  print(counts[1])
+  var x = 10
+  x -= 3
+  x *= 2
+  x /= 7
+  x %= 3
+  x--
+  print(x)
+  var c = 'a'
+  c++
+  print(c)
+  0
+}
+`
+	want := []string{"9", "32", "4", "1", "b"}
+	got := strings.Split(runSource(t, source), "\n")
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
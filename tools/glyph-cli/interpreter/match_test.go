@@ -2,8 +2,7 @@ package interpreter
 
 import (
 	"bytes"
-	"io"
-	"os"
+	"context"
 	"strings"
 	"testing"
 
@@ -16,21 +15,9 @@ func TestMatchBindingsDoNotLeakIntoOuterScope(t *testing.T) {
 	symbols := inlineSymbols(program)
 
 	var buf bytes.Buffer
-	origStdout := os.Stdout
-	r, w, err := os.Pipe()
-	if err != nil {
-		t.Fatalf("pipe: %v", err)
-	}
-	os.Stdout = w
-	if err := Eval(program, symbols); err != nil {
+	if err := Eval(context.Background(), program, symbols, Options{Stdout: &buf}); err != nil {
 		t.Fatalf("eval: %v", err)
 	}
-	w.Close()
-	os.Stdout = origStdout
-	if _, err := io.Copy(&buf, r); err != nil {
-		t.Fatalf("read pipe: %v", err)
-	}
-	r.Close()
 
 	output := strings.Split(strings.TrimSpace(buf.String()), "\n")
 	expected := []string{"outer", "inner"}
@@ -50,9 +37,11 @@ func inlineSymbols(program *ast.Program) *project.Symbols {
 		Functions: make(map[string]*ast.FunctionDecl),
 		Records:   make(map[string]*ast.RecordDecl),
 		Aliases:   make(map[string]*ast.TypeAliasDecl),
+		SumTypes:  make(map[string]*ast.SumTypeDecl),
 	}
 	for _, fn := range program.Functions {
 		symbols.Functions[fn.Name] = fn
+		project.ResolveScopes(fn)
 	}
 	for _, rec := range program.Records {
 		symbols.Records[rec.Name] = rec
@@ -60,6 +49,12 @@ func inlineSymbols(program *ast.Program) *project.Symbols {
 	for _, alias := range program.TypeAliases {
 		symbols.Aliases[alias.Name] = alias
 	}
+	for _, sum := range program.SumTypes {
+		symbols.SumTypes[sum.Name] = sum
+	}
+	if err := symbols.BuildConstructors(); err != nil {
+		panic(err)
+	}
 	return symbols
 }
 
@@ -0,0 +1,89 @@
+package interpreter
+
+import (
+	"strings"
+	"testing"
+
+	"glyph-cli/parser"
+)
+
+const memberSource = `type Name = string
+
+record User {
+  Name name
+}
+
+fun string greet(User? u) {
+  return "hello ${u.name}"
+}
+
+fun string shout(Name s, string suffix) {
+  return "${s}${suffix}"
+}
+
+fun int twice(int n) {
+  return n * 2
+}
+
+fun int main() {
+  val u = User { name = "ada" }
+  val User? nobody = null
+  print(u.greet())
+  print(u.greet().shout("!"))
+  print(u.name.shout("?"))
+  print(nobody?.greet())
+  print(21.twice())
+  0
+}
+`
+
+func TestMemberCallsResolveToFreeFunctions(t *testing.T) {
+	want := []string{"hello ada", "hello ada!", "ada?", "null", "42"}
+	got := strings.Split(runSource(t, memberSource), "\n")
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestMemberCallRejectsMismatchedReceiver(t *testing.T) {
+	source := strings.Replace(memberSource, "print(21.twice())", "print(u.twice())", 1)
+	program, err := parser.ParseProgramSource("test.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	_, err = output(Eval, program, inlineSymbols(program), Options{})
+	if err == nil || !strings.Contains(err.Error(), "function twice expects int as its first parameter, not User") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestMemberCallWidensTheReceiver(t *testing.T) {
+	source := `fun long twice(long x) {
+  return x * 2
+}
+
+fun double half(double x) {
+  return x / 2
+}
+
+fun int main() {
+  val int i = 3
+  print(twice(i))
+  print(i.twice())
+  print(i.half())
+  print('a'.twice())
+  0
+}
+`
+	program, err := parser.ParseProgramSource("test.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	out, err := runBoth(t, program, inlineSymbols(program))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "6\n6\n1.5\n194"; out != want {
+		t.Fatalf("got %q, want %q", out, want)
+	}
+}
@@ -0,0 +1,251 @@
+package interpreter
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+
+	"glyph-cli/ast"
+	"glyph-cli/project"
+)
+
+// Natives is a registry of Go functions that Glyph programs call as if
+// they were written in Glyph. A program declares one with an `extern fun`
+// in the package of its qualified name, or imports it by that name.
+// Natives implements project.Natives: an Index with it resolves programs
+// against the registered signatures, and the runtime calls the natives
+// of the Symbols it runs.
+//
+// A native's parameters and result are among int and int32 (Glyph int),
+// int64 (long), float32 (float), float64 (double), bool and string, or
+// types with those underlying kinds. It may take the *Runtime it runs on
+// as its first parameter, for the streams, and may return an error as its
+// last result, which stops the program as a runtime error at the call. A
+// native with no other result is void.
+type Natives struct {
+	mu    sync.RWMutex
+	funcs map[string]*native
+}
+
+// NewNatives makes an empty registry.
+func NewNatives() *Natives {
+	return &Natives{funcs: map[string]*native{}}
+}
+
+var defaultNatives = NewNatives()
+
+// DefaultNatives returns the registry that RegisterNative adds to. It
+// holds the natives of the standard library.
+func DefaultNatives() *Natives {
+	return defaultNatives
+}
+
+// RegisterNative registers fn as the native name in DefaultNatives.
+func RegisterNative(name string, fn interface{}) error {
+	return defaultNatives.Register(name, fn)
+}
+
+// Register makes fn the native with the qualified name name, such as
+// std.strings.length. It fails if fn's signature has types Glyph cannot
+// pass or if name is taken.
+func (n *Natives) Register(name string, fn interface{}) error {
+	nat, err := newNative(name, fn)
+	if err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, exists := n.funcs[name]; exists {
+		return fmt.Errorf("native %s is already registered", name)
+	}
+	n.funcs[name] = nat
+	return nil
+}
+
+// Signature reports the Glyph parameter and result types of the native
+// name.
+func (n *Natives) Signature(name string) ([]string, string, bool) {
+	nat := n.lookup(name)
+	if nat == nil {
+		return nil, "", false
+	}
+	return append([]string(nil), nat.params...), nat.result, true
+}
+
+func (n *Natives) lookup(name string) *native {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.funcs[name]
+}
+
+// native is a registered Go function and its Glyph signature.
+type native struct {
+	name    string
+	fn      reflect.Value
+	runtime bool // the first Go parameter is the *Runtime
+	params  []string
+	result  string
+	fails   bool // the last Go result is an error
+}
+
+var (
+	runtimeType = reflect.TypeOf((*Runtime)(nil))
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+func newNative(name string, fn interface{}) (*native, error) {
+	if name == "" {
+		return nil, fmt.Errorf("native name must not be empty")
+	}
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return nil, fmt.Errorf("native %s must be a func, got %T", name, fn)
+	}
+	t := v.Type()
+	if t.IsVariadic() {
+		return nil, fmt.Errorf("native %s must not be variadic", name)
+	}
+	nat := &native{name: name, fn: v, result: "void"}
+	first := 0
+	if t.NumIn() > 0 && t.In(0) == runtimeType {
+		nat.runtime = true
+		first = 1
+	}
+	for i := first; i < t.NumIn(); i++ {
+		glyph, ok := glyphType(t.In(i))
+		if !ok {
+			return nil, fmt.Errorf("native %s: parameter %d has unsupported type %s", name, i+1-first, t.In(i))
+		}
+		nat.params = append(nat.params, glyph)
+	}
+	results := t.NumOut()
+	if results > 0 && t.Out(results-1) == errorType {
+		nat.fails = true
+		results--
+	}
+	switch results {
+	case 0:
+	case 1:
+		glyph, ok := glyphType(t.Out(0))
+		if !ok {
+			return nil, fmt.Errorf("native %s: result has unsupported type %s", name, t.Out(0))
+		}
+		nat.result = glyph
+	default:
+		return nil, fmt.Errorf("native %s must return at most one value and an error", name)
+	}
+	return nat, nil
+}
+
+// glyphType names the Glyph type a Go type passes as.
+func glyphType(t reflect.Type) (string, bool) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int32:
+		return "int", true
+	case reflect.Int64:
+		return "long", true
+	case reflect.Float32:
+		return "float", true
+	case reflect.Float64:
+		return "double", true
+	case reflect.Bool:
+		return "bool", true
+	case reflect.String:
+		return "string", true
+	}
+	return "", false
+}
+
+// runsNative reports whether calls to fn run a native: it is extern, or a
+// native replaces its Glyph body.
+func runsNative(fn *ast.FunctionDecl) bool {
+	return fn.Extern || fn.Native != ""
+}
+
+// callNative runs the native fn is bound to with the Glyph values args,
+// looking it up in the natives symbols were resolved with.
+func (rt *Runtime) callNative(symbols *project.Symbols, fn *ast.FunctionDecl, args []Value) (Value, error) {
+	if fn.Native == "" {
+		return nil, fmt.Errorf("extern fun %s is not bound to a native (see project.Resolve)", fn.Name)
+	}
+	natives, ok := symbols.Natives.(*Natives)
+	if !ok && symbols.Natives != nil {
+		return nil, fmt.Errorf("natives %T cannot be called; use an *interpreter.Natives", symbols.Natives)
+	}
+	var nat *native
+	if natives != nil {
+		nat = natives.lookup(fn.Native)
+	}
+	if nat == nil {
+		return nil, fmt.Errorf("no native is registered for extern fun %s", fn.Native)
+	}
+	return nat.call(rt, args)
+}
+
+func (n *native) call(rt *Runtime, args []Value) (result Value, err error) {
+	t := n.fn.Type()
+	in := make([]reflect.Value, 0, t.NumIn())
+	if n.runtime {
+		in = append(in, reflect.ValueOf(rt))
+	}
+	for i, arg := range args {
+		v, err := n.argument(arg, n.params[i], t.In(len(in)))
+		if err != nil {
+			return nil, fmt.Errorf("native %s: argument %d: %v", n.name, i+1, err)
+		}
+		in = append(in, v)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("native %s panicked: %v", n.name, r)
+		}
+	}()
+	out := n.fn.Call(in)
+	if n.fails {
+		if failure := out[len(out)-1]; !failure.IsNil() {
+			return nil, failure.Interface().(error)
+		}
+		out = out[:len(out)-1]
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return n.value(out[0])
+}
+
+// argument converts the Glyph value arg, passed for a parameter of Glyph
+// type glyph, to the Go type t.
+func (n *native) argument(arg Value, glyph string, t reflect.Type) (reflect.Value, error) {
+	v, err := coerceDeclared(glyph, arg)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if KindOf(v).String() != glyph {
+		return reflect.Value{}, fmt.Errorf("expected %s, got %s", glyph, typeName(arg))
+	}
+	return reflect.ValueOf(v).Convert(t), nil
+}
+
+// value converts the Go result v to the Glyph value of the native's
+// result type.
+func (n *native) value(v reflect.Value) (Value, error) {
+	switch n.result {
+	case "int":
+		i := v.Int()
+		if i < math.MinInt32 || i > math.MaxInt32 {
+			return nil, fmt.Errorf("native %s returned %d, which is out of range for int", n.name, i)
+		}
+		return intValue(i), nil
+	case "long":
+		return longValue(v.Int()), nil
+	case "float":
+		return floatValue(v.Float()), nil
+	case "double":
+		return doubleValue(v.Float()), nil
+	case "bool":
+		return boolValue(v.Bool()), nil
+	default:
+		return stringValue(v.String()), nil
+	}
+}
@@ -0,0 +1,119 @@
+package interpreter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"glyph-cli/parser"
+	"glyph-cli/project"
+)
+
+var errNegative = errors.New("negative")
+
+func testNatives(t *testing.T) *Natives {
+	t.Helper()
+	n := NewNatives()
+	for name, fn := range map[string]interface{}{
+		"demo.twice": func(x int) int { return 2 * x },
+		"demo.half":  func(x float64) float64 { return x / 2 },
+		"demo.check": func(x int64) (int64, error) {
+			if x < 0 {
+				return 0, errNegative
+			}
+			return x, nil
+		},
+		"demo.greet": func(rt *Runtime, name string) error {
+			_, err := fmt.Fprintf(rt.Stdout(), "hello %s\n", name)
+			return err
+		},
+		"demo.boom": func() bool { panic("boom") },
+		// thrice has a Glyph body that the native replaces.
+		"demo.thrice": func(x int) int { return 3 * x },
+	} {
+		if err := n.Register(name, fn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return n
+}
+
+func runNatives(t *testing.T, body string) (string, error) {
+	t.Helper()
+	source := "package demo\n\nextern fun int twice(int n)\nextern fun double half(double x)\nextern fun long check(long x)\nextern fun void greet(string name)\nextern fun bool boom()\n\nfun void main() {\n  " + body + "\n}\n\nfun int thrice(int n) {\n  0\n}\n"
+	program, err := parser.ParseProgramSource("test.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	natives := testNatives(t)
+	symbols, err := project.Resolve(program, &project.Index{Natives: natives})
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	return runBoth(t, program, symbols)
+}
+
+func TestNativesAreCalledLikeGlyphFunctions(t *testing.T) {
+	out, err := runNatives(t, "print(twice(21))\n  val n = 4\n  print(n.twice())\n  print(half(3))\n  print(check(7))\n  greet(\"glyph\")\n  print(thrice(2))")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "42\n8\n1.5\n7\nhello glyph\n6"; out != want {
+		t.Fatalf("got %q, want %q", out, want)
+	}
+}
+
+func TestNativeFailuresAreRuntimeErrors(t *testing.T) {
+	cases := []struct {
+		name, body, want string
+	}{
+		{"error", "print(check(0 - 1))", "test.gly:10:9: negative"},
+		{"panic", "print(boom())", "test.gly:10:9: native demo.boom panicked: boom"},
+		{"argument", "print(twice(\"x\"))", "test.gly:10:9: native demo.twice: argument 1: expected int, got string"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := runNatives(t, tc.body)
+			var re *RuntimeError
+			if !errors.As(err, &re) || re.Error() != tc.want {
+				t.Fatalf("got error %v, want %q", err, tc.want)
+			}
+			if re.Trace() != "  at main (test.gly:10)" {
+				t.Fatalf("got trace %q", re.Trace())
+			}
+		})
+	}
+	if _, err := runNatives(t, "print(check(0 - 1))"); !errors.Is(err, errNegative) {
+		t.Fatalf("got %v, want the native's error", err)
+	}
+}
+
+func TestRegisterChecksSignatures(t *testing.T) {
+	n := NewNatives()
+	if err := n.Register("demo.len", func(s string) int { return len(s) }); err != nil {
+		t.Fatal(err)
+	}
+	params, result, ok := n.Signature("demo.len")
+	if !ok || strings.Join(params, ",") != "string" || result != "int" {
+		t.Fatalf("got %v %q %v", params, result, ok)
+	}
+	cases := []struct {
+		name string
+		fn   interface{}
+		want string
+	}{
+		{"demo.len", func() {}, "already registered"},
+		{"demo.notFunc", 1, "must be a func"},
+		{"demo.variadic", func(xs ...int) {}, "must not be variadic"},
+		{"demo.param", func(r io.Reader) {}, "parameter 1 has unsupported type io.Reader"},
+		{"demo.result", func() []int { return nil }, "result has unsupported type []int"},
+		{"demo.results", func() (int, int) { return 0, 0 }, "at most one value and an error"},
+	}
+	for _, tc := range cases {
+		if err := n.Register(tc.name, tc.fn); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: got %v, want %q", tc.name, err, tc.want)
+		}
+	}
+}
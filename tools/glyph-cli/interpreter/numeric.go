@@ -0,0 +1,279 @@
+package interpreter
+
+import (
+	"fmt"
+	"math"
+)
+
+// Glyph numbers are intValue, longValue, floatValue, doubleValue and
+// charValue at runtime (see Value).
+//
+// Arithmetic and comparisons promote both operands to the wider of the two
+// kinds (int < long < float < double); a char takes part as an int.
+
+// charValue is a Glyph char. It is its own type so that chars print as
+// characters and cannot be confused with an int.
+type charValue rune
+
+func (c charValue) String() string { return string(rune(c)) }
+
+type numKind int
+
+const (
+	kindInt numKind = iota
+	kindLong
+	kindFloat
+	kindDouble
+)
+
+func numericKind(v Value) (numKind, bool) {
+	switch v.(type) {
+	case intValue, charValue:
+		return kindInt, true
+	case longValue:
+		return kindLong, true
+	case floatValue:
+		return kindFloat, true
+	case doubleValue:
+		return kindDouble, true
+	default:
+		return 0, false
+	}
+}
+
+func asInt64(v Value) int64 {
+	switch n := v.(type) {
+	case intValue:
+		return int64(n)
+	case charValue:
+		return int64(n)
+	case longValue:
+		return int64(n)
+	case floatValue:
+		return int64(n)
+	case doubleValue:
+		return int64(n)
+	}
+	return 0
+}
+
+func asFloat64(v Value) float64 {
+	switch n := v.(type) {
+	case floatValue:
+		return float64(n)
+	case doubleValue:
+		return float64(n)
+	}
+	return float64(asInt64(v))
+}
+
+// promotedKind returns the kind both operands are converted to before a
+// binary operation.
+func promotedKind(left, right Value, op string) (numKind, error) {
+	lk, lok := numericKind(left)
+	rk, rok := numericKind(right)
+	if !lok || !rok {
+		return 0, fmt.Errorf("binary op %s expects numbers, got %s and %s", op, typeName(left), typeName(right))
+	}
+	if lk > rk {
+		return lk, nil
+	}
+	return rk, nil
+}
+
+func numericBinary(left, right Value, op string) (Value, error) {
+	kind, err := promotedKind(left, right, op)
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case kindInt, kindLong:
+		lv, rv := asInt64(left), asInt64(right)
+		var n int64
+		switch op {
+		case "+":
+			n = lv + rv
+		case "-":
+			n = lv - rv
+		case "*":
+			n = lv * rv
+		case "/":
+			if rv == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			n = lv / rv
+		case "%":
+			if rv == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			n = lv % rv
+		default:
+			return nil, fmt.Errorf("unknown numeric operator %s", op)
+		}
+		if kind == kindInt {
+			return intValue(n), nil
+		}
+		return longValue(n), nil
+	default:
+		lv, rv := asFloat64(left), asFloat64(right)
+		var f float64
+		switch op {
+		case "+":
+			f = lv + rv
+		case "-":
+			f = lv - rv
+		case "*":
+			f = lv * rv
+		case "/":
+			f = lv / rv
+		case "%":
+			f = math.Mod(lv, rv)
+		default:
+			return nil, fmt.Errorf("unknown numeric operator %s", op)
+		}
+		if kind == kindFloat {
+			return floatValue(f), nil
+		}
+		return doubleValue(f), nil
+	}
+}
+
+// negate implements unary minus. Like the binary operators, a char is
+// treated as an int.
+func negate(v Value) (Value, error) {
+	switch n := v.(type) {
+	case intValue:
+		return -n, nil
+	case charValue:
+		return -intValue(n), nil
+	case longValue:
+		return -n, nil
+	case floatValue:
+		return -n, nil
+	case doubleValue:
+		return -n, nil
+	default:
+		return nil, fmt.Errorf("operator - expects a number, got %s", typeName(v))
+	}
+}
+
+func comparisonBinary(left, right Value, op string) (Value, error) {
+	kind, err := promotedKind(left, right, op)
+	if err != nil {
+		return nil, err
+	}
+	var cmp int
+	if kind == kindInt || kind == kindLong {
+		lv, rv := asInt64(left), asInt64(right)
+		cmp = compareOrdered(lv, rv)
+	} else {
+		lv, rv := asFloat64(left), asFloat64(right)
+		if lv != lv || rv != rv {
+			// NaN is unordered: every comparison with it is false.
+			return boolValue(false), nil
+		}
+		cmp = compareOrdered(lv, rv)
+	}
+	switch op {
+	case "<":
+		return boolValue(cmp < 0), nil
+	case "<=":
+		return boolValue(cmp <= 0), nil
+	case ">":
+		return boolValue(cmp > 0), nil
+	case ">=":
+		return boolValue(cmp >= 0), nil
+	default:
+		return nil, fmt.Errorf("unknown comparison operator %s", op)
+	}
+}
+
+func compareOrdered[T int64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// indexValue converts an array index or size to a Go int.
+func indexValue(v Value) (int, bool) {
+	switch n := v.(type) {
+	case intValue:
+		return int(n), true
+	case longValue:
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
+
+// coerceDeclared converts the initializer of a typed declaration such as
+// `val long x = 1` to the declared primitive. Widening is always allowed; a
+// double may narrow to float so that `val float f = 1.5` works, but other
+// narrowing conversions are errors.
+func coerceDeclared(declared string, v Value) (Value, error) {
+	kind, ok := numericKind(v)
+	if !ok {
+		return v, nil
+	}
+	_, isChar := v.(charValue)
+	switch declared {
+	case "int":
+		if kind == kindInt {
+			return intValue(asInt64(v)), nil
+		}
+	case "long":
+		if kind <= kindLong {
+			return longValue(asInt64(v)), nil
+		}
+	case "float":
+		return floatValue(asFloat64(v)), nil
+	case "double":
+		return doubleValue(asFloat64(v)), nil
+	case "char":
+		if isChar {
+			return v, nil
+		}
+	default:
+		return v, nil
+	}
+	return nil, fmt.Errorf("cannot assign %s to %s", typeName(v), declared)
+}
+
+// typeName names the Glyph type of a runtime value for error messages.
+func typeName(v Value) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case intValue:
+		return "int"
+	case longValue:
+		return "long"
+	case floatValue:
+		return "float"
+	case doubleValue:
+		return "double"
+	case charValue:
+		return "char"
+	case boolValue:
+		return "bool"
+	case stringValue:
+		return "string"
+	case *recordInstance:
+		return val.name
+	case *variantInstance:
+		return val.sumType
+	case arrayValue:
+		return "array"
+	case *mapValue:
+		return "map"
+	case *closureValue:
+		return "function"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
@@ -0,0 +1,71 @@
+package interpreter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNumericPromotion(t *testing.T) {
+	source := `fun int main() {
+  val int age = 42
+  val float price = 19.99
+  val long big = 9_000_000_000L
+  val double ratio = 1
+  val c = 'A'
+  print(big + age)
+  print(price * 2)
+  print(ratio)
+  print(0xFF + 0b1010)
+  print(1.5e3)
+  print(c)
+  print(c + 1)
+  print(7 / 2)
+  print(7.0 / 2)
+  print(2147483647 + 1)
+  print(1 == 1.0)
+  print(2L > 1.5f)
+  0
+}
+`
+	want := []string{
+		"9000000042", "39.98", "1.0", "265", "1500.0", "A", "66",
+		"3", "3.5", "-2147483648", "true", "true",
+	}
+	got := strings.Split(runSource(t, source), "\n")
+	if len(got) != len(want) {
+		t.Fatalf("expected %d lines, got %d: %q", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("line %d: got %q, want %q", i+1, got[i], want[i])
+		}
+	}
+}
+
+func TestNumericResultTypes(t *testing.T) {
+	cases := []struct {
+		left, right Value
+		want        Value
+	}{
+		{intValue(1), intValue(2), intValue(3)},
+		{intValue(1), longValue(2), longValue(3)},
+		{longValue(1), floatValue(2), floatValue(3)},
+		{floatValue(1), doubleValue(2), doubleValue(3)},
+		{charValue('a'), intValue(1), intValue('b')},
+	}
+	for _, tc := range cases {
+		got, err := numericBinary(tc.left, tc.right, "+")
+		if err != nil {
+			t.Fatalf("%T + %T: %v", tc.left, tc.right, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%T + %T = %#v, want %#v", tc.left, tc.right, got, tc.want)
+		}
+	}
+	if _, err := numericBinary(longValue(1), longValue(0), "/"); err == nil {
+		t.Fatalf("expected division by zero error")
+	}
+	if _, err := coerceDeclared("int", doubleValue(1.5)); err == nil {
+		t.Fatalf("expected narrowing double to int to fail")
+	}
+}
@@ -0,0 +1,36 @@
+package interpreter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLogicalUnaryAndModuloOperators(t *testing.T) {
+	source := `record User {
+  bool active
+}
+
+fun bool isActive(User u) {
+  return u != null && u.active
+}
+
+fun int main() {
+  val x = 7
+  print(isActive(null))
+  print(isActive(User { active = true }))
+  print(null == null || missing)
+  print(!false && 1 < 2 || false)
+  print(-x + 10 % 4 * 2)
+  print(-(x % 3))
+  print(-7 % 3)
+  print(7.5 % 2)
+  print(!(x == 7))
+  0
+}
+`
+	want := []string{"false", "true", "true", "true", "-3", "-1", "-1", "1.5", "false"}
+	got := strings.Split(runSource(t, source), "\n")
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
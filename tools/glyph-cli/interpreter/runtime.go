@@ -0,0 +1,99 @@
+package interpreter
+
+import (
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Options configures a Runtime: where programs read and write, and how
+// much they may do. The zero value uses the process's standard streams,
+// sets no step limit or deadline and allows DefaultMaxDepth nested calls.
+type Options struct {
+	// Stdout receives what print writes. Stderr and Stdin are the streams
+	// host functions use. Each defaults to the process's own.
+	Stdout io.Writer
+	Stderr io.Writer
+	Stdin  io.Reader
+
+	// MaxSteps is the number of steps the program may take, 0 meaning no
+	// limit. Running a statement is a step, and so is starting another
+	// round of a while loop or iteration.
+	MaxSteps int64
+	// MaxDepth is how many calls may be in progress at once, main
+	// included.
+	MaxDepth int
+	// Deadline stops the program at a wall-clock time, unless it is zero.
+	// The context's deadline applies as well.
+	Deadline time.Time
+}
+
+// Runtime runs Glyph programs with the streams and limits of its Options.
+// Each run keeps its state to itself, so a Runtime can be made once and
+// used for any number of programs, one after another or from several
+// goroutines at once. Runs sharing a Runtime write whole lines to its
+// streams.
+type Runtime struct {
+	opts   Options
+	stdout io.Writer
+	stderr io.Writer
+	stdin  io.Reader
+}
+
+// NewRuntime makes a Runtime from opts.
+func NewRuntime(opts Options) *Runtime {
+	rt := &Runtime{opts: opts, stdout: os.Stdout, stderr: os.Stderr, stdin: os.Stdin}
+	if opts.Stdout != nil {
+		rt.stdout = opts.Stdout
+	}
+	if opts.Stderr != nil {
+		rt.stderr = opts.Stderr
+	}
+	if opts.Stdin != nil {
+		rt.stdin = opts.Stdin
+	}
+	rt.stdout = &lockedWriter{w: rt.stdout}
+	rt.stderr = &lockedWriter{w: rt.stderr}
+	rt.stdin = &lockedReader{r: rt.stdin}
+	return rt
+}
+
+// Stdout returns the stream print writes to.
+func (rt *Runtime) Stdout() io.Writer { return rt.stdout }
+
+// Stderr returns the runtime's error stream.
+func (rt *Runtime) Stderr() io.Writer { return rt.stderr }
+
+// Stdin returns the runtime's input stream.
+func (rt *Runtime) Stdin() io.Reader { return rt.stdin }
+
+// print writes a value and a newline to Stdout in one write.
+func (rt *Runtime) print(v Value) error {
+	_, err := io.WriteString(rt.stdout, formatValue(v)+"\n")
+	return err
+}
+
+// lockedWriter lets concurrent runs share a writer that is not safe for
+// concurrent use, such as a bytes.Buffer.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+type lockedReader struct {
+	mu sync.Mutex
+	r  io.Reader
+}
+
+func (l *lockedReader) Read(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Read(p)
+}
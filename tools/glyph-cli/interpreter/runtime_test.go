@@ -0,0 +1,72 @@
+package interpreter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"glyph-cli/parser"
+)
+
+const counter = `fun int count(int n) {
+  var total = 0
+  val add = fun int (int a, int b) { a + b }
+  var i = 0
+  while i < n {
+    total = add(total, i)
+    i++
+  }
+  total
+}
+
+fun void main() {
+  print(count(100))
+}
+`
+
+func TestRuntimesRunConcurrently(t *testing.T) {
+	program, err := parser.ParseProgramSource("count.gly", counter)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	symbols := inlineSymbols(program)
+	code, err := Compile(program, symbols)
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	const runs = 8
+	var shared bytes.Buffer
+	rt := NewRuntime(Options{Stdout: &shared})
+	own := make([]bytes.Buffer, runs)
+	var wg sync.WaitGroup
+	for i := 0; i < runs; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			mine := NewRuntime(Options{Stdout: &own[i]})
+			for _, err := range []error{
+				rt.Eval(context.Background(), program, symbols),
+				rt.Run(context.Background(), code),
+				mine.Eval(context.Background(), program, symbols),
+				mine.Run(context.Background(), code),
+			} {
+				if err != nil {
+					t.Error(err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := strings.Count(shared.String(), "4950\n"); got != 2*runs || shared.Len() != 2*runs*len("4950\n") {
+		t.Fatalf("shared runtime printed %q", shared.String())
+	}
+	for i := range own {
+		if own[i].String() != "4950\n4950\n" {
+			t.Fatalf("runtime %d printed %q", i, own[i].String())
+		}
+	}
+}
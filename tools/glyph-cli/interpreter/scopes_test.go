@@ -0,0 +1,77 @@
+package interpreter
+
+import (
+	"strings"
+	"testing"
+
+	"glyph-cli/parser"
+)
+
+func TestShadowingFollowsBlocks(t *testing.T) {
+	source := `fun void main() {
+  val x = 1
+  var total = 0
+  if true {
+    val x = 2
+    total = total + x
+    if true {
+      val x = "inner"
+      print(x)
+    }
+    print(x)
+  }
+  print(x)
+  var i = 0
+  while i < 2 {
+    fresh = i * 10
+    total = total + fresh
+    i++
+  }
+  print(total)
+  val x = "again"
+  print(x)
+}
+`
+	if got := runSource(t, source); got != "inner\n2\n1\n12\nagain" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestMatchBindingsShadowOnlyTheirCase(t *testing.T) {
+	source := `type Pair = Both(left: int, right: int) | Neither()
+
+fun int pick(Pair p, int left) {
+  match p {
+    Both(left, 0) -> left
+    Both(_, right) -> left + right
+  } else left
+}
+
+fun void main() {
+  print(pick(Both(5, 0), 100))
+  print(pick(Both(5, 1), 100))
+  print(pick(Neither(), 100))
+}
+`
+	if got := runSource(t, source); got != "5\n101\n100" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestBlockVariablesAreNotVisibleOutside(t *testing.T) {
+	source := `fun void main() {
+  if true {
+    val later = 1
+  }
+  print(later)
+}
+`
+	program, err := parser.ParseProgramSource("test.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	_, err = output(Eval, program, inlineSymbols(program), Options{})
+	if err == nil || !strings.Contains(err.Error(), "undefined variable later") {
+		t.Fatalf("expected undefined variable error, got %v", err)
+	}
+}
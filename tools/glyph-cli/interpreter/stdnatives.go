@@ -0,0 +1,19 @@
+package interpreter
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// The natives that replace the placeholder bodies of glyph-stdlib.
+func init() {
+	for name, fn := range map[string]interface{}{
+		"std.strings.length":  utf8.RuneCountInString,
+		"std.strings.toUpper": strings.ToUpper,
+		"std.strings.toLower": strings.ToLower,
+	} {
+		if err := RegisterNative(name, fn); err != nil {
+			panic(err)
+		}
+	}
+}
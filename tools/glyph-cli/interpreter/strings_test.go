@@ -0,0 +1,19 @@
+package interpreter
+
+import "testing"
+
+func TestStringEscapesAndInterpolation(t *testing.T) {
+	source := `fun int main() {
+  val name = "Ada"
+  val n = 2
+  print("tab\there \"quoted\" back\\slash \u{263A} \${literal}")
+  print("hi ${name}, ${n + 1} items${"!"} ${null}")
+  0
+}
+`
+	got := runSource(t, source)
+	want := "tab\there \"quoted\" back\\slash ☺ ${literal}\nhi Ada, 3 items! null"
+	if got != want {
+		t.Fatalf("unexpected output:\n got %q\nwant %q", got, want)
+	}
+}
@@ -0,0 +1,411 @@
+package interpreter
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Value is a Glyph runtime value. Each kind of value has one Go
+// representation:
+//
+//	null      nil
+//	int       intValue
+//	long      longValue
+//	float     floatValue
+//	double    doubleValue
+//	char      charValue
+//	bool      boolValue
+//	string    stringValue
+//	array     arrayValue
+//	map       *mapValue
+//	record    *recordInstance
+//	variant   *variantInstance
+//	function  *closureValue
+//
+// The interface is closed: kind is unexported, so no other type is a
+// Value. Equal and Hash look at what values hold rather than where they
+// are, as == and map keys do.
+type Value interface {
+	kind() Kind
+}
+
+type (
+	intValue    int32
+	longValue   int64
+	floatValue  float32
+	doubleValue float64
+	boolValue   bool
+	stringValue string
+	arrayValue  []Value
+)
+
+func (intValue) kind() Kind         { return IntKind }
+func (longValue) kind() Kind        { return LongKind }
+func (floatValue) kind() Kind       { return FloatKind }
+func (doubleValue) kind() Kind      { return DoubleKind }
+func (charValue) kind() Kind        { return CharKind }
+func (boolValue) kind() Kind        { return BoolKind }
+func (stringValue) kind() Kind      { return StringKind }
+func (arrayValue) kind() Kind       { return ArrayKind }
+func (*mapValue) kind() Kind        { return MapKind }
+func (*recordInstance) kind() Kind  { return RecordKind }
+func (*variantInstance) kind() Kind { return VariantKind }
+func (*closureValue) kind() Kind    { return FunctionKind }
+
+// Kind is the kind of a Value.
+type Kind int
+
+const (
+	NullKind Kind = iota
+	IntKind
+	LongKind
+	FloatKind
+	DoubleKind
+	CharKind
+	BoolKind
+	StringKind
+	ArrayKind
+	MapKind
+	RecordKind
+	VariantKind
+	FunctionKind
+)
+
+var kindNames = [...]string{"null", "int", "long", "float", "double", "char", "bool", "string", "array", "map", "record", "variant", "function"}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+	return kindNames[k]
+}
+
+// KindOf returns the kind of v.
+func KindOf(v Value) Kind {
+	if v == nil {
+		return NullKind
+	}
+	return v.kind()
+}
+
+// Equal reports whether a == b in Glyph. Numbers are equal when they hold
+// exactly the same value, whatever their kinds: 1 == 1L == 1.0, but a long
+// is not rounded to a double first, so 2^53 + 1 and 2^53.0 differ. Arrays,
+// maps, records and variants are equal when their contents are, and
+// functions only when they are the same closure.
+func Equal(a, b Value) bool {
+	return equal(a, b, nil)
+}
+
+// visit is a pair of containers being compared, so that comparing values
+// that contain themselves ends.
+type visit struct {
+	a, b interface{}
+}
+
+func equal(a, b Value, seen map[visit]bool) bool {
+	if _, ok := numericKind(a); ok {
+		return sameNumber(a, b)
+	}
+	if KindOf(a) != KindOf(b) {
+		return false
+	}
+	switch x := a.(type) {
+	case nil:
+		return true
+	case boolValue:
+		return x == b.(boolValue)
+	case stringValue:
+		return x == b.(stringValue)
+	case arrayValue:
+		y := b.(arrayValue)
+		if len(x) != len(y) {
+			return false
+		}
+		if len(x) == 0 || !visiting(&seen, &x[0], &y[0]) {
+			return true
+		}
+		for i := range x {
+			if !equal(x[i], y[i], seen) {
+				return false
+			}
+		}
+		return true
+	case *mapValue:
+		y := b.(*mapValue)
+		if x.count != y.count {
+			return false
+		}
+		if !visiting(&seen, x, y) {
+			return true
+		}
+		for _, bucket := range x.buckets {
+			for _, e := range bucket {
+				v, ok := y.get(e.key)
+				if !ok || !equal(e.value, v, seen) {
+					return false
+				}
+			}
+		}
+		return true
+	case *recordInstance:
+		y := b.(*recordInstance)
+		if x.name != y.name || len(x.fields) != len(y.fields) {
+			return false
+		}
+		if !visiting(&seen, x, y) {
+			return true
+		}
+		for name, v := range x.fields {
+			w, ok := y.fields[name]
+			if !ok || !equal(v, w, seen) {
+				return false
+			}
+		}
+		return true
+	case *variantInstance:
+		y := b.(*variantInstance)
+		if x.sumType != y.sumType || x.variant != y.variant || len(x.values) != len(y.values) {
+			return false
+		}
+		if !visiting(&seen, x, y) {
+			return true
+		}
+		for i := range x.values {
+			if !equal(x.values[i], y.values[i], seen) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+// sameNumber reports whether the number a and b hold exactly the same
+// value.
+func sameNumber(a, b Value) bool {
+	ak, _ := numericKind(a)
+	bk, ok := numericKind(b)
+	if !ok {
+		return false
+	}
+	switch {
+	case ak <= kindLong && bk <= kindLong:
+		return asInt64(a) == asInt64(b)
+	case ak >= kindFloat && bk >= kindFloat:
+		return asFloat64(a) == asFloat64(b)
+	case ak <= kindLong:
+		n, whole := wholeNumber(asFloat64(b))
+		return whole && n == asInt64(a)
+	default:
+		n, whole := wholeNumber(asFloat64(a))
+		return whole && n == asInt64(b)
+	}
+}
+
+// wholeNumber returns f as an int64 if it is a whole number in range.
+func wholeNumber(f float64) (int64, bool) {
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// visiting records that containers a and b are being compared, returning
+// false if they already were further out.
+func visiting(seen *map[visit]bool, a, b interface{}) bool {
+	if a == b {
+		return false // the same container is equal to itself
+	}
+	if *seen == nil {
+		*seen = map[visit]bool{}
+	}
+	if (*seen)[visit{a, b}] {
+		return false
+	}
+	(*seen)[visit{a, b}] = true
+	return true
+}
+
+// Hash returns a hash of v such that Equal values hash alike. A container
+// used as a map key is hashed as it is when stored, so changing it later
+// loses the entry.
+func Hash(v Value) uint64 {
+	return hashValue(v, nil)
+}
+
+const (
+	hashOffset = 14695981039346656037
+	hashPrime  = 1099511628211
+)
+
+// mix adds the eight bytes of x to the FNV-1a hash h.
+func mix(h, x uint64) uint64 {
+	for i := 0; i < 8; i++ {
+		h ^= x & 0xff
+		h *= hashPrime
+		x >>= 8
+	}
+	return h
+}
+
+func mixString(h uint64, s string) uint64 {
+	h = mix(h, uint64(len(s)))
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= hashPrime
+	}
+	return h
+}
+
+// hashValue hashes v. path holds the containers v is inside of, so that a
+// container that holds itself is hashed once.
+func hashValue(v Value, path []interface{}) uint64 {
+	h := mix(hashOffset, uint64(KindOf(v)))
+	switch x := v.(type) {
+	case intValue, longValue, charValue:
+		return mix(mix(hashOffset, 'n'), uint64(asInt64(x)))
+	case floatValue, doubleValue:
+		// Whole numbers hash as the ints they equal.
+		f := asFloat64(x)
+		if n, whole := wholeNumber(f); whole {
+			return mix(mix(hashOffset, 'n'), uint64(n))
+		}
+		return mix(mix(hashOffset, 'f'), math.Float64bits(f))
+	case boolValue:
+		if x {
+			return mix(h, 1)
+		}
+		return mix(h, 0)
+	case stringValue:
+		return mixString(h, string(x))
+	case arrayValue:
+		h = mix(h, uint64(len(x)))
+		if len(x) == 0 || onPath(path, &x[0]) {
+			return h
+		}
+		path = append(path, &x[0])
+		for _, e := range x {
+			h = mix(h, hashValue(e, path))
+		}
+		return h
+	case *mapValue:
+		h = mix(h, uint64(x.count))
+		if onPath(path, x) {
+			return h
+		}
+		path = append(path, x)
+		// Entries are combined in an order-independent way, as maps have
+		// no order.
+		var sum uint64
+		for _, bucket := range x.buckets {
+			for _, e := range bucket {
+				sum += mix(hashValue(e.key, path), hashValue(e.value, path))
+			}
+		}
+		return mix(h, sum)
+	case *recordInstance:
+		h = mixString(h, x.name)
+		if onPath(path, x) {
+			return h
+		}
+		path = append(path, x)
+		var sum uint64
+		for name, field := range x.fields {
+			sum += mix(mixString(hashOffset, name), hashValue(field, path))
+		}
+		return mix(h, sum)
+	case *variantInstance:
+		h = mixString(mixString(h, x.sumType), x.variant)
+		if onPath(path, x) {
+			return h
+		}
+		path = append(path, x)
+		for _, field := range x.values {
+			h = mix(h, hashValue(field, path))
+		}
+		return h
+	case *closureValue:
+		return mix(h, uint64(reflect.ValueOf(x).Pointer()))
+	default:
+		return h
+	}
+}
+
+func onPath(path []interface{}, container interface{}) bool {
+	for _, p := range path {
+		if p == container {
+			return true
+		}
+	}
+	return false
+}
+
+// mapValue is a Glyph map. Entries are found by the Hash of their key and
+// then compared with Equal, so keys that are == find the same entry: 1
+// and 1L, or two records with the same fields.
+type mapValue struct {
+	buckets map[uint64][]mapEntry
+	count   int
+}
+
+type mapEntry struct {
+	key, value Value
+}
+
+func newMap(capacity int) *mapValue {
+	return &mapValue{buckets: make(map[uint64][]mapEntry, capacity)}
+}
+
+func (m *mapValue) get(key Value) (Value, bool) {
+	for _, e := range m.buckets[Hash(key)] {
+		if Equal(e.key, key) {
+			return e.value, true
+		}
+	}
+	return nil, false
+}
+
+func (m *mapValue) set(key, value Value) {
+	h := Hash(key)
+	bucket := m.buckets[h]
+	for i := range bucket {
+		if Equal(bucket[i].key, key) {
+			bucket[i].value = value
+			return
+		}
+	}
+	m.buckets[h] = append(bucket, mapEntry{key, value})
+	m.count++
+}
+
+// keys returns the keys of m in the order iteration visits them.
+func (m *mapValue) keys() []Value {
+	keys := make([]Value, 0, m.count)
+	for _, bucket := range m.buckets {
+		for _, e := range bucket {
+			keys = append(keys, e.key)
+		}
+	}
+	sortKeys(keys)
+	return keys
+}
+
+// String renders m as fmt renders a Go map, which is how print has always
+// shown maps.
+func (m *mapValue) String() string {
+	var buf strings.Builder
+	buf.WriteString("map[")
+	for i, key := range m.keys() {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		value, _ := m.get(key)
+		fmt.Fprintf(&buf, "%v:%v", key, value)
+	}
+	buf.WriteByte(']')
+	return buf.String()
+}
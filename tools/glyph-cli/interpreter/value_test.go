@@ -0,0 +1,87 @@
+package interpreter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEqualAndHashCompareContents(t *testing.T) {
+	point := func(x, y intValue) *recordInstance {
+		return &recordInstance{name: "Point", fields: map[string]Value{"x": x, "y": y}}
+	}
+	nested := newMap(0)
+	nested.set(stringValue("a"), arrayValue{intValue(1), point(1, 2)})
+	same := newMap(0)
+	same.set(stringValue("a"), arrayValue{longValue(1), point(1, 2)})
+	cases := []struct {
+		name  string
+		a, b  Value
+		equal bool
+	}{
+		{"int and long", intValue(1), longValue(1), true},
+		{"int and whole double", intValue(2), doubleValue(2), true},
+		{"long and exact double", longValue(1 << 53), doubleValue(1 << 53), true},
+		{"long and rounded double", longValue(1<<53 + 1), doubleValue(1 << 53), false},
+		{"int and rounded float", intValue(1<<24 + 1), floatValue(1 << 24), false},
+		{"char and int", charValue('a'), intValue(97), true},
+		{"strings", stringValue("glyph"), stringValue("glyph"), true},
+		{"records", point(1, 2), point(1, 2), true},
+		{"record fields", point(1, 2), point(2, 1), false},
+		{"arrays", arrayValue{intValue(1), stringValue("a")}, arrayValue{longValue(1), stringValue("a")}, true},
+		{"array lengths", arrayValue{intValue(1)}, arrayValue{intValue(1), intValue(1)}, false},
+		{"maps", nested, same, true},
+		{"null and zero", nil, intValue(0), false},
+		{"string and char", stringValue("a"), charValue('a'), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Equal(tc.a, tc.b); got != tc.equal {
+				t.Fatalf("Equal = %v, want %v", got, tc.equal)
+			}
+			if tc.equal && Hash(tc.a) != Hash(tc.b) {
+				t.Fatalf("equal values hash differently: %d, %d", Hash(tc.a), Hash(tc.b))
+			}
+		})
+	}
+}
+
+func TestEqualEndsOnValuesThatContainThemselves(t *testing.T) {
+	a := arrayValue{nil}
+	a[0] = a
+	b := arrayValue{nil}
+	b[0] = b
+	if !Equal(a, b) {
+		t.Fatal("Equal = false, want true")
+	}
+	if Hash(a) != Hash(b) {
+		t.Fatal("equal values hash differently")
+	}
+}
+
+func TestMapsKeyOnValues(t *testing.T) {
+	source := `record Point {
+  int x
+  int y
+}
+
+fun void main() {
+  val seen = [Point: string] {}
+  seen[Point { x = 1, y = 2 }] = "first"
+  seen[Point { x = 1, y = 2 }] = "again"
+  print(seen[Point { x = 1, y = 2 }])
+  print(seen[Point { x = 2, y = 1 }])
+  val long one = 1L
+  val names = [long: string] { one: "one" }
+  print(names[1])
+  val grid = [[int]: string] {}
+  grid[[int](2)] = "origin"
+  print(grid[[int](2)])
+  print(seen == [Point: string] { Point { x = 1, y = 2 }: "again" })
+}
+`
+	want := []string{"again", "null", "one", "origin", "true"}
+	got := strings.Split(runSource(t, source), "\n")
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
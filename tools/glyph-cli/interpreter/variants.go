@@ -0,0 +1,84 @@
+package interpreter
+
+import (
+	"fmt"
+	"strings"
+
+	"glyph-cli/ast"
+)
+
+// variantInstance is a value built by a sum type constructor such as Ok(42).
+type variantInstance struct {
+	sumType string
+	variant string
+	fields  []string
+	values  []Value
+}
+
+func (v *variantInstance) String() string {
+	parts := make([]string, len(v.values))
+	for i, val := range v.values {
+		parts[i] = formatValue(val)
+	}
+	return v.variant + "(" + strings.Join(parts, ", ") + ")"
+}
+
+func (v *variantInstance) field(name string) (Value, bool) {
+	for i, field := range v.fields {
+		if field == name {
+			return v.values[i], true
+		}
+	}
+	return nil, false
+}
+
+func constructVariant(sum *ast.SumTypeDecl, variant *ast.VariantDecl, args []Value) (Value, error) {
+	if len(args) != len(variant.Fields) {
+		return nil, fmt.Errorf("constructor %s expects %d argument(s) but received %d", variant.Name, len(variant.Fields), len(args))
+	}
+	inst := &variantInstance{
+		sumType: sum.Name,
+		variant: variant.Name,
+		fields:  make([]string, len(variant.Fields)),
+		values:  make([]Value, len(args)),
+	}
+	for i, field := range variant.Fields {
+		val, err := coerceDeclared(field.Type, args[i])
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %v", variant.Name, field.Name, err)
+		}
+		inst.fields[i] = field.Name
+		inst.values[i] = val
+	}
+	return inst, nil
+}
+
+// matchVariantPattern matches Variant(p1, p2, ...) positionally against the
+// fields of a variant value. Variant(...) with no fields also matches a
+// record of that name, since the grammar cannot tell `User()` apart.
+func matchVariantPattern(pattern *ast.VariantPattern, value Value, scope *environment, st *state) (bool, error) {
+	sum, variant, known := st.symbols.Variant(pattern.Variant)
+	if !known {
+		if _, isRecord := st.symbols.Records[pattern.Variant]; isRecord && len(pattern.Fields) == 0 {
+			rec, ok := value.(*recordInstance)
+			return ok && rec.name == pattern.Variant, nil
+		}
+		return false, errorAt(pattern, "unknown variant %s", pattern.Variant)
+	}
+	if pattern.TypeName != "" && pattern.TypeName != sum.Name {
+		return false, errorAt(pattern, "%s is not a variant of %s", pattern.Variant, pattern.TypeName)
+	}
+	if len(pattern.Fields) != len(variant.Fields) {
+		return false, errorAt(pattern, "pattern %s has %d field(s) but the variant declares %d", pattern.Variant, len(pattern.Fields), len(variant.Fields))
+	}
+	inst, ok := value.(*variantInstance)
+	if !ok || inst.sumType != sum.Name || inst.variant != variant.Name {
+		return false, nil
+	}
+	for i, fieldPattern := range pattern.Fields {
+		if matched, err := matchPattern(fieldPattern, inst.values[i], scope, st); err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,48 @@
+package interpreter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSumTypesConstructAndMatch(t *testing.T) {
+	source := `package com.example.app
+
+type Result =
+  | Ok(value: int)
+  | Err(message: string)
+
+type Shape = Circle(r: double) | Square(s: int)
+
+fun int eval(Result r) {
+  return match r {
+    Ok(v) -> v
+    Err(_) -> 0
+  } else 0
+}
+
+fun string describe(Shape s) {
+  return match s {
+    Shape.Circle(r) -> "circle ${r}"
+    Square(1) -> "unit square"
+    Square(n) -> "square ${n}"
+  } else "?"
+}
+
+fun void main() {
+  print(eval(Ok(42)))
+  print(eval(Err("boom")))
+  print(describe(Circle(2)))
+  print(describe(Square(1)))
+  print(Square(3).describe())
+  print(Err("bad").message)
+  print(Ok(1) == Ok(1))
+  print(Ok(7))
+}
+`
+	want := []string{"42", "0", "circle 2.0", "unit square", "square 3", "bad", "true", "Ok(7)"}
+	got := strings.Split(runSource(t, source), "\n")
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,541 @@
+package interpreter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"glyph-cli/ast"
+	"glyph-cli/project"
+)
+
+// EvalVM compiles the program and runs it on the bytecode VM. Its output
+// and errors, including those for exceeding opts, are the same as Eval's.
+func EvalVM(ctx context.Context, program *ast.Program, symbols *project.Symbols, opts Options) error {
+	return NewRuntime(opts).EvalVM(ctx, program, symbols)
+}
+
+// EvalVM compiles the program and runs it on the bytecode VM, like the
+// package-level EvalVM.
+func (rt *Runtime) EvalVM(ctx context.Context, program *ast.Program, symbols *project.Symbols) error {
+	code, err := Compile(program, symbols)
+	if err != nil {
+		return err
+	}
+	return rt.Run(ctx, code)
+}
+
+// Run executes the main function of the compiled program within the
+// limits of opts and ctx, as Eval does.
+func (b *Bytecode) Run(ctx context.Context, opts Options) error {
+	return NewRuntime(opts).Run(ctx, b)
+}
+
+// Run executes the main function of code. Bytecode is not changed by
+// running it, so it can be compiled once and run many times, including
+// at the same time.
+func (rt *Runtime) Run(ctx context.Context, code *Bytecode) error {
+	if code.main == nil {
+		return fmt.Errorf("main function not found")
+	}
+	budget, done := newBudget(ctx, rt.opts)
+	defer done()
+	_, err := code.call(rt, budget, code.main, nil)
+	return err
+}
+
+// frame is the activation of a compiled function. Its slots start at base
+// on the VM stack, with the operands above them.
+type frame struct {
+	fn   *function
+	ip   int
+	base int
+	// callee is set when the closure or function called sits just below
+	// the frame and goes when it returns.
+	callee bool
+}
+
+// call runs fn with args to completion. Calls between Glyph functions do
+// not nest Go calls, so deep recursion only grows the VM stack.
+func (b *Bytecode) call(rt *Runtime, budget *budget, fn *function, args []Value) (result Value, err error) {
+	if len(args) != fn.params {
+		return nil, trace(fmt.Errorf("function %s expects %d argument(s) but received %d", fn.name, fn.params, len(args)), nil, frame{})
+	}
+	if fn.decl != nil && runsNative(fn.decl) {
+		result, err := rt.callNative(fn.symbols, fn.decl, args)
+		if err != nil {
+			return nil, trace(causeAt(nil, err), nil, frame{})
+		}
+		return result, nil
+	}
+	stack := make([]Value, 0, 1024)
+	stack = append(stack, args...)
+	convertArgs(stack, fn.args)
+	stack = extend(stack, fn.locals-len(args))
+	var frames []frame
+	cur := frame{fn: fn}
+	defer func() {
+		if err != nil {
+			err = trace(err, frames, cur)
+		}
+	}()
+	code := fn.code
+	consts := b.consts
+
+	for {
+		in := code[cur.ip]
+		cur.ip++
+		switch in.op {
+		case opConst:
+			stack = append(stack, consts[in.a].(Value))
+		case opNil:
+			stack = append(stack, nil)
+		case opPop:
+			stack = stack[:len(stack)-1]
+		case opDup:
+			stack = append(stack, stack[len(stack)-1])
+		case opDup2:
+			stack = append(stack, stack[len(stack)-2], stack[len(stack)-1])
+		case opLoad:
+			stack = append(stack, stack[cur.base+int(in.a)])
+		case opStore:
+			stack[cur.base+int(in.a)] = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+		case opFail:
+			failure := *consts[in.a].(*RuntimeError)
+			return nil, &failure
+		case opJump:
+			cur.ip = int(in.a)
+		case opJumpIfFalse:
+			cond, ok := stack[len(stack)-1].(boolValue)
+			stack = stack[:len(stack)-1]
+			if !ok {
+				return nil, cur.fail("%s", consts[in.b])
+			}
+			if !cond {
+				cur.ip = int(in.a)
+			}
+		case opJumpIfNil:
+			if stack[len(stack)-1] == nil {
+				cur.ip = int(in.a)
+			}
+		case opJumpIfNotNil:
+			if stack[len(stack)-1] != nil {
+				cur.ip = int(in.a)
+			}
+		case opJumpIfNotClosure:
+			if _, ok := stack[len(stack)-1].(*closureValue); !ok {
+				cur.ip = int(in.a)
+			}
+		case opShortCircuit:
+			if stack[len(stack)-1].(boolValue) == (in.b == 1) {
+				cur.ip = int(in.a)
+			} else {
+				stack = stack[:len(stack)-1]
+			}
+		case opCheckBool:
+			if _, ok := stack[len(stack)-1].(boolValue); !ok {
+				return nil, cur.fail("operator %s expects bool, got %s", consts[in.a], typeName(stack[len(stack)-1]))
+			}
+		case opNot:
+			v, ok := stack[len(stack)-1].(boolValue)
+			if !ok {
+				return nil, cur.fail("operator ! expects bool, got %s", typeName(stack[len(stack)-1]))
+			}
+			stack[len(stack)-1] = !v
+		case opNeg:
+			v, err := negate(stack[len(stack)-1])
+			if err != nil {
+				return nil, cur.fail("%v", err)
+			}
+			stack[len(stack)-1] = v
+		case opArith:
+			left, right := stack[len(stack)-2], stack[len(stack)-1]
+			val, ok := intArith(left, right, in.a)
+			if !ok {
+				var err error
+				if val, err = numericBinary(left, right, binaryOps[in.a]); err != nil {
+					return nil, cur.fail("%v", err)
+				}
+			}
+			stack = stack[:len(stack)-1]
+			stack[len(stack)-1] = val
+		case opCompare:
+			left, right := stack[len(stack)-2], stack[len(stack)-1]
+			val, ok := intCompare(left, right, in.a)
+			if !ok {
+				var err error
+				if val, err = comparisonBinary(left, right, binaryOps[in.a]); err != nil {
+					return nil, cur.fail("%v", err)
+				}
+			}
+			stack = stack[:len(stack)-1]
+			stack[len(stack)-1] = val
+		case opEqual:
+			eq := Equal(stack[len(stack)-2], stack[len(stack)-1])
+			stack = stack[:len(stack)-1]
+			stack[len(stack)-1] = boolValue(eq != (in.b == 1))
+		case opConcat:
+			var buf strings.Builder
+			parts := stack[len(stack)-int(in.a):]
+			for _, part := range parts {
+				buf.WriteString(formatValue(part))
+			}
+			stack = append(stack[:len(stack)-int(in.a)], stringValue(buf.String()))
+		case opPrint:
+			if err := rt.print(stack[len(stack)-1]); err != nil {
+				return nil, cur.fail("%v", err)
+			}
+			stack = stack[:len(stack)-1]
+		case opCoerce:
+			v, err := coerceDeclared(consts[in.a].(string), stack[len(stack)-1])
+			if err != nil {
+				return nil, cur.fail("%v", err)
+			}
+			stack[len(stack)-1] = v
+		case opStep:
+			op := "++"
+			if in.a == 1 {
+				op = "--"
+			}
+			v, err := step(stack[len(stack)-1], op)
+			if err != nil {
+				return nil, cur.fail("%v", err)
+			}
+			stack[len(stack)-1] = v
+		case opRecord:
+			rec := b.records[in.a]
+			n := len(rec.Fields)
+			inst := newRecord(rec, stack[len(stack)-n:])
+			stack = append(stack[:len(stack)-n], inst)
+		case opArray:
+			arr, err := newArray(stack[len(stack)-1], cur.fn.nodes[cur.ip-1].(*ast.ArrayAllocExpr))
+			if err != nil {
+				return nil, err
+			}
+			stack[len(stack)-1] = arr
+		case opMap:
+			n := 2 * int(in.a)
+			m := newMap(int(in.a))
+			entries := stack[len(stack)-n:]
+			for i := 0; i < n; i += 2 {
+				m.set(entries[i], entries[i+1])
+			}
+			stack = append(stack[:len(stack)-n], m)
+		case opField, opSafeField:
+			get := fieldValue
+			if in.op == opSafeField {
+				get = safeFieldValue
+			}
+			v, err := get(stack[len(stack)-1], consts[in.a].(string), cur.fn.nodes[cur.ip-1])
+			if err != nil {
+				return nil, err
+			}
+			stack[len(stack)-1] = v
+		case opIndex:
+			v, err := indexValueAt(stack[len(stack)-2], stack[len(stack)-1], cur.fn.nodes[cur.ip-1].(*ast.IndexAccess))
+			if err != nil {
+				return nil, err
+			}
+			stack = stack[:len(stack)-1]
+			stack[len(stack)-1] = v
+		case opFieldTarget:
+			rec, err := fieldTarget(stack[len(stack)-1], consts[in.a].(string), cur.fn.nodes[cur.ip-1])
+			if err != nil {
+				return nil, err
+			}
+			stack[len(stack)-1] = rec
+		case opRecordField:
+			stack[len(stack)-1] = stack[len(stack)-1].(*recordInstance).fields[consts[in.a].(string)]
+		case opSetField:
+			stack[len(stack)-2].(*recordInstance).fields[consts[in.a].(string)] = stack[len(stack)-1]
+			stack = stack[:len(stack)-2]
+		case opIndexTarget:
+			key, err := indexTarget(stack[len(stack)-2], stack[len(stack)-1], consts[in.a].(*ast.IndexAccess), cur.fn.nodes[cur.ip-1])
+			if err != nil {
+				return nil, err
+			}
+			stack[len(stack)-1] = key
+		case opElement:
+			v := elementAt(stack[len(stack)-2], stack[len(stack)-1])
+			stack = stack[:len(stack)-1]
+			stack[len(stack)-1] = v
+		case opSetElement:
+			setElement(stack[len(stack)-3], stack[len(stack)-2], stack[len(stack)-1])
+			stack = stack[:len(stack)-3]
+		case opClosure:
+			closure := cur.fn.closures[in.a]
+			captured := make([]Value, len(closure.captures))
+			for i, slot := range closure.captures {
+				captured[i] = stack[cur.base+slot]
+			}
+			stack = append(stack, &closureValue{lambda: closure.fn.lambda, captured: captured, code: closure.fn})
+		case opCall:
+			callee := b.functions[in.a]
+			if int(in.b) != callee.params {
+				return nil, cur.fail("function %s expects %d argument(s) but received %d", callee.name, callee.params, in.b)
+			}
+			if runsNative(callee.decl) {
+				if stack, err = callNative(rt, stack, callee, int(in.b), false, cur.fn.nodes[cur.ip-1]); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := budget.enter(len(frames)+1, cur.fn.nodes[cur.ip-1]); err != nil {
+				return nil, err
+			}
+			frames = append(frames, cur)
+			stack, cur = enter(stack, callee, int(in.b), false)
+			code = callee.code
+		case opCallValue:
+			argc := int(in.b)
+			closure := stack[len(stack)-argc-1].(*closureValue)
+			callee := closure.code
+			if argc != callee.params {
+				return nil, cur.fail("callable expects %d argument(s) but received %d", callee.params, argc)
+			}
+			if err := budget.enter(len(frames)+1, cur.fn.nodes[cur.ip-1]); err != nil {
+				return nil, err
+			}
+			frames = append(frames, cur)
+			stack, cur = enter(stack, callee, argc, true)
+			code = callee.code
+			end := cur.base + closure.lambda.Slots
+			copy(stack[end-len(closure.captured):end], closure.captured)
+		case opLookupMember:
+			recv := stack[len(stack)-1]
+			fn, err := cur.fn.symbols.LookupMember(consts[in.a].(string), typeName(recv))
+			if err != nil {
+				return nil, cur.fail("%v", err)
+			}
+			stack[len(stack)-1] = intValue(b.byDecl[fn].id)
+			stack = append(stack, recv)
+		case opCallMember:
+			argc := int(in.b)
+			callee := b.functions[stack[len(stack)-argc-1].(intValue)]
+			if argc != callee.params {
+				return nil, cur.fail("function %s expects %d argument(s) but received %d", callee.name, callee.params, argc)
+			}
+			if runsNative(callee.decl) {
+				if stack, err = callNative(rt, stack, callee, argc, true, cur.fn.nodes[cur.ip-1]); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := budget.enter(len(frames)+1, cur.fn.nodes[cur.ip-1]); err != nil {
+				return nil, err
+			}
+			frames = append(frames, cur)
+			stack, cur = enter(stack, callee, argc, true)
+			code = callee.code
+		case opVariant:
+			ref := b.variants[in.a]
+			n := int(in.b)
+			inst, err := constructVariant(ref.sum, ref.variant, stack[len(stack)-n:])
+			if err != nil {
+				return nil, cur.fail("%v", err)
+			}
+			stack = append(stack[:len(stack)-n], inst)
+		case opRange:
+			n := int(in.a)
+			out, err := builtinRange(stack[len(stack)-n:])
+			if err != nil {
+				return nil, cur.fail("%v", err)
+			}
+			stack = append(stack[:len(stack)-n], out)
+		case opReturn:
+			result := stack[len(stack)-1]
+			top := cur.base
+			if cur.callee {
+				top--
+			}
+			clear(stack[top:])
+			stack = stack[:top]
+			if len(frames) == 0 {
+				return result, nil
+			}
+			stack = append(stack, result)
+			cur = frames[len(frames)-1]
+			frames = frames[:len(frames)-1]
+			code = cur.fn.code
+		case opIterItems:
+			items, err := iterationItems(cur.fn.nodes[cur.ip-1].(*ast.IterateExpr), stack[len(stack)-1])
+			if err != nil {
+				return nil, err
+			}
+			stack = stack[:len(stack)-1]
+			stack[cur.base+int(in.a)] = arrayValue(items)
+			stack[cur.base+int(in.a)+1] = intValue(0)
+		case opIterNext:
+			items := stack[cur.base+int(in.a)].(arrayValue)
+			i := int(stack[cur.base+int(in.b)].(intValue))
+			if i >= len(items) {
+				cur.ip = int(in.c)
+				break
+			}
+			stack[cur.base+int(in.b)] = intValue(i + 1)
+			stack = append(stack, items[i])
+		case opMark:
+			stack[cur.base+int(in.a)] = intValue(len(stack))
+		case opUnwind:
+			stack = stack[:stack[cur.base+int(in.a)].(intValue)]
+		case opMatchLiteral:
+			literal, _ := consts[in.b].(Value) // nil for null
+			if !Equal(stack[cur.base+int(in.a)], literal) {
+				cur.ip = int(in.c)
+			}
+		case opMatchRecord:
+			rec, ok := stack[cur.base+int(in.a)].(*recordInstance)
+			if !ok || rec.name != consts[in.b].(string) {
+				cur.ip = int(in.c)
+			}
+		case opMatchField:
+			v, ok := stack[cur.base+int(in.a)].(*recordInstance).fields[consts[in.b].(string)]
+			if !ok {
+				cur.ip = int(in.c)
+				break
+			}
+			stack = append(stack, v)
+		case opMatchVariant:
+			ref := b.variants[in.b]
+			inst, ok := stack[cur.base+int(in.a)].(*variantInstance)
+			if !ok || inst.sumType != ref.sum.Name || inst.variant != ref.variant.Name {
+				cur.ip = int(in.c)
+			}
+		case opVariantValue:
+			stack = append(stack, stack[cur.base+int(in.a)].(*variantInstance).values[in.b])
+		case opTick:
+			budget.steps++
+			if budget.steps >= budget.next {
+				if err := budget.check(cur.fn.nodes[cur.ip-1]); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			return nil, fmt.Errorf("unknown opcode %s", in.op)
+		}
+	}
+}
+
+// trace gives err the frames of the calls in progress, cur being the
+// innermost, unless a call further in already did.
+func trace(err error, frames []frame, cur frame) error {
+	re := runtimeError(err)
+	if re.Frames != nil {
+		return re
+	}
+	re.Frames = make([]Frame, 0, len(frames)+1)
+	if cur.fn != nil {
+		line := lineOf(cur.fn.nodes[cur.ip-1])
+		if re.Pos.IsValid() {
+			line = re.Pos.Line
+		}
+		re.Frames = append(re.Frames, cur.fn.frame(line))
+	}
+	for i := len(frames) - 1; i >= 0; i-- {
+		f := frames[i]
+		re.Frames = append(re.Frames, f.fn.frame(lineOf(f.fn.nodes[f.ip-1])))
+	}
+	return re
+}
+
+// callNative calls the native of callee with the argc arguments on top of
+// stack and replaces them with its result, along with the callee value
+// below them if below is set, as it is for a member call. site is the
+// call.
+func callNative(rt *Runtime, stack []Value, callee *function, argc int, below bool, site ast.Node) ([]Value, error) {
+	top := len(stack) - argc
+	result, err := rt.callNative(callee.symbols, callee.decl, stack[top:])
+	if err != nil {
+		return nil, causeAt(site, err)
+	}
+	if below {
+		top--
+	}
+	clear(stack[top:])
+	return append(stack[:top], result), nil
+}
+
+func (fn *function) frame(line int) Frame {
+	file := ""
+	if fn.decl != nil {
+		file = fn.decl.Pos.File
+	} else {
+		file = fn.lambda.Pos.File
+	}
+	return Frame{Function: fn.name, File: file, Line: line}
+}
+
+// enter starts a call of callee with the argc arguments on top of stack.
+func enter(stack []Value, callee *function, argc int, below bool) ([]Value, frame) {
+	base := len(stack) - argc
+	convertArgs(stack[base:], callee.args)
+	return extend(stack, callee.locals-argc), frame{fn: callee, base: base, callee: below}
+}
+
+// fail reports an error at the node of the instruction being run.
+func (f *frame) fail(format string, args ...interface{}) error {
+	if node := f.fn.nodes[f.ip-1]; node != nil {
+		return errorAt(node, format, args...)
+	}
+	return fmt.Errorf(format, args...)
+}
+
+// extend grows stack by n null slots.
+func extend(stack []Value, n int) []Value {
+	size := len(stack) + n
+	if size > cap(stack) {
+		grown := make([]Value, len(stack), 2*size)
+		copy(grown, stack)
+		stack = grown
+	}
+	stack = stack[:size]
+	clear(stack[size-n:])
+	return stack
+}
+
+// intArith is the fast path of opArith for two ints. ok is false when the
+// general numericBinary is needed.
+func intArith(left, right Value, op int32) (Value, bool) {
+	l, lok := left.(intValue)
+	r, rok := right.(intValue)
+	if !lok || !rok {
+		return nil, false
+	}
+	switch op {
+	case 0: // +
+		return l + r, true
+	case 1: // -
+		return l - r, true
+	case 2: // *
+		return l * r, true
+	case 3: // /
+		if r != 0 {
+			return l / r, true
+		}
+	case 4: // %
+		if r != 0 {
+			return l % r, true
+		}
+	}
+	return nil, false
+}
+
+func intCompare(left, right Value, op int32) (Value, bool) {
+	l, lok := left.(intValue)
+	r, rok := right.(intValue)
+	if !lok || !rok {
+		return nil, false
+	}
+	switch op {
+	case 5: // <
+		return boolValue(l < r), true
+	case 6: // <=
+		return boolValue(l <= r), true
+	case 7: // >
+		return boolValue(l > r), true
+	case 8: // >=
+		return boolValue(l >= r), true
+	}
+	return nil, false
+}
@@ -0,0 +1,142 @@
+package interpreter
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"glyph-cli/ast"
+	"glyph-cli/parser"
+	"glyph-cli/project"
+)
+
+// runBoth runs program on the interpreter and on the VM and fails the test
+// unless both print the same and fail with the same error.
+func runBoth(t *testing.T, program *ast.Program, symbols *project.Symbols) (string, error) {
+	t.Helper()
+	return runBothWith(t, program, symbols, Options{})
+}
+
+func runBothWith(t *testing.T, program *ast.Program, symbols *project.Symbols, opts Options) (string, error) {
+	t.Helper()
+	out, err := output(Eval, program, symbols, opts)
+	vmOut, vmErr := output(EvalVM, program, symbols, opts)
+	if vmOut != out {
+		t.Errorf("vm printed %q, interpreter printed %q", vmOut, out)
+	}
+	if errorText(vmErr) != errorText(err) {
+		t.Errorf("vm failed with %q, interpreter with %q", errorText(vmErr), errorText(err))
+	}
+	var want, got *RuntimeError
+	if errors.As(err, &want) && errors.As(vmErr, &got) && got.Trace() != want.Trace() {
+		t.Errorf("vm trace:\n%s\ninterpreter trace:\n%s", got.Trace(), want.Trace())
+	}
+	return out, err
+}
+
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func TestVMReportsTheInterpretersErrors(t *testing.T) {
+	cases := []struct {
+		name, body, want string
+	}{
+		{"undefined variable", "print(missing)", "undefined variable missing"},
+		{"division by zero", "val z = 0\n  print(10 / z)", "division by zero"},
+		{"while condition", "while 1 {\n  }", "while condition must be bool"},
+		{"logical operand", "val b = 1\n  print(true && b)", "operator && expects bool, got int"},
+		{"index non-collection", "val n = 1\n  print(n[0])", "index access on non-collection"},
+		{"unknown record", "val p = Nowhere { x = 1 }\n  print(p)", "unknown record Nowhere"},
+		{"unknown function", "nowhere(1)", "nowhere"},
+		{"arity", "takesOne(1, 2)", "test.gly:11:3: function takesOne expects 1 argument(s) but received 2"},
+		{"member arity", "val n = 1\n  print(n.takesOne(2))", "test.gly:12:9: function takesOne expects 1 argument(s) but received 2"},
+		{"local shadows function", "val takesOne = 1\n  print(takesOne(2))", "test.gly:12:9: takesOne is not callable"},
+		{"closure arity", "val f = fun (int x) { x }\n  print(f(1, 2))", "test.gly:12:9: callable expects 1 argument(s) but received 2"},
+		{"unmatched", "print(match 3 {\n    1 -> \"one\"\n  })", "match"},
+		{"break outside loop", "break", "break"},
+		{"null field", "val Point? p = null\n  print(p.x)", "field access on non-record"},
+		{"index out of bounds", "val xs = [int](2)\n  print(xs[2])", "array index 2 is out of bounds for length 2"},
+		{"assign out of bounds", "val xs = [int](2)\n  xs[-1] = 1", "array index -1 is out of bounds for length 2"},
+		{"negative size", "val n = -1\n  val xs = [int](n)", "array size must not be negative, got -1"},
+		{"after output", "print(\"before\")\n  val xs = [int](1)\n  xs[\"a\"] = 1", "array index must be an int"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			source := "record Point {\n  int x\n  int y\n}\n\nfun int takesOne(int n) {\n  n\n}\n\nfun void main() {\n  " + tc.body + "\n}\n"
+			program, err := parser.ParseProgramSource("test.gly", source)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			_, err = runBoth(t, program, inlineSymbols(program))
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("got error %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestVMRunsTheExamples(t *testing.T) {
+	examples := "../../../examples"
+	cases := []struct {
+		name, root, entry, lib string
+		// want is the exact output, when the test checks it.
+		want string
+	}{
+		{"closure-playground", "closure-playground/src/main/glyph", "com/example/closures/main.gly", "", ""},
+		{"stdlib-test", "stdlib-test/src/main/glyph", "com/example/stdlib/main.gly", "../../../glyph-stdlib/src/main/glyph",
+			"Hello from stdlib test!\nabs(-42) produced 42\nclamp(15, 0, 10) produced 10"},
+		{"advanced-features", "advanced-features/src/main/glyph", "com/example/advanced/app/main.gly", "", ""},
+		{"wasm-abi-tests", "wasm-abi-tests/src/main/glyph", "com/example/abi/main.gly", "", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			root, err := filepath.Abs(filepath.Join(examples, tc.root))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var libs []string
+			if tc.lib != "" {
+				libs = append(libs, tc.lib)
+			}
+			index, err := project.BuildIndex(root, libs...)
+			if err != nil {
+				t.Fatalf("index: %v", err)
+			}
+			index.Natives = DefaultNatives()
+			program := index.Programs[filepath.Join(root, tc.entry)]
+			if program == nil {
+				t.Fatalf("%s is not in the index", tc.entry)
+			}
+			symbols, err := project.Resolve(program, index)
+			if err != nil {
+				t.Fatalf("resolve: %v", err)
+			}
+			out, err := runBoth(t, program, symbols)
+			if err != nil {
+				t.Fatalf("%s failed: %v", tc.name, err)
+			}
+			if out == "" {
+				t.Fatalf("%s printed nothing", tc.name)
+			}
+			if tc.want != "" && out != tc.want {
+				t.Fatalf("%s printed %q, want %q", tc.name, out, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompileRejectsUnresolvedFunctions(t *testing.T) {
+	program, err := parser.ParseProgramSource("test.gly", "fun void main() {\n  print(1)\n}\n")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	symbols := &project.Symbols{Functions: map[string]*ast.FunctionDecl{"main": program.Functions[0]}}
+	if _, err := Compile(program, symbols); err == nil || !strings.Contains(err.Error(), "has not been resolved") {
+		t.Fatalf("got %v, want an unresolved function error", err)
+	}
+}
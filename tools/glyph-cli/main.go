@@ -1,16 +1,21 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
 
 	"glyph-cli/ast"
 	"glyph-cli/interpreter"
 	"glyph-cli/parser"
 	"glyph-cli/project"
+	"glyph-cli/typecheck"
 )
 
 func main() {
@@ -21,6 +26,12 @@ func main() {
 	var helpShort bool
 	var runWasm bool
 	var libPath string
+	var noTypecheck bool
+	var useVM bool
+	var jsonErrors bool
+	var maxSteps int64
+	var maxDepth int
+	var timeout time.Duration
 
 	flag.StringVar(&sourcePath, "file", "", "Path to a Glyph source file")
 	flag.StringVar(&rootPath, "root", "", "Project root directory (defaults to the source file directory)")
@@ -29,8 +40,22 @@ func main() {
 	flag.BoolVar(&helpShort, "h", false, "Show help (short)")
 	flag.BoolVar(&runWasm, "run-wasm", false, "Execute a compiled WASM module via wasmtime")
 	flag.StringVar(&libPath, "libpath", "", "Path to Glyph standard library sources")
+	flag.BoolVar(&noTypecheck, "no-typecheck", false, "Skip static type checking before running")
+	flag.BoolVar(&useVM, "vm", false, "Run on the bytecode VM instead of the tree-walking interpreter")
+	flag.BoolVar(&jsonErrors, "json-errors", false, "Report runtime errors and their Glyph stack trace as JSON")
+	flag.Int64Var(&maxSteps, "max-steps", 0, "Stop the program after this many steps (0 for no limit)")
+	flag.IntVar(&maxDepth, "max-depth", 0, "Maximum call depth (0 for the default)")
+	flag.DurationVar(&timeout, "timeout", 0, "Stop the program after this long, such as 5s (0 for no limit)")
 	flag.Parse()
 
+	cfg := runConfig{
+		typecheck:  !noTypecheck,
+		vm:         useVM,
+		jsonErrors: jsonErrors,
+		limits:     interpreter.Options{MaxSteps: maxSteps, MaxDepth: maxDepth},
+		timeout:    timeout,
+	}
+
 	if helpFlag || helpShort {
 		printHelp()
 		return
@@ -54,7 +79,7 @@ func main() {
 			}
 			rootPath = cwd
 		}
-		runInline(inlineCode, rootPath, libPath)
+		runInline(inlineCode, rootPath, libPath, cfg)
 		return
 	}
 
@@ -77,23 +102,32 @@ func main() {
 		return
 	}
 
-	execute(absSource, absRoot, nil, resolvedLib)
+	execute(absSource, absRoot, nil, resolvedLib, cfg)
+}
+
+// runConfig holds the flags that decide how a program is run.
+type runConfig struct {
+	typecheck  bool
+	vm         bool
+	jsonErrors bool
+	limits     interpreter.Options
+	timeout    time.Duration
 }
 
-func runInline(code string, root string, libPath string) {
+func runInline(code string, root string, libPath string, cfg runConfig) {
 	absRoot, err := filepath.Abs(root)
 	if err != nil {
 		fail("resolve root path: %v", err)
 	}
-	program, err := parser.ParseProgramSource("<inline>", code)
+	program, err := parser.ParseProgramSourceRecovering("<inline>", code)
 	if err != nil {
-		fail("parse error: %v", err)
+		failParse(err)
 	}
 	resolvedLib := resolveLibPath(absRoot, libPath)
-	execute("", absRoot, program, resolvedLib)
+	execute("", absRoot, program, resolvedLib, cfg)
 }
 
-func execute(absSource string, absRoot string, override *ast.Program, libPath string) {
+func execute(absSource string, absRoot string, override *ast.Program, libPath string, cfg runConfig) {
 	var libs []string
 	if libPath != "" {
 		libs = append(libs, libPath)
@@ -101,8 +135,13 @@ func execute(absSource string, absRoot string, override *ast.Program, libPath st
 
 	index, err := project.BuildIndex(absRoot, libs...)
 	if err != nil {
+		var diags parser.Diagnostics
+		if errors.As(err, &diags) {
+			failParse(diags)
+		}
 		fail("failed to index project: %v", err)
 	}
+	index.Natives = interpreter.DefaultNatives()
 
 	var program *ast.Program
 	if override != nil {
@@ -113,9 +152,9 @@ func execute(absSource string, absRoot string, override *ast.Program, libPath st
 		}
 		program = index.Programs[absSource]
 		if program == nil {
-			program, err = parser.ParseProgramFile(absSource)
+			program, err = parser.ParseProgramFileRecovering(absSource)
 			if err != nil {
-				fail("parse error: %v", err)
+				failParse(err)
 			}
 		}
 	}
@@ -125,8 +164,22 @@ func execute(absSource string, absRoot string, override *ast.Program, libPath st
 		fail("symbol resolution error: %v", err)
 	}
 
-	if err := interpreter.Eval(program, symbols); err != nil {
-		fail("runtime error: %v", err)
+	if cfg.typecheck {
+		if err := typecheck.Check(program, symbols); err != nil {
+			failTypecheck(err)
+		}
+	}
+
+	opts := cfg.limits
+	if cfg.timeout > 0 {
+		opts.Deadline = time.Now().Add(cfg.timeout)
+	}
+	run := interpreter.Eval
+	if cfg.vm {
+		run = interpreter.EvalVM
+	}
+	if err := run(context.Background(), program, symbols, opts); err != nil {
+		failRuntime(err, cfg.jsonErrors)
 	}
 }
 
@@ -141,6 +194,12 @@ Options:
   -e <code>              Execute inline Glyph code snippet
   --run-wasm             Run a compiled WASM module via wasmtime
   --libpath <dir>        Path to Glyph standard library sources
+  --no-typecheck         Run without static type checking
+  --vm                   Run on the bytecode VM instead of the interpreter
+  --json-errors          Report runtime errors and stack traces as JSON
+  --max-steps <n>        Stop the program after n steps
+  --max-depth <n>        Limit the call depth (default 10000)
+  --timeout <duration>   Stop the program after a wall-clock time, e.g. 5s
   --help, -h             Show this help message`)
 }
 
@@ -168,6 +227,48 @@ func fail(format string, args ...interface{}) {
 	os.Exit(1)
 }
 
+// failParse prints every syntax diagnostic in compiler style and exits.
+func failParse(err error) {
+	var diags parser.Diagnostics
+	if !errors.As(err, &diags) {
+		fail("parse error: %v", err)
+	}
+	fmt.Fprintln(os.Stderr, diags.Format())
+	os.Exit(1)
+}
+
+// failRuntime prints a runtime error followed by its Glyph stack trace,
+// or the error as a JSON object when asJSON is set, and exits.
+func failRuntime(err error, asJSON bool) {
+	var rerr *interpreter.RuntimeError
+	if !errors.As(err, &rerr) {
+		fail("runtime error: %v", err)
+	}
+	if asJSON {
+		enc := json.NewEncoder(os.Stderr)
+		enc.SetEscapeHTML(false)
+		if jerr := enc.Encode(rerr); jerr != nil {
+			fail("runtime error: %v", err)
+		}
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "Error: runtime error: %v\n", rerr)
+	if trace := rerr.Trace(); trace != "" {
+		fmt.Fprintln(os.Stderr, trace)
+	}
+	os.Exit(1)
+}
+
+// failTypecheck prints every type error in compiler style and exits.
+func failTypecheck(err error) {
+	var errs typecheck.Errors
+	if !errors.As(err, &errs) {
+		fail("type check error: %v", err)
+	}
+	fmt.Fprintln(os.Stderr, errs.Format())
+	os.Exit(1)
+}
+
 func resolveLibPath(root string, override string) string {
 	if override != "" {
 		return absIfPossible(override)
@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"testing"
+
+	"glyph-cli/ast"
+)
+
+func TestCommentsAndDocComments(t *testing.T) {
+	source := `/* file header
+   spanning lines */
+package demo
+
+/// A registered user.
+/// Second line.
+record User {
+  /// Display name.
+  string name // trailing comment
+  int age /* inline */
+}
+
+/// Not attached: a blank line follows.
+
+fun int helper() {
+  1
+}
+
+/// Entry point.
+fun int main() {
+  // comment inside a body
+  val x = 1 /* after a statement */
+  /* between
+     statements */
+  x
+}
+`
+	program, err := ParseProgramSource("docs.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	rec := program.Records[0]
+	if rec.Doc != "A registered user.\nSecond line." {
+		t.Fatalf("unexpected record doc %q", rec.Doc)
+	}
+	if len(rec.Fields) != 2 || rec.Fields[0].Doc != "Display name." || rec.Fields[1].Doc != "" {
+		t.Fatalf("unexpected fields %+v", rec.Fields)
+	}
+	if got := source[rec.Fields[0].Pos.Offset:rec.Fields[0].Pos.EndOffset]; got != "string name" {
+		t.Fatalf("field span should exclude its doc comment, got %q", got)
+	}
+
+	docs := map[string]string{}
+	var main *ast.FunctionDecl
+	for _, fn := range program.Functions {
+		docs[fn.Name] = fn.Doc
+		if fn.Name == "main" {
+			main = fn
+		}
+	}
+	if docs["helper"] != "" {
+		t.Fatalf("doc separated by a blank line should not attach, got %q", docs["helper"])
+	}
+	if docs["main"] != "Entry point." {
+		t.Fatalf("unexpected main doc %q", docs["main"])
+	}
+	if len(main.Body.Statements) != 2 {
+		t.Fatalf("expected 2 statements in main, got %d", len(main.Body.Statements))
+	}
+}
@@ -0,0 +1,194 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Diagnostic describes a single syntax error found while parsing a Glyph
+// source file.
+type Diagnostic struct {
+	File     string
+	Line     int
+	Column   int
+	Offset   int
+	Message  string
+	Expected []string
+	// Snippet is the offending source line followed by a caret line that
+	// points at Column.
+	Snippet string
+}
+
+// Error returns the diagnostic as a single line.
+func (d *Diagnostic) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, d.Message)
+}
+
+// Format renders the diagnostic in a compiler-style, multi-line form.
+func (d *Diagnostic) Format() string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s:%d:%d: error: %s", d.File, d.Line, d.Column, d.Message)
+	if d.Snippet != "" {
+		buf.WriteString("\n")
+		buf.WriteString(d.Snippet)
+	}
+	return buf.String()
+}
+
+// Diagnostics is the error returned by the parse helpers when the source
+// contains syntax errors.
+type Diagnostics []*Diagnostic
+
+func (ds Diagnostics) Error() string {
+	lines := make([]string, len(ds))
+	for i, d := range ds {
+		lines[i] = d.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Format renders every diagnostic, separated by blank lines.
+func (ds Diagnostics) Format() string {
+	blocks := make([]string, len(ds))
+	for i, d := range ds {
+		blocks[i] = d.Format()
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// toDiagnostics converts the error list produced by the generated parser.
+func toDiagnostics(file string, source []byte, err error) Diagnostics {
+	var errs []error
+	if list, ok := err.(errList); ok {
+		errs = list
+	} else {
+		errs = []error{err}
+	}
+	out := make(Diagnostics, 0, len(errs))
+	for _, e := range errs {
+		pe, ok := e.(*parserError)
+		if !ok {
+			out = append(out, &Diagnostic{File: file, Line: 1, Column: 1, Message: e.Error()})
+			continue
+		}
+		out = append(out, newDiagnostic(file, source, pe.pos.offset, pe.Inner, pe.expected))
+	}
+	return out
+}
+
+func newDiagnostic(file string, source []byte, offset int, inner error, expected []string) *Diagnostic {
+	if offset > len(source) {
+		offset = len(source)
+	}
+	line, column, lineText := locate(source, offset)
+	d := &Diagnostic{
+		File:   file,
+		Line:   line,
+		Column: column,
+		Offset: offset,
+	}
+	for _, want := range expected {
+		d.Expected = appendUnique(d.Expected, describeExpected(want))
+	}
+	if len(d.Expected) > 0 {
+		d.Message = fmt.Sprintf("unexpected %s, expected %s", describeFound(source, offset), joinAlternatives(d.Expected))
+	} else {
+		d.Message = inner.Error()
+	}
+	d.Snippet = renderSnippet(line, column, lineText)
+	return d
+}
+
+// locate maps a byte offset to a 1-based line and rune column, and returns
+// the text of that line.
+func locate(source []byte, offset int) (int, int, string) {
+	line := 1 + bytes.Count(source[:offset], []byte("\n"))
+	start := bytes.LastIndexByte(source[:offset], '\n') + 1
+	end := bytes.IndexByte(source[offset:], '\n')
+	if end < 0 {
+		end = len(source)
+	} else {
+		end += offset
+	}
+	column := utf8.RuneCount(source[start:offset]) + 1
+	return line, column, strings.TrimRight(string(source[start:end]), "\r")
+}
+
+func renderSnippet(line, column int, text string) string {
+	gutter := fmt.Sprintf("%d", line)
+	var caret strings.Builder
+	for i, r := range []rune(text) {
+		if i >= column-1 {
+			break
+		}
+		if r == '\t' {
+			caret.WriteRune('\t')
+		} else {
+			caret.WriteRune(' ')
+		}
+	}
+	for caret.Len() < column-1 {
+		caret.WriteRune(' ')
+	}
+	caret.WriteRune('^')
+	pad := strings.Repeat(" ", len(gutter))
+	return fmt.Sprintf(" %s | %s\n %s | %s", gutter, text, pad, caret.String())
+}
+
+func describeFound(source []byte, offset int) string {
+	if offset >= len(source) {
+		return "end of file"
+	}
+	r, _ := utf8.DecodeRune(source[offset:])
+	switch r {
+	case '\n':
+		return "end of line"
+	case ' ', '\t', '\r':
+		return "whitespace"
+	}
+	return fmt.Sprintf("%q", string(r))
+}
+
+// expectedNames gives readable names to the character classes used by the
+// grammar; literals are already readable as quoted strings.
+var expectedNames = map[string]string{
+	"[ \\t\\r]":    "whitespace",
+	"\"\\n\"":      "newline",
+	"[0-9]":        "digit",
+	"[0-9a-fA-F]":  "hex digit",
+	"[01]":         "binary digit",
+	"[A-Za-z_]":    "identifier",
+	"[A-Za-z0-9_]": "identifier",
+	"[A-Z]":        "type name",
+	"[^\"]":        "string character",
+	"EOF":          "end of file",
+}
+
+func describeExpected(want string) string {
+	if name, ok := expectedNames[want]; ok {
+		return name
+	}
+	return want
+}
+
+func joinAlternatives(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	default:
+		return strings.Join(items[:len(items)-1], ", ") + " or " + items[len(items)-1]
+	}
+}
+
+func appendUnique(list []string, item string) []string {
+	for _, existing := range list {
+		if existing == item {
+			return list
+		}
+	}
+	return append(list, item)
+}
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSyntaxErrorsBecomeDiagnostics(t *testing.T) {
+	_, err := ParseProgramSource("bad.gly", "fun int main() {\n  val x = (1 + 2\n}\n")
+	if err == nil {
+		t.Fatalf("expected parse error")
+	}
+	var diags Diagnostics
+	if !errors.As(err, &diags) {
+		t.Fatalf("expected Diagnostics, got %T", err)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+	}
+	d := diags[0]
+	if d.File != "bad.gly" || d.Line != 2 || d.Column != 17 {
+		t.Fatalf("unexpected location %s:%d:%d", d.File, d.Line, d.Column)
+	}
+	found := false
+	for _, want := range d.Expected {
+		if want == `")"` {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected tokens %v should include \")\"", d.Expected)
+	}
+	if !strings.HasPrefix(d.Message, "unexpected end of line, expected ") {
+		t.Fatalf("unexpected message %q", d.Message)
+	}
+
+	lines := strings.Split(d.Snippet, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("snippet should have 2 lines, got %q", d.Snippet)
+	}
+	if lines[0] != " 2 |   val x = (1 + 2" {
+		t.Fatalf("unexpected source line %q", lines[0])
+	}
+	if caret := strings.Index(lines[1], "^"); caret != len(lines[0]) {
+		t.Fatalf("caret misplaced:\n%s", d.Snippet)
+	}
+	if !strings.HasPrefix(d.Format(), "bad.gly:2:17: error: unexpected end of line") {
+		t.Fatalf("unexpected formatted output:\n%s", d.Format())
+	}
+}
@@ -18,77 +18,195 @@ import (
 	"unicode/utf8"
 )
 
+// span returns the source position covered by the current match.
+func (c *current) span() ast.SourcePos {
+	file, _ := c.globalStore["file"].(string)
+	return ast.SourcePos{
+		File:      file,
+		Line:      c.pos.line,
+		Column:    c.pos.col,
+		Offset:    c.pos.offset,
+		EndOffset: c.pos.offset + len(c.text),
+	}
+}
+
 func applySuffix(base ast.Expr, suffixes []interface{}) ast.Expr {
 	expr := base
 	for _, item := range suffixes {
 		parts := item.([]interface{})
 		kind := parts[0].(string)
+		pos := expr.Position().Through(parts[2].(ast.SourcePos))
 		switch kind {
 		case "field":
-			expr = &ast.FieldAccess{Target: expr, Field: parts[1].(string)}
+			expr = &ast.FieldAccess{Target: expr, Field: parts[1].(string), Pos: pos}
 		case "safe-field":
-			expr = &ast.SafeFieldAccess{Target: expr, Field: parts[1].(string)}
+			expr = &ast.SafeFieldAccess{Target: expr, Field: parts[1].(string), Pos: pos}
 		case "index":
-			expr = &ast.IndexAccess{Target: expr, Index: parts[1].(ast.Expr)}
+			expr = &ast.IndexAccess{Target: expr, Index: parts[1].(ast.Expr), Pos: pos}
+		case "call", "safe-call":
+			call := parts[1].([]interface{})
+			var args []ast.Expr
+			if call[1] != nil {
+				for _, arg := range call[1].([]interface{}) {
+					args = append(args, arg.(ast.Expr))
+				}
+			}
+			expr = &ast.MethodCall{Receiver: expr, Name: call[0].(string), Arguments: args, Safe: kind == "safe-call", Pos: pos}
+		case "iterate":
+			call := parts[1].([]interface{})
+			expr = &ast.IterateExpr{Target: expr, Method: call[0].(string), Body: call[1].(*ast.Block), Pos: pos}
 		}
 	}
 	return expr
 }
 
+// attachDoc records a /// documentation comment on the declaration it
+// precedes.
+func attachDoc(decl interface{}, doc string) {
+	switch d := decl.(type) {
+	case *ast.FunctionDecl:
+		d.Doc = doc
+	case *ast.RecordDecl:
+		d.Doc = doc
+	case *ast.SumTypeDecl:
+		d.Doc = doc
+	case *ast.TypeAliasDecl:
+		d.Doc = doc
+	}
+}
+
+// parseIntLiteral parses the digits of an integer literal. Decimal literals
+// must fit the signed range of the given bit size; hex and binary literals
+// may use the full unsigned range and wrap, so 0xFFFFFFFF is the int -1.
+func parseIntLiteral(digits string, base, bits int) (int64, error) {
+	if base == 10 {
+		return strconv.ParseInt(digits, 10, bits)
+	}
+	n, err := strconv.ParseUint(digits, base, bits)
+	if err != nil {
+		return 0, err
+	}
+	if bits == 32 {
+		return int64(int32(uint32(n))), nil
+	}
+	return int64(n), nil
+}
+
+// intLiteral builds an int or long (l suffix) literal from the matched
+// text, which may start with a minus sign.
+func intLiteral(c *current) (interface{}, error) {
+	text := strings.ReplaceAll(string(c.text), "_", "")
+	long := strings.HasSuffix(text, "L") || strings.HasSuffix(text, "l")
+	text = strings.TrimRight(text, "Ll")
+	base, digits := 10, text
+	if len(text) > 2 && text[0] == '0' {
+		switch text[1] {
+		case 'x', 'X':
+			base, digits = 16, text[2:]
+		case 'b', 'B':
+			base, digits = 2, text[2:]
+		}
+	}
+	if long {
+		n, err := parseIntLiteral(digits, base, 64)
+		if err != nil {
+			return &ast.LongLiteral{Pos: c.span()}, fmt.Errorf("long literal %s is out of range", c.text)
+		}
+		return &ast.LongLiteral{Value: n, Pos: c.span()}, nil
+	}
+	n, err := parseIntLiteral(digits, base, 32)
+	if err != nil {
+		return &ast.IntLiteral{Pos: c.span()}, fmt.Errorf("int literal %s is out of range; use an L suffix for long", c.text)
+	}
+	return &ast.IntLiteral{Value: n, Pos: c.span()}, nil
+}
+
+// floatLiteral builds a float (f suffix) or double literal from the
+// matched text.
+func floatLiteral(c *current) (interface{}, error) {
+	text := strings.ReplaceAll(string(c.text), "_", "")
+	switch text[len(text)-1] {
+	case 'f', 'F':
+		v, err := strconv.ParseFloat(text[:len(text)-1], 32)
+		if err != nil {
+			return &ast.FloatLiteral{Pos: c.span()}, fmt.Errorf("float literal %s is out of range", c.text)
+		}
+		return &ast.FloatLiteral{Value: float32(v), Pos: c.span()}, nil
+	case 'd', 'D':
+		text = text[:len(text)-1]
+	}
+	v, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return &ast.DoubleLiteral{Pos: c.span()}, fmt.Errorf("double literal %s is out of range", c.text)
+	}
+	return &ast.DoubleLiteral{Value: v, Pos: c.span()}, nil
+}
+
+func binaryChain(l, t interface{}) ast.Expr {
+	left := l.(ast.Expr)
+	for _, item := range t.([]interface{}) {
+		parts := item.([]interface{})
+		op := parts[0].(string)
+		right := parts[1].(ast.Expr)
+		left = &ast.BinaryOp{Op: op, Left: left, Right: right, Pos: left.Position().Through(right.Position())}
+	}
+	return left
+}
+
 var g = &grammar{
 	rules: []*rule{
 		{
 			name: "Program",
-			pos:  position{line: 25, col: 1, offset: 680},
+			pos:  position{line: 147, col: 1, offset: 5450},
 			expr: &actionExpr{
-				pos: position{line: 25, col: 20, offset: 699},
+				pos: position{line: 147, col: 20, offset: 5469},
 				run: (*parser).callonProgram1,
 				expr: &seqExpr{
-					pos: position{line: 25, col: 20, offset: 699},
+					pos: position{line: 147, col: 20, offset: 5469},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 25, col: 20, offset: 699},
+							pos:  position{line: 147, col: 20, offset: 5469},
 							name: "Skip",
 						},
 						&labeledExpr{
-							pos:   position{line: 25, col: 25, offset: 704},
+							pos:   position{line: 147, col: 25, offset: 5474},
 							label: "pkg",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 25, col: 29, offset: 708},
+								pos: position{line: 147, col: 29, offset: 5478},
 								expr: &ruleRefExpr{
-									pos:  position{line: 25, col: 29, offset: 708},
+									pos:  position{line: 147, col: 29, offset: 5478},
 									name: "PackageDecl",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 25, col: 42, offset: 721},
+							pos:   position{line: 147, col: 42, offset: 5491},
 							label: "imps",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 25, col: 47, offset: 726},
+								pos: position{line: 147, col: 47, offset: 5496},
 								expr: &ruleRefExpr{
-									pos:  position{line: 25, col: 47, offset: 726},
+									pos:  position{line: 147, col: 47, offset: 5496},
 									name: "ImportDecl",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 25, col: 59, offset: 738},
+							pos:   position{line: 147, col: 59, offset: 5508},
 							label: "d",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 25, col: 61, offset: 740},
+								pos: position{line: 147, col: 61, offset: 5510},
 								expr: &ruleRefExpr{
-									pos:  position{line: 25, col: 61, offset: 740},
+									pos:  position{line: 147, col: 61, offset: 5510},
 									name: "Decl",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 25, col: 67, offset: 746},
+							pos:  position{line: 147, col: 67, offset: 5516},
 							name: "Skip",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 25, col: 72, offset: 751},
+							pos:  position{line: 147, col: 72, offset: 5521},
 							name: "EOF",
 						},
 					},
@@ -97,34 +215,34 @@ var g = &grammar{
 		},
 		{
 			name: "PackageDecl",
-			pos:  position{line: 54, col: 1, offset: 1626},
+			pos:  position{line: 179, col: 1, offset: 6526},
 			expr: &actionExpr{
-				pos: position{line: 54, col: 20, offset: 1645},
+				pos: position{line: 179, col: 20, offset: 6545},
 				run: (*parser).callonPackageDecl1,
 				expr: &seqExpr{
-					pos: position{line: 54, col: 20, offset: 1645},
+					pos: position{line: 179, col: 20, offset: 6545},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 54, col: 20, offset: 1645},
+							pos:  position{line: 179, col: 20, offset: 6545},
 							name: "PACKAGE",
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 54, col: 28, offset: 1653},
+							pos: position{line: 179, col: 28, offset: 6553},
 							expr: &ruleRefExpr{
-								pos:  position{line: 54, col: 28, offset: 1653},
+								pos:  position{line: 179, col: 28, offset: 6553},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 54, col: 32, offset: 1657},
+							pos:   position{line: 179, col: 32, offset: 6557},
 							label: "q",
 							expr: &ruleRefExpr{
-								pos:  position{line: 54, col: 34, offset: 1659},
+								pos:  position{line: 179, col: 34, offset: 6559},
 								name: "QualifiedName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 54, col: 48, offset: 1673},
+							pos:  position{line: 179, col: 48, offset: 6573},
 							name: "Terminator",
 						},
 					},
@@ -133,34 +251,34 @@ var g = &grammar{
 		},
 		{
 			name: "ImportDecl",
-			pos:  position{line: 58, col: 1, offset: 1740},
+			pos:  position{line: 183, col: 1, offset: 6655},
 			expr: &actionExpr{
-				pos: position{line: 58, col: 20, offset: 1759},
+				pos: position{line: 183, col: 20, offset: 6674},
 				run: (*parser).callonImportDecl1,
 				expr: &seqExpr{
-					pos: position{line: 58, col: 20, offset: 1759},
+					pos: position{line: 183, col: 20, offset: 6674},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 58, col: 20, offset: 1759},
+							pos:  position{line: 183, col: 20, offset: 6674},
 							name: "IMPORT",
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 58, col: 27, offset: 1766},
+							pos: position{line: 183, col: 27, offset: 6681},
 							expr: &ruleRefExpr{
-								pos:  position{line: 58, col: 27, offset: 1766},
+								pos:  position{line: 183, col: 27, offset: 6681},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 58, col: 31, offset: 1770},
+							pos:   position{line: 183, col: 31, offset: 6685},
 							label: "q",
 							expr: &ruleRefExpr{
-								pos:  position{line: 58, col: 33, offset: 1772},
+								pos:  position{line: 183, col: 33, offset: 6687},
 								name: "QualifiedName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 58, col: 47, offset: 1786},
+							pos:  position{line: 183, col: 47, offset: 6701},
 							name: "Terminator",
 						},
 					},
@@ -169,80 +287,116 @@ var g = &grammar{
 		},
 		{
 			name: "Decl",
-			pos:  position{line: 62, col: 1, offset: 1852},
-			expr: &choiceExpr{
-				pos: position{line: 62, col: 20, offset: 1871},
-				alternatives: []any{
-					&ruleRefExpr{
-						pos:  position{line: 62, col: 20, offset: 1871},
-						name: "TypeAliasDecl",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 62, col: 36, offset: 1887},
-						name: "RecordDecl",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 62, col: 49, offset: 1900},
-						name: "FuncDecl",
+			pos:  position{line: 187, col: 1, offset: 6782},
+			expr: &actionExpr{
+				pos: position{line: 187, col: 20, offset: 6801},
+				run: (*parser).callonDecl1,
+				expr: &seqExpr{
+					pos: position{line: 187, col: 20, offset: 6801},
+					exprs: []any{
+						&ruleRefExpr{
+							pos:  position{line: 187, col: 20, offset: 6801},
+							name: "Skip",
+						},
+						&labeledExpr{
+							pos:   position{line: 187, col: 25, offset: 6806},
+							label: "doc",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 187, col: 29, offset: 6810},
+								expr: &ruleRefExpr{
+									pos:  position{line: 187, col: 29, offset: 6810},
+									name: "DocComment",
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 187, col: 41, offset: 6822},
+							label: "d",
+							expr: &choiceExpr{
+								pos: position{line: 187, col: 44, offset: 6825},
+								alternatives: []any{
+									&ruleRefExpr{
+										pos:  position{line: 187, col: 44, offset: 6825},
+										name: "SumTypeDecl",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 187, col: 58, offset: 6839},
+										name: "TypeAliasDecl",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 187, col: 74, offset: 6855},
+										name: "RecordDecl",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 187, col: 87, offset: 6868},
+										name: "ExternFuncDecl",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 187, col: 104, offset: 6885},
+										name: "FuncDecl",
+									},
+								},
+							},
+						},
 					},
 				},
 			},
 		},
 		{
 			name: "TypeAliasDecl",
-			pos:  position{line: 64, col: 1, offset: 1910},
+			pos:  position{line: 194, col: 1, offset: 6979},
 			expr: &actionExpr{
-				pos: position{line: 64, col: 20, offset: 1929},
+				pos: position{line: 194, col: 20, offset: 6998},
 				run: (*parser).callonTypeAliasDecl1,
 				expr: &seqExpr{
-					pos: position{line: 64, col: 20, offset: 1929},
+					pos: position{line: 194, col: 20, offset: 6998},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 64, col: 20, offset: 1929},
+							pos:  position{line: 194, col: 20, offset: 6998},
 							name: "TYPE",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 64, col: 25, offset: 1934},
+							pos:  position{line: 194, col: 25, offset: 7003},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 64, col: 28, offset: 1937},
+							pos:   position{line: 194, col: 28, offset: 7006},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 64, col: 33, offset: 1942},
+								pos:  position{line: 194, col: 33, offset: 7011},
 								name: "Ident",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 64, col: 39, offset: 1948},
+							pos: position{line: 194, col: 39, offset: 7017},
 							expr: &ruleRefExpr{
-								pos:  position{line: 64, col: 39, offset: 1948},
+								pos:  position{line: 194, col: 39, offset: 7017},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 64, col: 43, offset: 1952},
+							pos:        position{line: 194, col: 43, offset: 7021},
 							val:        "=",
 							ignoreCase: false,
 							want:       "\"=\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 64, col: 47, offset: 1956},
+							pos: position{line: 194, col: 47, offset: 7025},
 							expr: &ruleRefExpr{
-								pos:  position{line: 64, col: 47, offset: 1956},
+								pos:  position{line: 194, col: 47, offset: 7025},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 64, col: 51, offset: 1960},
+							pos:   position{line: 194, col: 51, offset: 7029},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 64, col: 53, offset: 1962},
+								pos:  position{line: 194, col: 53, offset: 7031},
 								name: "Type",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 64, col: 58, offset: 1967},
+							pos:  position{line: 194, col: 58, offset: 7036},
 							name: "Terminator",
 						},
 					},
@@ -251,59 +405,59 @@ var g = &grammar{
 		},
 		{
 			name: "RecordDecl",
-			pos:  position{line: 68, col: 1, offset: 2063},
+			pos:  position{line: 198, col: 1, offset: 7147},
 			expr: &actionExpr{
-				pos: position{line: 68, col: 20, offset: 2082},
+				pos: position{line: 198, col: 20, offset: 7166},
 				run: (*parser).callonRecordDecl1,
 				expr: &seqExpr{
-					pos: position{line: 68, col: 20, offset: 2082},
+					pos: position{line: 198, col: 20, offset: 7166},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 68, col: 20, offset: 2082},
+							pos:  position{line: 198, col: 20, offset: 7166},
 							name: "RECORD",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 68, col: 27, offset: 2089},
+							pos:  position{line: 198, col: 27, offset: 7173},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 68, col: 30, offset: 2092},
+							pos:   position{line: 198, col: 30, offset: 7176},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 68, col: 35, offset: 2097},
+								pos:  position{line: 198, col: 35, offset: 7181},
 								name: "Ident",
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 68, col: 41, offset: 2103},
+							pos: position{line: 198, col: 41, offset: 7187},
 							expr: &ruleRefExpr{
-								pos:  position{line: 68, col: 41, offset: 2103},
+								pos:  position{line: 198, col: 41, offset: 7187},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 68, col: 45, offset: 2107},
+							pos:        position{line: 198, col: 45, offset: 7191},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 68, col: 49, offset: 2111},
+							pos:  position{line: 198, col: 49, offset: 7195},
 							name: "Skip",
 						},
 						&labeledExpr{
-							pos:   position{line: 68, col: 54, offset: 2116},
+							pos:   position{line: 198, col: 54, offset: 7200},
 							label: "f",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 68, col: 56, offset: 2118},
+								pos: position{line: 198, col: 56, offset: 7202},
 								expr: &ruleRefExpr{
-									pos:  position{line: 68, col: 56, offset: 2118},
+									pos:  position{line: 198, col: 56, offset: 7202},
 									name: "RecordField",
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 68, col: 69, offset: 2131},
+							pos:        position{line: 198, col: 69, offset: 7215},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -314,168 +468,202 @@ var g = &grammar{
 		},
 		{
 			name: "RecordField",
-			pos:  position{line: 77, col: 1, offset: 2370},
+			pos:  position{line: 207, col: 1, offset: 7469},
 			expr: &actionExpr{
-				pos: position{line: 77, col: 20, offset: 2389},
+				pos: position{line: 207, col: 20, offset: 7488},
 				run: (*parser).callonRecordField1,
 				expr: &seqExpr{
-					pos: position{line: 77, col: 20, offset: 2389},
+					pos: position{line: 207, col: 20, offset: 7488},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 207, col: 20, offset: 7488},
+							label: "doc",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 207, col: 24, offset: 7492},
+								expr: &ruleRefExpr{
+									pos:  position{line: 207, col: 24, offset: 7492},
+									name: "DocComment",
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 207, col: 36, offset: 7504},
+							label: "f",
+							expr: &ruleRefExpr{
+								pos:  position{line: 207, col: 38, offset: 7506},
+								name: "FieldDecl",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 207, col: 48, offset: 7516},
+							name: "Terminator",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "FieldDecl",
+			pos:  position{line: 215, col: 1, offset: 7647},
+			expr: &actionExpr{
+				pos: position{line: 215, col: 20, offset: 7666},
+				run: (*parser).callonFieldDecl1,
+				expr: &seqExpr{
+					pos: position{line: 215, col: 20, offset: 7666},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 77, col: 20, offset: 2389},
+							pos:   position{line: 215, col: 20, offset: 7666},
 							label: "m",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 77, col: 22, offset: 2391},
+								pos: position{line: 215, col: 22, offset: 7668},
 								expr: &ruleRefExpr{
-									pos:  position{line: 77, col: 22, offset: 2391},
+									pos:  position{line: 215, col: 22, offset: 7668},
 									name: "FieldMutability",
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 77, col: 39, offset: 2408},
+							pos: position{line: 215, col: 39, offset: 7685},
 							expr: &ruleRefExpr{
-								pos:  position{line: 77, col: 39, offset: 2408},
+								pos:  position{line: 215, col: 39, offset: 7685},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 77, col: 43, offset: 2412},
+							pos:   position{line: 215, col: 43, offset: 7689},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 77, col: 45, offset: 2414},
+								pos:  position{line: 215, col: 45, offset: 7691},
 								name: "Type",
 							},
 						},
-						&ruleRefExpr{
-							pos:  position{line: 77, col: 50, offset: 2419},
-							name: "WS",
+						&zeroOrOneExpr{
+							pos: position{line: 215, col: 50, offset: 7696},
+							expr: &ruleRefExpr{
+								pos:  position{line: 215, col: 50, offset: 7696},
+								name: "WS",
+							},
 						},
 						&labeledExpr{
-							pos:   position{line: 77, col: 53, offset: 2422},
+							pos:   position{line: 215, col: 54, offset: 7700},
 							label: "n",
 							expr: &ruleRefExpr{
-								pos:  position{line: 77, col: 55, offset: 2424},
+								pos:  position{line: 215, col: 56, offset: 7702},
 								name: "Ident",
 							},
 						},
-						&ruleRefExpr{
-							pos:  position{line: 77, col: 61, offset: 2430},
-							name: "Terminator",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "FieldMutability",
-			pos:  position{line: 85, col: 1, offset: 2598},
+			pos:  position{line: 223, col: 1, offset: 7880},
 			expr: &actionExpr{
-				pos: position{line: 85, col: 20, offset: 2617},
+				pos: position{line: 223, col: 20, offset: 7899},
 				run: (*parser).callonFieldMutability1,
 				expr: &ruleRefExpr{
-					pos:  position{line: 85, col: 20, offset: 2617},
+					pos:  position{line: 223, col: 20, offset: 7899},
 					name: "VAL",
 				},
 			},
 		},
 		{
 			name: "FuncDecl",
-			pos:  position{line: 87, col: 1, offset: 2644},
+			pos:  position{line: 225, col: 1, offset: 7926},
 			expr: &actionExpr{
-				pos: position{line: 87, col: 20, offset: 2663},
+				pos: position{line: 225, col: 20, offset: 7945},
 				run: (*parser).callonFuncDecl1,
 				expr: &seqExpr{
-					pos: position{line: 87, col: 20, offset: 2663},
+					pos: position{line: 225, col: 20, offset: 7945},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 87, col: 20, offset: 2663},
-							name: "Skip",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 87, col: 25, offset: 2668},
+							pos:  position{line: 225, col: 20, offset: 7945},
 							name: "FUN",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 87, col: 29, offset: 2672},
+							pos:  position{line: 225, col: 24, offset: 7949},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 87, col: 32, offset: 2675},
+							pos:   position{line: 225, col: 27, offset: 7952},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 87, col: 34, offset: 2677},
+								pos:  position{line: 225, col: 29, offset: 7954},
 								name: "Type",
 							},
 						},
-						&ruleRefExpr{
-							pos:  position{line: 87, col: 39, offset: 2682},
-							name: "WS",
+						&zeroOrOneExpr{
+							pos: position{line: 225, col: 34, offset: 7959},
+							expr: &ruleRefExpr{
+								pos:  position{line: 225, col: 34, offset: 7959},
+								name: "WS",
+							},
 						},
 						&labeledExpr{
-							pos:   position{line: 87, col: 42, offset: 2685},
+							pos:   position{line: 225, col: 38, offset: 7963},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 87, col: 47, offset: 2690},
+								pos:  position{line: 225, col: 43, offset: 7968},
 								name: "Ident",
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 87, col: 53, offset: 2696},
+							pos: position{line: 225, col: 49, offset: 7974},
 							expr: &ruleRefExpr{
-								pos:  position{line: 87, col: 53, offset: 2696},
+								pos:  position{line: 225, col: 49, offset: 7974},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 87, col: 57, offset: 2700},
+							pos:        position{line: 225, col: 53, offset: 7978},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 87, col: 61, offset: 2704},
+							pos: position{line: 225, col: 57, offset: 7982},
 							expr: &ruleRefExpr{
-								pos:  position{line: 87, col: 61, offset: 2704},
+								pos:  position{line: 225, col: 57, offset: 7982},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 87, col: 65, offset: 2708},
+							pos:   position{line: 225, col: 61, offset: 7986},
 							label: "p",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 87, col: 67, offset: 2710},
+								pos: position{line: 225, col: 63, offset: 7988},
 								expr: &ruleRefExpr{
-									pos:  position{line: 87, col: 67, offset: 2710},
+									pos:  position{line: 225, col: 63, offset: 7988},
 									name: "ParamList",
 								},
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 87, col: 78, offset: 2721},
+							pos: position{line: 225, col: 74, offset: 7999},
 							expr: &ruleRefExpr{
-								pos:  position{line: 87, col: 78, offset: 2721},
+								pos:  position{line: 225, col: 74, offset: 7999},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 87, col: 82, offset: 2725},
+							pos:        position{line: 225, col: 78, offset: 8003},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 87, col: 86, offset: 2729},
+							pos: position{line: 225, col: 82, offset: 8007},
 							expr: &ruleRefExpr{
-								pos:  position{line: 87, col: 86, offset: 2729},
+								pos:  position{line: 225, col: 82, offset: 8007},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 87, col: 90, offset: 2733},
+							pos:   position{line: 225, col: 86, offset: 8011},
 							label: "b",
 							expr: &ruleRefExpr{
-								pos:  position{line: 87, col: 92, offset: 2735},
+								pos:  position{line: 225, col: 88, offset: 8013},
 								name: "Block",
 							},
 						},
@@ -483,53 +671,153 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "ExternFuncDecl",
+			pos:  position{line: 239, col: 1, offset: 8494},
+			expr: &actionExpr{
+				pos: position{line: 239, col: 20, offset: 8513},
+				run: (*parser).callonExternFuncDecl1,
+				expr: &seqExpr{
+					pos: position{line: 239, col: 20, offset: 8513},
+					exprs: []any{
+						&ruleRefExpr{
+							pos:  position{line: 239, col: 20, offset: 8513},
+							name: "EXTERN",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 239, col: 27, offset: 8520},
+							name: "WS",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 239, col: 30, offset: 8523},
+							name: "FUN",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 239, col: 34, offset: 8527},
+							name: "WS",
+						},
+						&labeledExpr{
+							pos:   position{line: 239, col: 37, offset: 8530},
+							label: "t",
+							expr: &ruleRefExpr{
+								pos:  position{line: 239, col: 39, offset: 8532},
+								name: "Type",
+							},
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 239, col: 44, offset: 8537},
+							expr: &ruleRefExpr{
+								pos:  position{line: 239, col: 44, offset: 8537},
+								name: "WS",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 239, col: 48, offset: 8541},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 239, col: 53, offset: 8546},
+								name: "Ident",
+							},
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 239, col: 59, offset: 8552},
+							expr: &ruleRefExpr{
+								pos:  position{line: 239, col: 59, offset: 8552},
+								name: "WS",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 239, col: 63, offset: 8556},
+							val:        "(",
+							ignoreCase: false,
+							want:       "\"(\"",
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 239, col: 67, offset: 8560},
+							expr: &ruleRefExpr{
+								pos:  position{line: 239, col: 67, offset: 8560},
+								name: "WS",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 239, col: 71, offset: 8564},
+							label: "p",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 239, col: 73, offset: 8566},
+								expr: &ruleRefExpr{
+									pos:  position{line: 239, col: 73, offset: 8566},
+									name: "ParamList",
+								},
+							},
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 239, col: 84, offset: 8577},
+							expr: &ruleRefExpr{
+								pos:  position{line: 239, col: 84, offset: 8577},
+								name: "WS",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 239, col: 88, offset: 8581},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 239, col: 92, offset: 8585},
+							name: "Terminator",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "ParamList",
-			pos:  position{line: 99, col: 1, offset: 3085},
+			pos:  position{line: 251, col: 1, offset: 8947},
 			expr: &actionExpr{
-				pos: position{line: 99, col: 20, offset: 3104},
+				pos: position{line: 251, col: 20, offset: 8966},
 				run: (*parser).callonParamList1,
 				expr: &seqExpr{
-					pos: position{line: 99, col: 20, offset: 3104},
+					pos: position{line: 251, col: 20, offset: 8966},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 99, col: 20, offset: 3104},
+							pos:   position{line: 251, col: 20, offset: 8966},
 							label: "p",
 							expr: &ruleRefExpr{
-								pos:  position{line: 99, col: 22, offset: 3106},
+								pos:  position{line: 251, col: 22, offset: 8968},
 								name: "Param",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 99, col: 28, offset: 3112},
+							pos:   position{line: 251, col: 28, offset: 8974},
 							label: "r",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 99, col: 30, offset: 3114},
+								pos: position{line: 251, col: 30, offset: 8976},
 								expr: &seqExpr{
-									pos: position{line: 99, col: 31, offset: 3115},
+									pos: position{line: 251, col: 31, offset: 8977},
 									exprs: []any{
 										&zeroOrMoreExpr{
-											pos: position{line: 99, col: 31, offset: 3115},
+											pos: position{line: 251, col: 31, offset: 8977},
 											expr: &ruleRefExpr{
-												pos:  position{line: 99, col: 31, offset: 3115},
+												pos:  position{line: 251, col: 31, offset: 8977},
 												name: "WS",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 99, col: 35, offset: 3119},
+											pos:        position{line: 251, col: 35, offset: 8981},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 99, col: 39, offset: 3123},
+											pos: position{line: 251, col: 39, offset: 8985},
 											expr: &ruleRefExpr{
-												pos:  position{line: 99, col: 39, offset: 3123},
+												pos:  position{line: 251, col: 39, offset: 8985},
 												name: "WS",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 99, col: 43, offset: 3127},
+											pos:  position{line: 251, col: 43, offset: 8989},
 											name: "Param",
 										},
 									},
@@ -542,30 +830,33 @@ var g = &grammar{
 		},
 		{
 			name: "Param",
-			pos:  position{line: 108, col: 1, offset: 3313},
+			pos:  position{line: 260, col: 1, offset: 9175},
 			expr: &actionExpr{
-				pos: position{line: 108, col: 20, offset: 3332},
+				pos: position{line: 260, col: 20, offset: 9194},
 				run: (*parser).callonParam1,
 				expr: &seqExpr{
-					pos: position{line: 108, col: 20, offset: 3332},
+					pos: position{line: 260, col: 20, offset: 9194},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 108, col: 20, offset: 3332},
+							pos:   position{line: 260, col: 20, offset: 9194},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 108, col: 22, offset: 3334},
+								pos:  position{line: 260, col: 22, offset: 9196},
 								name: "Type",
 							},
 						},
-						&ruleRefExpr{
-							pos:  position{line: 108, col: 27, offset: 3339},
-							name: "WS",
+						&zeroOrOneExpr{
+							pos: position{line: 260, col: 27, offset: 9201},
+							expr: &ruleRefExpr{
+								pos:  position{line: 260, col: 27, offset: 9201},
+								name: "WS",
+							},
 						},
 						&labeledExpr{
-							pos:   position{line: 108, col: 30, offset: 3342},
+							pos:   position{line: 260, col: 31, offset: 9205},
 							label: "n",
 							expr: &ruleRefExpr{
-								pos:  position{line: 108, col: 32, offset: 3344},
+								pos:  position{line: 260, col: 33, offset: 9207},
 								name: "Ident",
 							},
 						},
@@ -575,36 +866,36 @@ var g = &grammar{
 		},
 		{
 			name: "Block",
-			pos:  position{line: 112, col: 1, offset: 3418},
+			pos:  position{line: 264, col: 1, offset: 9296},
 			expr: &actionExpr{
-				pos: position{line: 112, col: 20, offset: 3437},
+				pos: position{line: 264, col: 20, offset: 9315},
 				run: (*parser).callonBlock1,
 				expr: &seqExpr{
-					pos: position{line: 112, col: 20, offset: 3437},
+					pos: position{line: 264, col: 20, offset: 9315},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 112, col: 20, offset: 3437},
+							pos:        position{line: 264, col: 20, offset: 9315},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 112, col: 24, offset: 3441},
+							pos:  position{line: 264, col: 24, offset: 9319},
 							name: "Skip",
 						},
 						&labeledExpr{
-							pos:   position{line: 112, col: 29, offset: 3446},
+							pos:   position{line: 264, col: 29, offset: 9324},
 							label: "s",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 112, col: 31, offset: 3448},
+								pos: position{line: 264, col: 31, offset: 9326},
 								expr: &ruleRefExpr{
-									pos:  position{line: 112, col: 31, offset: 3448},
+									pos:  position{line: 264, col: 31, offset: 9326},
 									name: "Statement",
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 112, col: 42, offset: 3459},
+							pos:        position{line: 264, col: 42, offset: 9337},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -615,48 +906,64 @@ var g = &grammar{
 		},
 		{
 			name: "Statement",
-			pos:  position{line: 121, col: 1, offset: 3661},
+			pos:  position{line: 273, col: 1, offset: 9554},
 			expr: &actionExpr{
-				pos: position{line: 121, col: 20, offset: 3680},
+				pos: position{line: 273, col: 20, offset: 9573},
 				run: (*parser).callonStatement1,
 				expr: &seqExpr{
-					pos: position{line: 121, col: 20, offset: 3680},
+					pos: position{line: 273, col: 20, offset: 9573},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 121, col: 20, offset: 3680},
+							pos:   position{line: 273, col: 20, offset: 9573},
 							label: "s",
 							expr: &choiceExpr{
-								pos: position{line: 121, col: 23, offset: 3683},
+								pos: position{line: 273, col: 23, offset: 9576},
 								alternatives: []any{
 									&ruleRefExpr{
-										pos:  position{line: 121, col: 23, offset: 3683},
+										pos:  position{line: 273, col: 23, offset: 9576},
+										name: "WhileStmt",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 273, col: 35, offset: 9588},
+										name: "BreakStmt",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 273, col: 47, offset: 9600},
+										name: "ContinueStmt",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 273, col: 62, offset: 9615},
 										name: "VarDecl",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 121, col: 33, offset: 3693},
+										pos:  position{line: 273, col: 72, offset: 9625},
 										name: "ImplicitTypedDecl",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 121, col: 53, offset: 3713},
+										pos:  position{line: 273, col: 92, offset: 9645},
 										name: "AssignStmt",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 121, col: 66, offset: 3726},
+										pos:  position{line: 273, col: 105, offset: 9658},
+										name: "IncDecStmt",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 273, col: 118, offset: 9671},
 										name: "PrintStmt",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 121, col: 78, offset: 3738},
+										pos:  position{line: 273, col: 130, offset: 9683},
 										name: "ReturnStmt",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 121, col: 91, offset: 3751},
+										pos:  position{line: 273, col: 143, offset: 9696},
 										name: "ExprStmt",
 									},
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 121, col: 101, offset: 3761},
+							pos:  position{line: 273, col: 153, offset: 9706},
 							name: "Terminator",
 						},
 					},
@@ -664,75 +971,91 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "VarDecl",
-			pos:  position{line: 123, col: 1, offset: 3791},
+			name: "WhileStmt",
+			pos:  position{line: 275, col: 1, offset: 9736},
 			expr: &actionExpr{
-				pos: position{line: 123, col: 20, offset: 3810},
-				run: (*parser).callonVarDecl1,
+				pos: position{line: 275, col: 20, offset: 9755},
+				run: (*parser).callonWhileStmt1,
 				expr: &seqExpr{
-					pos: position{line: 123, col: 20, offset: 3810},
+					pos: position{line: 275, col: 20, offset: 9755},
 					exprs: []any{
-						&labeledExpr{
-							pos:   position{line: 123, col: 20, offset: 3810},
-							label: "k",
-							expr: &ruleRefExpr{
-								pos:  position{line: 123, col: 22, offset: 3812},
-								name: "VarKind",
-							},
-						},
 						&ruleRefExpr{
-							pos:  position{line: 123, col: 30, offset: 3820},
-							name: "WS",
-						},
-						&labeledExpr{
-							pos:   position{line: 123, col: 33, offset: 3823},
-							label: "n",
-							expr: &ruleRefExpr{
-								pos:  position{line: 123, col: 35, offset: 3825},
-								name: "Ident",
-							},
+							pos:  position{line: 275, col: 20, offset: 9755},
+							name: "WHILE",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 123, col: 41, offset: 3831},
+							pos:  position{line: 275, col: 26, offset: 9761},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 123, col: 44, offset: 3834},
-							label: "t",
-							expr: &zeroOrOneExpr{
-								pos: position{line: 123, col: 46, offset: 3836},
-								expr: &ruleRefExpr{
-									pos:  position{line: 123, col: 46, offset: 3836},
-									name: "TypeAnn",
-								},
+							pos:   position{line: 275, col: 29, offset: 9764},
+							label: "cond",
+							expr: &ruleRefExpr{
+								pos:  position{line: 275, col: 34, offset: 9769},
+								name: "Expr",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 123, col: 55, offset: 3845},
+							pos: position{line: 275, col: 39, offset: 9774},
 							expr: &ruleRefExpr{
-								pos:  position{line: 123, col: 55, offset: 3845},
+								pos:  position{line: 275, col: 39, offset: 9774},
 								name: "WS",
 							},
 						},
-						&litMatcher{
-							pos:        position{line: 123, col: 59, offset: 3849},
-							val:        "=",
-							ignoreCase: false,
-							want:       "\"=\"",
+						&labeledExpr{
+							pos:   position{line: 275, col: 43, offset: 9778},
+							label: "b",
+							expr: &ruleRefExpr{
+								pos:  position{line: 275, col: 45, offset: 9780},
+								name: "Block",
+							},
 						},
-						&zeroOrOneExpr{
-							pos: position{line: 123, col: 63, offset: 3853},
+					},
+				},
+			},
+		},
+		{
+			name: "BreakStmt",
+			pos:  position{line: 279, col: 1, offset: 9887},
+			expr: &actionExpr{
+				pos: position{line: 279, col: 20, offset: 9906},
+				run: (*parser).callonBreakStmt1,
+				expr: &seqExpr{
+					pos: position{line: 279, col: 20, offset: 9906},
+					exprs: []any{
+						&ruleRefExpr{
+							pos:  position{line: 279, col: 20, offset: 9906},
+							name: "BREAK",
+						},
+						&notExpr{
+							pos: position{line: 279, col: 26, offset: 9912},
 							expr: &ruleRefExpr{
-								pos:  position{line: 123, col: 63, offset: 3853},
-								name: "WS",
+								pos:  position{line: 279, col: 27, offset: 9913},
+								name: "IdentChar",
 							},
 						},
-						&labeledExpr{
-							pos:   position{line: 123, col: 67, offset: 3857},
-							label: "e",
+					},
+				},
+			},
+		},
+		{
+			name: "ContinueStmt",
+			pos:  position{line: 281, col: 1, offset: 9970},
+			expr: &actionExpr{
+				pos: position{line: 281, col: 20, offset: 9989},
+				run: (*parser).callonContinueStmt1,
+				expr: &seqExpr{
+					pos: position{line: 281, col: 20, offset: 9989},
+					exprs: []any{
+						&ruleRefExpr{
+							pos:  position{line: 281, col: 20, offset: 9989},
+							name: "CONTINUE",
+						},
+						&notExpr{
+							pos: position{line: 281, col: 29, offset: 9998},
 							expr: &ruleRefExpr{
-								pos:  position{line: 123, col: 69, offset: 3859},
-								name: "Expr",
+								pos:  position{line: 281, col: 30, offset: 9999},
+								name: "IdentChar",
 							},
 						},
 					},
@@ -740,75 +1063,277 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "ImplicitTypedDecl",
-			pos:  position{line: 131, col: 1, offset: 4050},
+			name: "IncDecStmt",
+			pos:  position{line: 283, col: 1, offset: 10059},
 			expr: &actionExpr{
-				pos: position{line: 131, col: 22, offset: 4071},
-				run: (*parser).callonImplicitTypedDecl1,
+				pos: position{line: 283, col: 20, offset: 10078},
+				run: (*parser).callonIncDecStmt1,
 				expr: &seqExpr{
-					pos: position{line: 131, col: 22, offset: 4071},
+					pos: position{line: 283, col: 20, offset: 10078},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 131, col: 22, offset: 4071},
-							label: "n",
+							pos:   position{line: 283, col: 20, offset: 10078},
+							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 131, col: 24, offset: 4073},
-								name: "Ident",
+								pos:  position{line: 283, col: 22, offset: 10080},
+								name: "Assignable",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 131, col: 30, offset: 4079},
+							pos: position{line: 283, col: 33, offset: 10091},
 							expr: &ruleRefExpr{
-								pos:  position{line: 131, col: 30, offset: 4079},
+								pos:  position{line: 283, col: 33, offset: 10091},
 								name: "WS",
 							},
 						},
-						&litMatcher{
-							pos:        position{line: 131, col: 34, offset: 4083},
-							val:        ":",
+						&labeledExpr{
+							pos:   position{line: 283, col: 37, offset: 10095},
+							label: "o",
+							expr: &choiceExpr{
+								pos: position{line: 283, col: 40, offset: 10098},
+								alternatives: []any{
+									&litMatcher{
+										pos:        position{line: 283, col: 40, offset: 10098},
+										val:        "++",
+										ignoreCase: false,
+										want:       "\"++\"",
+									},
+									&litMatcher{
+										pos:        position{line: 283, col: 47, offset: 10105},
+										val:        "--",
+										ignoreCase: false,
+										want:       "\"--\"",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "VarDecl",
+			pos:  position{line: 287, col: 1, offset: 10209},
+			expr: &choiceExpr{
+				pos: position{line: 287, col: 20, offset: 10228},
+				alternatives: []any{
+					&actionExpr{
+						pos: position{line: 287, col: 20, offset: 10228},
+						run: (*parser).callonVarDecl2,
+						expr: &seqExpr{
+							pos: position{line: 287, col: 20, offset: 10228},
+							exprs: []any{
+								&labeledExpr{
+									pos:   position{line: 287, col: 20, offset: 10228},
+									label: "k",
+									expr: &ruleRefExpr{
+										pos:  position{line: 287, col: 22, offset: 10230},
+										name: "VarKind",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 287, col: 30, offset: 10238},
+									name: "WS",
+								},
+								&labeledExpr{
+									pos:   position{line: 287, col: 33, offset: 10241},
+									label: "t",
+									expr: &ruleRefExpr{
+										pos:  position{line: 287, col: 35, offset: 10243},
+										name: "Type",
+									},
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 287, col: 40, offset: 10248},
+									expr: &ruleRefExpr{
+										pos:  position{line: 287, col: 40, offset: 10248},
+										name: "WS",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 287, col: 44, offset: 10252},
+									label: "n",
+									expr: &ruleRefExpr{
+										pos:  position{line: 287, col: 46, offset: 10254},
+										name: "Ident",
+									},
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 287, col: 52, offset: 10260},
+									expr: &ruleRefExpr{
+										pos:  position{line: 287, col: 52, offset: 10260},
+										name: "WS",
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 287, col: 56, offset: 10264},
+									val:        "=",
+									ignoreCase: false,
+									want:       "\"=\"",
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 287, col: 60, offset: 10268},
+									expr: &ruleRefExpr{
+										pos:  position{line: 287, col: 60, offset: 10268},
+										name: "WS",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 287, col: 64, offset: 10272},
+									label: "e",
+									expr: &ruleRefExpr{
+										pos:  position{line: 287, col: 66, offset: 10274},
+										name: "Expr",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 290, col: 19, offset: 10426},
+						run: (*parser).callonVarDecl20,
+						expr: &seqExpr{
+							pos: position{line: 290, col: 19, offset: 10426},
+							exprs: []any{
+								&labeledExpr{
+									pos:   position{line: 290, col: 19, offset: 10426},
+									label: "k",
+									expr: &ruleRefExpr{
+										pos:  position{line: 290, col: 21, offset: 10428},
+										name: "VarKind",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 290, col: 29, offset: 10436},
+									name: "WS",
+								},
+								&labeledExpr{
+									pos:   position{line: 290, col: 32, offset: 10439},
+									label: "n",
+									expr: &ruleRefExpr{
+										pos:  position{line: 290, col: 34, offset: 10441},
+										name: "Ident",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 290, col: 40, offset: 10447},
+									name: "WS",
+								},
+								&labeledExpr{
+									pos:   position{line: 290, col: 43, offset: 10450},
+									label: "t",
+									expr: &zeroOrOneExpr{
+										pos: position{line: 290, col: 45, offset: 10452},
+										expr: &ruleRefExpr{
+											pos:  position{line: 290, col: 45, offset: 10452},
+											name: "TypeAnn",
+										},
+									},
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 290, col: 54, offset: 10461},
+									expr: &ruleRefExpr{
+										pos:  position{line: 290, col: 54, offset: 10461},
+										name: "WS",
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 290, col: 58, offset: 10465},
+									val:        "=",
+									ignoreCase: false,
+									want:       "\"=\"",
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 290, col: 62, offset: 10469},
+									expr: &ruleRefExpr{
+										pos:  position{line: 290, col: 62, offset: 10469},
+										name: "WS",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 290, col: 66, offset: 10473},
+									label: "e",
+									expr: &ruleRefExpr{
+										pos:  position{line: 290, col: 68, offset: 10475},
+										name: "Expr",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ImplicitTypedDecl",
+			pos:  position{line: 298, col: 1, offset: 10681},
+			expr: &actionExpr{
+				pos: position{line: 298, col: 22, offset: 10702},
+				run: (*parser).callonImplicitTypedDecl1,
+				expr: &seqExpr{
+					pos: position{line: 298, col: 22, offset: 10702},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 298, col: 22, offset: 10702},
+							label: "n",
+							expr: &ruleRefExpr{
+								pos:  position{line: 298, col: 24, offset: 10704},
+								name: "Ident",
+							},
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 298, col: 30, offset: 10710},
+							expr: &ruleRefExpr{
+								pos:  position{line: 298, col: 30, offset: 10710},
+								name: "WS",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 298, col: 34, offset: 10714},
+							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 131, col: 38, offset: 4087},
+							pos: position{line: 298, col: 38, offset: 10718},
 							expr: &ruleRefExpr{
-								pos:  position{line: 131, col: 38, offset: 4087},
+								pos:  position{line: 298, col: 38, offset: 10718},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 131, col: 42, offset: 4091},
+							pos:   position{line: 298, col: 42, offset: 10722},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 131, col: 44, offset: 4093},
+								pos:  position{line: 298, col: 44, offset: 10724},
 								name: "Type",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 131, col: 49, offset: 4098},
+							pos: position{line: 298, col: 49, offset: 10729},
 							expr: &ruleRefExpr{
-								pos:  position{line: 131, col: 49, offset: 4098},
+								pos:  position{line: 298, col: 49, offset: 10729},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 131, col: 53, offset: 4102},
+							pos:        position{line: 298, col: 53, offset: 10733},
 							val:        "=",
 							ignoreCase: false,
 							want:       "\"=\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 131, col: 57, offset: 4106},
+							pos: position{line: 298, col: 57, offset: 10737},
 							expr: &ruleRefExpr{
-								pos:  position{line: 131, col: 57, offset: 4106},
+								pos:  position{line: 298, col: 57, offset: 10737},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 131, col: 61, offset: 4110},
+							pos:   position{line: 298, col: 61, offset: 10741},
 							label: "e",
 							expr: &ruleRefExpr{
-								pos:  position{line: 131, col: 63, offset: 4112},
+								pos:  position{line: 298, col: 63, offset: 10743},
 								name: "Expr",
 							},
 						},
@@ -818,31 +1343,31 @@ var g = &grammar{
 		},
 		{
 			name: "TypeAnn",
-			pos:  position{line: 135, col: 1, offset: 4227},
+			pos:  position{line: 302, col: 1, offset: 10873},
 			expr: &actionExpr{
-				pos: position{line: 135, col: 20, offset: 4246},
+				pos: position{line: 302, col: 20, offset: 10892},
 				run: (*parser).callonTypeAnn1,
 				expr: &seqExpr{
-					pos: position{line: 135, col: 20, offset: 4246},
+					pos: position{line: 302, col: 20, offset: 10892},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 135, col: 20, offset: 4246},
+							pos:        position{line: 302, col: 20, offset: 10892},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 135, col: 24, offset: 4250},
+							pos: position{line: 302, col: 24, offset: 10896},
 							expr: &ruleRefExpr{
-								pos:  position{line: 135, col: 24, offset: 4250},
+								pos:  position{line: 302, col: 24, offset: 10896},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 135, col: 28, offset: 4254},
+							pos:   position{line: 302, col: 28, offset: 10900},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 135, col: 30, offset: 4256},
+								pos:  position{line: 302, col: 30, offset: 10902},
 								name: "Type",
 							},
 						},
@@ -852,31 +1377,31 @@ var g = &grammar{
 		},
 		{
 			name: "VarKind",
-			pos:  position{line: 137, col: 1, offset: 4280},
+			pos:  position{line: 304, col: 1, offset: 10926},
 			expr: &choiceExpr{
-				pos: position{line: 137, col: 20, offset: 4299},
+				pos: position{line: 304, col: 20, offset: 10945},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 137, col: 20, offset: 4299},
+						pos: position{line: 304, col: 20, offset: 10945},
 						run: (*parser).callonVarKind2,
 						expr: &ruleRefExpr{
-							pos:  position{line: 137, col: 20, offset: 4299},
+							pos:  position{line: 304, col: 20, offset: 10945},
 							name: "CONST",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 137, col: 52, offset: 4331},
+						pos: position{line: 304, col: 52, offset: 10977},
 						run: (*parser).callonVarKind4,
 						expr: &ruleRefExpr{
-							pos:  position{line: 137, col: 52, offset: 4331},
+							pos:  position{line: 304, col: 52, offset: 10977},
 							name: "VAL",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 137, col: 80, offset: 4359},
+						pos: position{line: 304, col: 80, offset: 11005},
 						run: (*parser).callonVarKind6,
 						expr: &ruleRefExpr{
-							pos:  position{line: 137, col: 80, offset: 4359},
+							pos:  position{line: 304, col: 80, offset: 11005},
 							name: "VAR",
 						},
 					},
@@ -885,46 +1410,48 @@ var g = &grammar{
 		},
 		{
 			name: "AssignStmt",
-			pos:  position{line: 139, col: 1, offset: 4386},
+			pos:  position{line: 306, col: 1, offset: 11032},
 			expr: &actionExpr{
-				pos: position{line: 139, col: 20, offset: 4405},
+				pos: position{line: 306, col: 20, offset: 11051},
 				run: (*parser).callonAssignStmt1,
 				expr: &seqExpr{
-					pos: position{line: 139, col: 20, offset: 4405},
+					pos: position{line: 306, col: 20, offset: 11051},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 139, col: 20, offset: 4405},
+							pos:   position{line: 306, col: 20, offset: 11051},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 139, col: 22, offset: 4407},
+								pos:  position{line: 306, col: 22, offset: 11053},
 								name: "Assignable",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 139, col: 33, offset: 4418},
+							pos: position{line: 306, col: 33, offset: 11064},
 							expr: &ruleRefExpr{
-								pos:  position{line: 139, col: 33, offset: 4418},
+								pos:  position{line: 306, col: 33, offset: 11064},
 								name: "WS",
 							},
 						},
-						&litMatcher{
-							pos:        position{line: 139, col: 37, offset: 4422},
-							val:        "=",
-							ignoreCase: false,
-							want:       "\"=\"",
+						&labeledExpr{
+							pos:   position{line: 306, col: 37, offset: 11068},
+							label: "o",
+							expr: &ruleRefExpr{
+								pos:  position{line: 306, col: 39, offset: 11070},
+								name: "AssignOp",
+							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 139, col: 41, offset: 4426},
+							pos: position{line: 306, col: 48, offset: 11079},
 							expr: &ruleRefExpr{
-								pos:  position{line: 139, col: 41, offset: 4426},
+								pos:  position{line: 306, col: 48, offset: 11079},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 139, col: 45, offset: 4430},
+							pos:   position{line: 306, col: 52, offset: 11083},
 							label: "e",
 							expr: &ruleRefExpr{
-								pos:  position{line: 139, col: 47, offset: 4432},
+								pos:  position{line: 306, col: 54, offset: 11085},
 								name: "Expr",
 							},
 						},
@@ -932,30 +1459,89 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "AssignOp",
+			pos:  position{line: 310, col: 1, offset: 11201},
+			expr: &choiceExpr{
+				pos: position{line: 310, col: 20, offset: 11220},
+				alternatives: []any{
+					&actionExpr{
+						pos: position{line: 310, col: 20, offset: 11220},
+						run: (*parser).callonAssignOp2,
+						expr: &seqExpr{
+							pos: position{line: 310, col: 20, offset: 11220},
+							exprs: []any{
+								&litMatcher{
+									pos:        position{line: 310, col: 20, offset: 11220},
+									val:        "=",
+									ignoreCase: false,
+									want:       "\"=\"",
+								},
+								&notExpr{
+									pos: position{line: 310, col: 24, offset: 11224},
+									expr: &litMatcher{
+										pos:        position{line: 310, col: 25, offset: 11225},
+										val:        "=",
+										ignoreCase: false,
+										want:       "\"=\"",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 311, col: 19, offset: 11266},
+						run: (*parser).callonAssignOp7,
+						expr: &seqExpr{
+							pos: position{line: 311, col: 19, offset: 11266},
+							exprs: []any{
+								&labeledExpr{
+									pos:   position{line: 311, col: 19, offset: 11266},
+									label: "o",
+									expr: &charClassMatcher{
+										pos:        position{line: 311, col: 21, offset: 11268},
+										val:        "[-+*/%]",
+										chars:      []rune{'-', '+', '*', '/', '%'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 311, col: 29, offset: 11276},
+									val:        "=",
+									ignoreCase: false,
+									want:       "\"=\"",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Assignable",
-			pos:  position{line: 143, col: 1, offset: 4517},
+			pos:  position{line: 313, col: 1, offset: 11316},
 			expr: &actionExpr{
-				pos: position{line: 143, col: 20, offset: 4536},
+				pos: position{line: 313, col: 20, offset: 11335},
 				run: (*parser).callonAssignable1,
 				expr: &seqExpr{
-					pos: position{line: 143, col: 20, offset: 4536},
+					pos: position{line: 313, col: 20, offset: 11335},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 143, col: 20, offset: 4536},
+							pos:   position{line: 313, col: 20, offset: 11335},
 							label: "p",
 							expr: &ruleRefExpr{
-								pos:  position{line: 143, col: 22, offset: 4538},
+								pos:  position{line: 313, col: 22, offset: 11337},
 								name: "PrimaryAccess",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 143, col: 36, offset: 4552},
+							pos:   position{line: 313, col: 36, offset: 11351},
 							label: "s",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 143, col: 38, offset: 4554},
+								pos: position{line: 313, col: 38, offset: 11353},
 								expr: &ruleRefExpr{
-									pos:  position{line: 143, col: 38, offset: 4554},
+									pos:  position{line: 313, col: 38, offset: 11353},
 									name: "AssignableSuffix",
 								},
 							},
@@ -966,15 +1552,15 @@ var g = &grammar{
 		},
 		{
 			name: "PrimaryAccess",
-			pos:  position{line: 148, col: 1, offset: 4655},
+			pos:  position{line: 318, col: 1, offset: 11454},
 			expr: &actionExpr{
-				pos: position{line: 148, col: 20, offset: 4674},
+				pos: position{line: 318, col: 20, offset: 11473},
 				run: (*parser).callonPrimaryAccess1,
 				expr: &labeledExpr{
-					pos:   position{line: 148, col: 20, offset: 4674},
+					pos:   position{line: 318, col: 20, offset: 11473},
 					label: "i",
 					expr: &ruleRefExpr{
-						pos:  position{line: 148, col: 22, offset: 4676},
+						pos:  position{line: 318, col: 22, offset: 11475},
 						name: "Ident",
 					},
 				},
@@ -982,41 +1568,41 @@ var g = &grammar{
 		},
 		{
 			name: "AssignableSuffix",
-			pos:  position{line: 150, col: 1, offset: 4729},
+			pos:  position{line: 320, col: 1, offset: 11543},
 			expr: &choiceExpr{
-				pos: position{line: 150, col: 21, offset: 4749},
+				pos: position{line: 320, col: 21, offset: 11563},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 150, col: 21, offset: 4749},
+						pos: position{line: 320, col: 21, offset: 11563},
 						run: (*parser).callonAssignableSuffix2,
 						expr: &seqExpr{
-							pos: position{line: 150, col: 21, offset: 4749},
+							pos: position{line: 320, col: 21, offset: 11563},
 							exprs: []any{
 								&zeroOrOneExpr{
-									pos: position{line: 150, col: 21, offset: 4749},
+									pos: position{line: 320, col: 21, offset: 11563},
 									expr: &ruleRefExpr{
-										pos:  position{line: 150, col: 21, offset: 4749},
+										pos:  position{line: 320, col: 21, offset: 11563},
 										name: "WS",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 150, col: 25, offset: 4753},
+									pos:        position{line: 320, col: 25, offset: 11567},
 									val:        ".",
 									ignoreCase: false,
 									want:       "\".\"",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 150, col: 29, offset: 4757},
+									pos: position{line: 320, col: 29, offset: 11571},
 									expr: &ruleRefExpr{
-										pos:  position{line: 150, col: 29, offset: 4757},
+										pos:  position{line: 320, col: 29, offset: 11571},
 										name: "WS",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 150, col: 33, offset: 4761},
+									pos:   position{line: 320, col: 33, offset: 11575},
 									label: "i",
 									expr: &ruleRefExpr{
-										pos:  position{line: 150, col: 35, offset: 4763},
+										pos:  position{line: 320, col: 35, offset: 11577},
 										name: "Ident",
 									},
 								},
@@ -1024,48 +1610,48 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 151, col: 20, offset: 4841},
+						pos: position{line: 321, col: 20, offset: 11665},
 						run: (*parser).callonAssignableSuffix11,
 						expr: &seqExpr{
-							pos: position{line: 151, col: 20, offset: 4841},
+							pos: position{line: 321, col: 20, offset: 11665},
 							exprs: []any{
 								&zeroOrOneExpr{
-									pos: position{line: 151, col: 20, offset: 4841},
+									pos: position{line: 321, col: 20, offset: 11665},
 									expr: &ruleRefExpr{
-										pos:  position{line: 151, col: 20, offset: 4841},
+										pos:  position{line: 321, col: 20, offset: 11665},
 										name: "WS",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 151, col: 24, offset: 4845},
+									pos:        position{line: 321, col: 24, offset: 11669},
 									val:        "[",
 									ignoreCase: false,
 									want:       "\"[\"",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 151, col: 28, offset: 4849},
+									pos: position{line: 321, col: 28, offset: 11673},
 									expr: &ruleRefExpr{
-										pos:  position{line: 151, col: 28, offset: 4849},
+										pos:  position{line: 321, col: 28, offset: 11673},
 										name: "WS",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 151, col: 32, offset: 4853},
+									pos:   position{line: 321, col: 32, offset: 11677},
 									label: "e",
 									expr: &ruleRefExpr{
-										pos:  position{line: 151, col: 34, offset: 4855},
+										pos:  position{line: 321, col: 34, offset: 11679},
 										name: "Expr",
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 151, col: 39, offset: 4860},
+									pos: position{line: 321, col: 39, offset: 11684},
 									expr: &ruleRefExpr{
-										pos:  position{line: 151, col: 39, offset: 4860},
+										pos:  position{line: 321, col: 39, offset: 11684},
 										name: "WS",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 151, col: 43, offset: 4864},
+									pos:        position{line: 321, col: 43, offset: 11688},
 									val:        "]",
 									ignoreCase: false,
 									want:       "\"]\"",
@@ -1078,187 +1664,401 @@ var g = &grammar{
 		},
 		{
 			name: "AccessSuffix",
-			pos:  position{line: 152, col: 1, offset: 4923},
+			pos:  position{line: 322, col: 1, offset: 11757},
 			expr: &choiceExpr{
-				pos: position{line: 152, col: 20, offset: 4942},
+				pos: position{line: 322, col: 20, offset: 11776},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 152, col: 20, offset: 4942},
+						pos: position{line: 322, col: 20, offset: 11776},
 						run: (*parser).callonAccessSuffix2,
 						expr: &seqExpr{
-							pos: position{line: 152, col: 20, offset: 4942},
+							pos: position{line: 322, col: 20, offset: 11776},
 							exprs: []any{
 								&zeroOrOneExpr{
-									pos: position{line: 152, col: 20, offset: 4942},
+									pos: position{line: 322, col: 20, offset: 11776},
 									expr: &ruleRefExpr{
-										pos:  position{line: 152, col: 20, offset: 4942},
+										pos:  position{line: 322, col: 20, offset: 11776},
 										name: "WS",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 152, col: 24, offset: 4946},
-									val:        "?.",
+									pos:        position{line: 322, col: 24, offset: 11780},
+									val:        ".",
 									ignoreCase: false,
-									want:       "\"?.\"",
+									want:       "\".\"",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 152, col: 29, offset: 4951},
+									pos: position{line: 322, col: 28, offset: 11784},
 									expr: &ruleRefExpr{
-										pos:  position{line: 152, col: 29, offset: 4951},
+										pos:  position{line: 322, col: 28, offset: 11784},
 										name: "WS",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 152, col: 33, offset: 4955},
-									label: "i",
+									pos:   position{line: 322, col: 32, offset: 11788},
+									label: "m",
 									expr: &ruleRefExpr{
-										pos:  position{line: 152, col: 35, offset: 4957},
-										name: "Ident",
+										pos:  position{line: 322, col: 34, offset: 11790},
+										name: "IterMethod",
+									},
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 322, col: 45, offset: 11801},
+									expr: &ruleRefExpr{
+										pos:  position{line: 322, col: 45, offset: 11801},
+										name: "WS",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 322, col: 49, offset: 11805},
+									label: "b",
+									expr: &ruleRefExpr{
+										pos:  position{line: 322, col: 51, offset: 11807},
+										name: "Block",
 									},
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 153, col: 19, offset: 5039},
-						run: (*parser).callonAccessSuffix11,
+						pos: position{line: 323, col: 19, offset: 11905},
+						run: (*parser).callonAccessSuffix15,
 						expr: &seqExpr{
-							pos: position{line: 153, col: 19, offset: 5039},
+							pos: position{line: 323, col: 19, offset: 11905},
 							exprs: []any{
 								&zeroOrOneExpr{
-									pos: position{line: 153, col: 19, offset: 5039},
+									pos: position{line: 323, col: 19, offset: 11905},
 									expr: &ruleRefExpr{
-										pos:  position{line: 153, col: 19, offset: 5039},
+										pos:  position{line: 323, col: 19, offset: 11905},
 										name: "WS",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 153, col: 23, offset: 5043},
-									val:        ".",
+									pos:        position{line: 323, col: 23, offset: 11909},
+									val:        "?.",
 									ignoreCase: false,
-									want:       "\".\"",
+									want:       "\"?.\"",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 153, col: 27, offset: 5047},
+									pos: position{line: 323, col: 28, offset: 11914},
 									expr: &ruleRefExpr{
-										pos:  position{line: 153, col: 27, offset: 5047},
+										pos:  position{line: 323, col: 28, offset: 11914},
 										name: "WS",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 153, col: 31, offset: 5051},
+									pos:   position{line: 323, col: 32, offset: 11918},
 									label: "i",
 									expr: &ruleRefExpr{
-										pos:  position{line: 153, col: 33, offset: 5053},
+										pos:  position{line: 323, col: 34, offset: 11920},
 										name: "Ident",
 									},
 								},
+								&zeroOrOneExpr{
+									pos: position{line: 323, col: 40, offset: 11926},
+									expr: &ruleRefExpr{
+										pos:  position{line: 323, col: 40, offset: 11926},
+										name: "WS",
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 323, col: 44, offset: 11930},
+									val:        "(",
+									ignoreCase: false,
+									want:       "\"(\"",
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 323, col: 48, offset: 11934},
+									expr: &ruleRefExpr{
+										pos:  position{line: 323, col: 48, offset: 11934},
+										name: "WS",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 323, col: 52, offset: 11938},
+									label: "args",
+									expr: &zeroOrOneExpr{
+										pos: position{line: 323, col: 57, offset: 11943},
+										expr: &ruleRefExpr{
+											pos:  position{line: 323, col: 57, offset: 11943},
+											name: "CallArgList",
+										},
+									},
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 323, col: 70, offset: 11956},
+									expr: &ruleRefExpr{
+										pos:  position{line: 323, col: 70, offset: 11956},
+										name: "WS",
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 323, col: 74, offset: 11960},
+									val:        ")",
+									ignoreCase: false,
+									want:       "\")\"",
+								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 154, col: 19, offset: 5130},
-						run: (*parser).callonAccessSuffix20,
+						pos: position{line: 324, col: 19, offset: 12061},
+						run: (*parser).callonAccessSuffix35,
 						expr: &seqExpr{
-							pos: position{line: 154, col: 19, offset: 5130},
+							pos: position{line: 324, col: 19, offset: 12061},
 							exprs: []any{
 								&zeroOrOneExpr{
-									pos: position{line: 154, col: 19, offset: 5130},
+									pos: position{line: 324, col: 19, offset: 12061},
 									expr: &ruleRefExpr{
-										pos:  position{line: 154, col: 19, offset: 5130},
+										pos:  position{line: 324, col: 19, offset: 12061},
 										name: "WS",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 154, col: 23, offset: 5134},
-									val:        "[",
+									pos:        position{line: 324, col: 23, offset: 12065},
+									val:        ".",
 									ignoreCase: false,
-									want:       "\"[\"",
+									want:       "\".\"",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 154, col: 27, offset: 5138},
+									pos: position{line: 324, col: 27, offset: 12069},
 									expr: &ruleRefExpr{
-										pos:  position{line: 154, col: 27, offset: 5138},
+										pos:  position{line: 324, col: 27, offset: 12069},
 										name: "WS",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 154, col: 31, offset: 5142},
-									label: "e",
+									pos:   position{line: 324, col: 31, offset: 12073},
+									label: "i",
 									expr: &ruleRefExpr{
-										pos:  position{line: 154, col: 33, offset: 5144},
-										name: "Expr",
+										pos:  position{line: 324, col: 33, offset: 12075},
+										name: "Ident",
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 154, col: 38, offset: 5149},
+									pos: position{line: 324, col: 39, offset: 12081},
 									expr: &ruleRefExpr{
-										pos:  position{line: 154, col: 38, offset: 5149},
+										pos:  position{line: 324, col: 39, offset: 12081},
 										name: "WS",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 154, col: 42, offset: 5153},
-									val:        "]",
+									pos:        position{line: 324, col: 43, offset: 12085},
+									val:        "(",
 									ignoreCase: false,
-									want:       "\"]\"",
+									want:       "\"(\"",
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 324, col: 47, offset: 12089},
+									expr: &ruleRefExpr{
+										pos:  position{line: 324, col: 47, offset: 12089},
+										name: "WS",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 324, col: 51, offset: 12093},
+									label: "args",
+									expr: &zeroOrOneExpr{
+										pos: position{line: 324, col: 56, offset: 12098},
+										expr: &ruleRefExpr{
+											pos:  position{line: 324, col: 56, offset: 12098},
+											name: "CallArgList",
+										},
+									},
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 324, col: 69, offset: 12111},
+									expr: &ruleRefExpr{
+										pos:  position{line: 324, col: 69, offset: 12111},
+										name: "WS",
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 324, col: 73, offset: 12115},
+									val:        ")",
+									ignoreCase: false,
+									want:       "\")\"",
 								},
 							},
 						},
 					},
-				},
-			},
+					&actionExpr{
+						pos: position{line: 325, col: 19, offset: 12211},
+						run: (*parser).callonAccessSuffix55,
+						expr: &seqExpr{
+							pos: position{line: 325, col: 19, offset: 12211},
+							exprs: []any{
+								&zeroOrOneExpr{
+									pos: position{line: 325, col: 19, offset: 12211},
+									expr: &ruleRefExpr{
+										pos:  position{line: 325, col: 19, offset: 12211},
+										name: "WS",
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 325, col: 23, offset: 12215},
+									val:        "?.",
+									ignoreCase: false,
+									want:       "\"?.\"",
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 325, col: 28, offset: 12220},
+									expr: &ruleRefExpr{
+										pos:  position{line: 325, col: 28, offset: 12220},
+										name: "WS",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 325, col: 32, offset: 12224},
+									label: "i",
+									expr: &ruleRefExpr{
+										pos:  position{line: 325, col: 34, offset: 12226},
+										name: "Ident",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 326, col: 19, offset: 12318},
+						run: (*parser).callonAccessSuffix64,
+						expr: &seqExpr{
+							pos: position{line: 326, col: 19, offset: 12318},
+							exprs: []any{
+								&zeroOrOneExpr{
+									pos: position{line: 326, col: 19, offset: 12318},
+									expr: &ruleRefExpr{
+										pos:  position{line: 326, col: 19, offset: 12318},
+										name: "WS",
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 326, col: 23, offset: 12322},
+									val:        ".",
+									ignoreCase: false,
+									want:       "\".\"",
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 326, col: 27, offset: 12326},
+									expr: &ruleRefExpr{
+										pos:  position{line: 326, col: 27, offset: 12326},
+										name: "WS",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 326, col: 31, offset: 12330},
+									label: "i",
+									expr: &ruleRefExpr{
+										pos:  position{line: 326, col: 33, offset: 12332},
+										name: "Ident",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 327, col: 19, offset: 12419},
+						run: (*parser).callonAccessSuffix73,
+						expr: &seqExpr{
+							pos: position{line: 327, col: 19, offset: 12419},
+							exprs: []any{
+								&zeroOrOneExpr{
+									pos: position{line: 327, col: 19, offset: 12419},
+									expr: &ruleRefExpr{
+										pos:  position{line: 327, col: 19, offset: 12419},
+										name: "WS",
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 327, col: 23, offset: 12423},
+									val:        "[",
+									ignoreCase: false,
+									want:       "\"[\"",
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 327, col: 27, offset: 12427},
+									expr: &ruleRefExpr{
+										pos:  position{line: 327, col: 27, offset: 12427},
+										name: "WS",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 327, col: 31, offset: 12431},
+									label: "e",
+									expr: &ruleRefExpr{
+										pos:  position{line: 327, col: 33, offset: 12433},
+										name: "Expr",
+									},
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 327, col: 38, offset: 12438},
+									expr: &ruleRefExpr{
+										pos:  position{line: 327, col: 38, offset: 12438},
+										name: "WS",
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 327, col: 42, offset: 12442},
+									val:        "]",
+									ignoreCase: false,
+									want:       "\"]\"",
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 		{
 			name: "PrintStmt",
-			pos:  position{line: 156, col: 1, offset: 5213},
+			pos:  position{line: 329, col: 1, offset: 12512},
 			expr: &actionExpr{
-				pos: position{line: 156, col: 20, offset: 5232},
+				pos: position{line: 329, col: 20, offset: 12531},
 				run: (*parser).callonPrintStmt1,
 				expr: &seqExpr{
-					pos: position{line: 156, col: 20, offset: 5232},
+					pos: position{line: 329, col: 20, offset: 12531},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 156, col: 20, offset: 5232},
+							pos:  position{line: 329, col: 20, offset: 12531},
 							name: "PRINT",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 156, col: 26, offset: 5238},
+							pos: position{line: 329, col: 26, offset: 12537},
 							expr: &ruleRefExpr{
-								pos:  position{line: 156, col: 26, offset: 5238},
+								pos:  position{line: 329, col: 26, offset: 12537},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 156, col: 30, offset: 5242},
+							pos:        position{line: 329, col: 30, offset: 12541},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 156, col: 34, offset: 5246},
+							pos: position{line: 329, col: 34, offset: 12545},
 							expr: &ruleRefExpr{
-								pos:  position{line: 156, col: 34, offset: 5246},
+								pos:  position{line: 329, col: 34, offset: 12545},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 156, col: 38, offset: 5250},
+							pos:   position{line: 329, col: 38, offset: 12549},
 							label: "e",
 							expr: &ruleRefExpr{
-								pos:  position{line: 156, col: 40, offset: 5252},
+								pos:  position{line: 329, col: 40, offset: 12551},
 								name: "Expr",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 156, col: 45, offset: 5257},
+							pos: position{line: 329, col: 45, offset: 12556},
 							expr: &ruleRefExpr{
-								pos:  position{line: 156, col: 45, offset: 5257},
+								pos:  position{line: 329, col: 45, offset: 12556},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 156, col: 49, offset: 5261},
+							pos:        position{line: 329, col: 49, offset: 12560},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -1269,31 +2069,31 @@ var g = &grammar{
 		},
 		{
 			name: "ReturnStmt",
-			pos:  position{line: 160, col: 1, offset: 5321},
+			pos:  position{line: 333, col: 1, offset: 12635},
 			expr: &actionExpr{
-				pos: position{line: 160, col: 20, offset: 5340},
+				pos: position{line: 333, col: 20, offset: 12654},
 				run: (*parser).callonReturnStmt1,
 				expr: &seqExpr{
-					pos: position{line: 160, col: 20, offset: 5340},
+					pos: position{line: 333, col: 20, offset: 12654},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 160, col: 20, offset: 5340},
+							pos:  position{line: 333, col: 20, offset: 12654},
 							name: "RETURN",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 160, col: 27, offset: 5347},
+							pos: position{line: 333, col: 27, offset: 12661},
 							expr: &ruleRefExpr{
-								pos:  position{line: 160, col: 27, offset: 5347},
+								pos:  position{line: 333, col: 27, offset: 12661},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 160, col: 31, offset: 5351},
+							pos:   position{line: 333, col: 31, offset: 12665},
 							label: "e",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 160, col: 33, offset: 5353},
+								pos: position{line: 333, col: 33, offset: 12667},
 								expr: &ruleRefExpr{
-									pos:  position{line: 160, col: 33, offset: 5353},
+									pos:  position{line: 333, col: 33, offset: 12667},
 									name: "Expr",
 								},
 							},
@@ -1304,15 +2104,15 @@ var g = &grammar{
 		},
 		{
 			name: "ExprStmt",
-			pos:  position{line: 168, col: 1, offset: 5482},
+			pos:  position{line: 341, col: 1, offset: 12811},
 			expr: &actionExpr{
-				pos: position{line: 168, col: 20, offset: 5501},
+				pos: position{line: 341, col: 20, offset: 12830},
 				run: (*parser).callonExprStmt1,
 				expr: &labeledExpr{
-					pos:   position{line: 168, col: 20, offset: 5501},
+					pos:   position{line: 341, col: 20, offset: 12830},
 					label: "e",
 					expr: &ruleRefExpr{
-						pos:  position{line: 168, col: 22, offset: 5503},
+						pos:  position{line: 341, col: 22, offset: 12832},
 						name: "Expr",
 					},
 				},
@@ -1320,70 +2120,70 @@ var g = &grammar{
 		},
 		{
 			name: "Expr",
-			pos:  position{line: 170, col: 1, offset: 5559},
+			pos:  position{line: 343, col: 1, offset: 12903},
 			expr: &ruleRefExpr{
-				pos:  position{line: 170, col: 20, offset: 5578},
+				pos:  position{line: 343, col: 20, offset: 12922},
 				name: "Elvis",
 			},
 		},
 		{
 			name: "Elvis",
-			pos:  position{line: 172, col: 1, offset: 5585},
+			pos:  position{line: 345, col: 1, offset: 12929},
 			expr: &choiceExpr{
-				pos: position{line: 172, col: 20, offset: 5604},
+				pos: position{line: 345, col: 20, offset: 12948},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 172, col: 20, offset: 5604},
+						pos: position{line: 345, col: 20, offset: 12948},
 						run: (*parser).callonElvis2,
 						expr: &seqExpr{
-							pos: position{line: 172, col: 20, offset: 5604},
+							pos: position{line: 345, col: 20, offset: 12948},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 172, col: 20, offset: 5604},
+									pos:   position{line: 345, col: 20, offset: 12948},
 									label: "m",
 									expr: &ruleRefExpr{
-										pos:  position{line: 172, col: 22, offset: 5606},
+										pos:  position{line: 345, col: 22, offset: 12950},
 										name: "MatchOrIf",
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 172, col: 32, offset: 5616},
+									pos: position{line: 345, col: 32, offset: 12960},
 									expr: &ruleRefExpr{
-										pos:  position{line: 172, col: 32, offset: 5616},
+										pos:  position{line: 345, col: 32, offset: 12960},
 										name: "WS",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 172, col: 36, offset: 5620},
+									pos:        position{line: 345, col: 36, offset: 12964},
 									val:        "?",
 									ignoreCase: false,
 									want:       "\"?\"",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 172, col: 40, offset: 5624},
+									pos: position{line: 345, col: 40, offset: 12968},
 									expr: &ruleRefExpr{
-										pos:  position{line: 172, col: 40, offset: 5624},
+										pos:  position{line: 345, col: 40, offset: 12968},
 										name: "WS",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 172, col: 44, offset: 5628},
+									pos:        position{line: 345, col: 44, offset: 12972},
 									val:        ":",
 									ignoreCase: false,
 									want:       "\":\"",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 172, col: 48, offset: 5632},
+									pos: position{line: 345, col: 48, offset: 12976},
 									expr: &ruleRefExpr{
-										pos:  position{line: 172, col: 48, offset: 5632},
+										pos:  position{line: 345, col: 48, offset: 12976},
 										name: "WS",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 172, col: 52, offset: 5636},
+									pos:   position{line: 345, col: 52, offset: 12980},
 									label: "r",
 									expr: &ruleRefExpr{
-										pos:  position{line: 172, col: 54, offset: 5638},
+										pos:  position{line: 345, col: 54, offset: 12982},
 										name: "Elvis",
 									},
 								},
@@ -1391,7 +2191,7 @@ var g = &grammar{
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 175, col: 19, offset: 5738},
+						pos:  position{line: 348, col: 19, offset: 13097},
 						name: "Ternary",
 					},
 				},
@@ -1399,77 +2199,77 @@ var g = &grammar{
 		},
 		{
 			name: "Ternary",
-			pos:  position{line: 177, col: 1, offset: 5747},
+			pos:  position{line: 350, col: 1, offset: 13106},
 			expr: &choiceExpr{
-				pos: position{line: 177, col: 20, offset: 5766},
+				pos: position{line: 350, col: 20, offset: 13125},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 177, col: 20, offset: 5766},
+						pos: position{line: 350, col: 20, offset: 13125},
 						run: (*parser).callonTernary2,
 						expr: &seqExpr{
-							pos: position{line: 177, col: 20, offset: 5766},
+							pos: position{line: 350, col: 20, offset: 13125},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 177, col: 20, offset: 5766},
+									pos:   position{line: 350, col: 20, offset: 13125},
 									label: "m",
 									expr: &ruleRefExpr{
-										pos:  position{line: 177, col: 22, offset: 5768},
+										pos:  position{line: 350, col: 22, offset: 13127},
 										name: "MatchOrIf",
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 177, col: 32, offset: 5778},
+									pos: position{line: 350, col: 32, offset: 13137},
 									expr: &ruleRefExpr{
-										pos:  position{line: 177, col: 32, offset: 5778},
+										pos:  position{line: 350, col: 32, offset: 13137},
 										name: "WS",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 177, col: 36, offset: 5782},
+									pos:        position{line: 350, col: 36, offset: 13141},
 									val:        "?",
 									ignoreCase: false,
 									want:       "\"?\"",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 177, col: 40, offset: 5786},
+									pos: position{line: 350, col: 40, offset: 13145},
 									expr: &ruleRefExpr{
-										pos:  position{line: 177, col: 40, offset: 5786},
+										pos:  position{line: 350, col: 40, offset: 13145},
 										name: "WS",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 177, col: 44, offset: 5790},
+									pos:   position{line: 350, col: 44, offset: 13149},
 									label: "t",
 									expr: &ruleRefExpr{
-										pos:  position{line: 177, col: 46, offset: 5792},
+										pos:  position{line: 350, col: 46, offset: 13151},
 										name: "Ternary",
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 177, col: 54, offset: 5800},
+									pos: position{line: 350, col: 54, offset: 13159},
 									expr: &ruleRefExpr{
-										pos:  position{line: 177, col: 54, offset: 5800},
+										pos:  position{line: 350, col: 54, offset: 13159},
 										name: "WS",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 177, col: 58, offset: 5804},
+									pos:        position{line: 350, col: 58, offset: 13163},
 									val:        ":",
 									ignoreCase: false,
 									want:       "\":\"",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 177, col: 62, offset: 5808},
+									pos: position{line: 350, col: 62, offset: 13167},
 									expr: &ruleRefExpr{
-										pos:  position{line: 177, col: 62, offset: 5808},
+										pos:  position{line: 350, col: 62, offset: 13167},
 										name: "WS",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 177, col: 66, offset: 5812},
+									pos:   position{line: 350, col: 66, offset: 13171},
 									label: "f",
 									expr: &ruleRefExpr{
-										pos:  position{line: 177, col: 68, offset: 5814},
+										pos:  position{line: 350, col: 68, offset: 13173},
 										name: "Ternary",
 									},
 								},
@@ -1477,7 +2277,7 @@ var g = &grammar{
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 180, col: 19, offset: 5947},
+						pos:  position{line: 353, col: 19, offset: 13321},
 						name: "MatchOrIf",
 					},
 				},
@@ -1485,80 +2285,214 @@ var g = &grammar{
 		},
 		{
 			name: "MatchOrIf",
-			pos:  position{line: 182, col: 1, offset: 5958},
+			pos:  position{line: 355, col: 1, offset: 13332},
 			expr: &choiceExpr{
-				pos: position{line: 182, col: 20, offset: 5977},
+				pos: position{line: 355, col: 20, offset: 13351},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 182, col: 20, offset: 5977},
+						pos:  position{line: 355, col: 20, offset: 13351},
 						name: "IfExpr",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 182, col: 29, offset: 5986},
+						pos:  position{line: 355, col: 29, offset: 13360},
 						name: "MatchExpr",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 182, col: 41, offset: 5998},
-						name: "Equality",
+						pos:  position{line: 355, col: 41, offset: 13372},
+						name: "LogicalOr",
+					},
+				},
+			},
+		},
+		{
+			name: "LogicalOr",
+			pos:  position{line: 357, col: 1, offset: 13383},
+			expr: &actionExpr{
+				pos: position{line: 357, col: 20, offset: 13402},
+				run: (*parser).callonLogicalOr1,
+				expr: &seqExpr{
+					pos: position{line: 357, col: 20, offset: 13402},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 357, col: 20, offset: 13402},
+							label: "l",
+							expr: &ruleRefExpr{
+								pos:  position{line: 357, col: 22, offset: 13404},
+								name: "LogicalAnd",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 357, col: 33, offset: 13415},
+							label: "t",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 357, col: 35, offset: 13417},
+								expr: &actionExpr{
+									pos: position{line: 357, col: 36, offset: 13418},
+									run: (*parser).callonLogicalOr7,
+									expr: &seqExpr{
+										pos: position{line: 357, col: 36, offset: 13418},
+										exprs: []any{
+											&zeroOrOneExpr{
+												pos: position{line: 357, col: 36, offset: 13418},
+												expr: &ruleRefExpr{
+													pos:  position{line: 357, col: 36, offset: 13418},
+													name: "WS",
+												},
+											},
+											&labeledExpr{
+												pos:   position{line: 357, col: 40, offset: 13422},
+												label: "o",
+												expr: &ruleRefExpr{
+													pos:  position{line: 357, col: 42, offset: 13424},
+													name: "OrOp",
+												},
+											},
+											&zeroOrOneExpr{
+												pos: position{line: 357, col: 47, offset: 13429},
+												expr: &ruleRefExpr{
+													pos:  position{line: 357, col: 47, offset: 13429},
+													name: "WS",
+												},
+											},
+											&labeledExpr{
+												pos:   position{line: 357, col: 51, offset: 13433},
+												label: "r",
+												expr: &ruleRefExpr{
+													pos:  position{line: 357, col: 53, offset: 13435},
+													name: "LogicalAnd",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "LogicalAnd",
+			pos:  position{line: 361, col: 1, offset: 13523},
+			expr: &actionExpr{
+				pos: position{line: 361, col: 20, offset: 13542},
+				run: (*parser).callonLogicalAnd1,
+				expr: &seqExpr{
+					pos: position{line: 361, col: 20, offset: 13542},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 361, col: 20, offset: 13542},
+							label: "l",
+							expr: &ruleRefExpr{
+								pos:  position{line: 361, col: 22, offset: 13544},
+								name: "Equality",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 361, col: 31, offset: 13553},
+							label: "t",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 361, col: 33, offset: 13555},
+								expr: &actionExpr{
+									pos: position{line: 361, col: 34, offset: 13556},
+									run: (*parser).callonLogicalAnd7,
+									expr: &seqExpr{
+										pos: position{line: 361, col: 34, offset: 13556},
+										exprs: []any{
+											&zeroOrOneExpr{
+												pos: position{line: 361, col: 34, offset: 13556},
+												expr: &ruleRefExpr{
+													pos:  position{line: 361, col: 34, offset: 13556},
+													name: "WS",
+												},
+											},
+											&labeledExpr{
+												pos:   position{line: 361, col: 38, offset: 13560},
+												label: "o",
+												expr: &ruleRefExpr{
+													pos:  position{line: 361, col: 40, offset: 13562},
+													name: "AndOp",
+												},
+											},
+											&zeroOrOneExpr{
+												pos: position{line: 361, col: 46, offset: 13568},
+												expr: &ruleRefExpr{
+													pos:  position{line: 361, col: 46, offset: 13568},
+													name: "WS",
+												},
+											},
+											&labeledExpr{
+												pos:   position{line: 361, col: 50, offset: 13572},
+												label: "r",
+												expr: &ruleRefExpr{
+													pos:  position{line: 361, col: 52, offset: 13574},
+													name: "Equality",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
 					},
 				},
 			},
 		},
 		{
 			name: "Equality",
-			pos:  position{line: 184, col: 1, offset: 6008},
+			pos:  position{line: 365, col: 1, offset: 13660},
 			expr: &actionExpr{
-				pos: position{line: 184, col: 20, offset: 6027},
+				pos: position{line: 365, col: 20, offset: 13679},
 				run: (*parser).callonEquality1,
 				expr: &seqExpr{
-					pos: position{line: 184, col: 20, offset: 6027},
+					pos: position{line: 365, col: 20, offset: 13679},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 184, col: 20, offset: 6027},
+							pos:   position{line: 365, col: 20, offset: 13679},
 							label: "l",
 							expr: &ruleRefExpr{
-								pos:  position{line: 184, col: 22, offset: 6029},
+								pos:  position{line: 365, col: 22, offset: 13681},
 								name: "Comparison",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 184, col: 33, offset: 6040},
+							pos:   position{line: 365, col: 33, offset: 13692},
 							label: "t",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 184, col: 35, offset: 6042},
+								pos: position{line: 365, col: 35, offset: 13694},
 								expr: &actionExpr{
-									pos: position{line: 184, col: 36, offset: 6043},
+									pos: position{line: 365, col: 36, offset: 13695},
 									run: (*parser).callonEquality7,
 									expr: &seqExpr{
-										pos: position{line: 184, col: 36, offset: 6043},
+										pos: position{line: 365, col: 36, offset: 13695},
 										exprs: []any{
 											&zeroOrOneExpr{
-												pos: position{line: 184, col: 36, offset: 6043},
+												pos: position{line: 365, col: 36, offset: 13695},
 												expr: &ruleRefExpr{
-													pos:  position{line: 184, col: 36, offset: 6043},
+													pos:  position{line: 365, col: 36, offset: 13695},
 													name: "WS",
 												},
 											},
 											&labeledExpr{
-												pos:   position{line: 184, col: 40, offset: 6047},
+												pos:   position{line: 365, col: 40, offset: 13699},
 												label: "o",
 												expr: &ruleRefExpr{
-													pos:  position{line: 184, col: 42, offset: 6049},
+													pos:  position{line: 365, col: 42, offset: 13701},
 													name: "EqualityOp",
 												},
 											},
 											&zeroOrOneExpr{
-												pos: position{line: 184, col: 53, offset: 6060},
+												pos: position{line: 365, col: 53, offset: 13712},
 												expr: &ruleRefExpr{
-													pos:  position{line: 184, col: 53, offset: 6060},
+													pos:  position{line: 365, col: 53, offset: 13712},
 													name: "WS",
 												},
 											},
 											&labeledExpr{
-												pos:   position{line: 184, col: 57, offset: 6064},
+												pos:   position{line: 365, col: 57, offset: 13716},
 												label: "r",
 												expr: &ruleRefExpr{
-													pos:  position{line: 184, col: 59, offset: 6066},
+													pos:  position{line: 365, col: 59, offset: 13718},
 													name: "Comparison",
 												},
 											},
@@ -1573,59 +2507,59 @@ var g = &grammar{
 		},
 		{
 			name: "Comparison",
-			pos:  position{line: 196, col: 1, offset: 6406},
+			pos:  position{line: 369, col: 1, offset: 13806},
 			expr: &actionExpr{
-				pos: position{line: 196, col: 20, offset: 6425},
+				pos: position{line: 369, col: 20, offset: 13825},
 				run: (*parser).callonComparison1,
 				expr: &seqExpr{
-					pos: position{line: 196, col: 20, offset: 6425},
+					pos: position{line: 369, col: 20, offset: 13825},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 196, col: 20, offset: 6425},
+							pos:   position{line: 369, col: 20, offset: 13825},
 							label: "l",
 							expr: &ruleRefExpr{
-								pos:  position{line: 196, col: 22, offset: 6427},
+								pos:  position{line: 369, col: 22, offset: 13827},
 								name: "Sum",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 196, col: 26, offset: 6431},
+							pos:   position{line: 369, col: 26, offset: 13831},
 							label: "t",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 196, col: 28, offset: 6433},
+								pos: position{line: 369, col: 28, offset: 13833},
 								expr: &actionExpr{
-									pos: position{line: 196, col: 29, offset: 6434},
+									pos: position{line: 369, col: 29, offset: 13834},
 									run: (*parser).callonComparison7,
 									expr: &seqExpr{
-										pos: position{line: 196, col: 29, offset: 6434},
+										pos: position{line: 369, col: 29, offset: 13834},
 										exprs: []any{
 											&zeroOrOneExpr{
-												pos: position{line: 196, col: 29, offset: 6434},
+												pos: position{line: 369, col: 29, offset: 13834},
 												expr: &ruleRefExpr{
-													pos:  position{line: 196, col: 29, offset: 6434},
+													pos:  position{line: 369, col: 29, offset: 13834},
 													name: "WS",
 												},
 											},
 											&labeledExpr{
-												pos:   position{line: 196, col: 33, offset: 6438},
+												pos:   position{line: 369, col: 33, offset: 13838},
 												label: "o",
 												expr: &ruleRefExpr{
-													pos:  position{line: 196, col: 35, offset: 6440},
+													pos:  position{line: 369, col: 35, offset: 13840},
 													name: "CompareOp",
 												},
 											},
 											&zeroOrOneExpr{
-												pos: position{line: 196, col: 45, offset: 6450},
+												pos: position{line: 369, col: 45, offset: 13850},
 												expr: &ruleRefExpr{
-													pos:  position{line: 196, col: 45, offset: 6450},
+													pos:  position{line: 369, col: 45, offset: 13850},
 													name: "WS",
 												},
 											},
 											&labeledExpr{
-												pos:   position{line: 196, col: 49, offset: 6454},
+												pos:   position{line: 369, col: 49, offset: 13854},
 												label: "r",
 												expr: &ruleRefExpr{
-													pos:  position{line: 196, col: 51, offset: 6456},
+													pos:  position{line: 369, col: 51, offset: 13856},
 													name: "Sum",
 												},
 											},
@@ -1640,70 +2574,70 @@ var g = &grammar{
 		},
 		{
 			name: "IfExpr",
-			pos:  position{line: 208, col: 1, offset: 6789},
+			pos:  position{line: 373, col: 1, offset: 13937},
 			expr: &choiceExpr{
-				pos: position{line: 208, col: 20, offset: 6808},
+				pos: position{line: 373, col: 20, offset: 13956},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 208, col: 20, offset: 6808},
+						pos: position{line: 373, col: 20, offset: 13956},
 						run: (*parser).callonIfExpr2,
 						expr: &seqExpr{
-							pos: position{line: 208, col: 20, offset: 6808},
+							pos: position{line: 373, col: 20, offset: 13956},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 208, col: 20, offset: 6808},
+									pos:  position{line: 373, col: 20, offset: 13956},
 									name: "IF",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 208, col: 23, offset: 6811},
+									pos:  position{line: 373, col: 23, offset: 13959},
 									name: "WS",
 								},
 								&labeledExpr{
-									pos:   position{line: 208, col: 26, offset: 6814},
+									pos:   position{line: 373, col: 26, offset: 13962},
 									label: "cond",
 									expr: &ruleRefExpr{
-										pos:  position{line: 208, col: 31, offset: 6819},
+										pos:  position{line: 373, col: 31, offset: 13967},
 										name: "Expr",
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 208, col: 36, offset: 6824},
+									pos: position{line: 373, col: 36, offset: 13972},
 									expr: &ruleRefExpr{
-										pos:  position{line: 208, col: 36, offset: 6824},
+										pos:  position{line: 373, col: 36, offset: 13972},
 										name: "WS",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 208, col: 40, offset: 6828},
+									pos:   position{line: 373, col: 40, offset: 13976},
 									label: "tb",
 									expr: &ruleRefExpr{
-										pos:  position{line: 208, col: 43, offset: 6831},
+										pos:  position{line: 373, col: 43, offset: 13979},
 										name: "Block",
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 208, col: 49, offset: 6837},
+									pos: position{line: 373, col: 49, offset: 13985},
 									expr: &ruleRefExpr{
-										pos:  position{line: 208, col: 49, offset: 6837},
+										pos:  position{line: 373, col: 49, offset: 13985},
 										name: "WS",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 208, col: 53, offset: 6841},
+									pos:  position{line: 373, col: 53, offset: 13989},
 									name: "ELSE",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 208, col: 58, offset: 6846},
+									pos: position{line: 373, col: 58, offset: 13994},
 									expr: &ruleRefExpr{
-										pos:  position{line: 208, col: 58, offset: 6846},
+										pos:  position{line: 373, col: 58, offset: 13994},
 										name: "WS",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 208, col: 62, offset: 6850},
+									pos:   position{line: 373, col: 62, offset: 13998},
 									label: "eb",
 									expr: &ruleRefExpr{
-										pos:  position{line: 208, col: 65, offset: 6853},
+										pos:  position{line: 373, col: 65, offset: 14001},
 										name: "Block",
 									},
 								},
@@ -1711,39 +2645,39 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 211, col: 19, offset: 6993},
+						pos: position{line: 376, col: 19, offset: 14156},
 						run: (*parser).callonIfExpr19,
 						expr: &seqExpr{
-							pos: position{line: 211, col: 19, offset: 6993},
+							pos: position{line: 376, col: 19, offset: 14156},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 211, col: 19, offset: 6993},
+									pos:  position{line: 376, col: 19, offset: 14156},
 									name: "IF",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 211, col: 22, offset: 6996},
+									pos:  position{line: 376, col: 22, offset: 14159},
 									name: "WS",
 								},
 								&labeledExpr{
-									pos:   position{line: 211, col: 25, offset: 6999},
+									pos:   position{line: 376, col: 25, offset: 14162},
 									label: "cond",
 									expr: &ruleRefExpr{
-										pos:  position{line: 211, col: 30, offset: 7004},
+										pos:  position{line: 376, col: 30, offset: 14167},
 										name: "Expr",
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 211, col: 35, offset: 7009},
+									pos: position{line: 376, col: 35, offset: 14172},
 									expr: &ruleRefExpr{
-										pos:  position{line: 211, col: 35, offset: 7009},
+										pos:  position{line: 376, col: 35, offset: 14172},
 										name: "WS",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 211, col: 39, offset: 7013},
+									pos:   position{line: 376, col: 39, offset: 14176},
 									label: "tb",
 									expr: &ruleRefExpr{
-										pos:  position{line: 211, col: 42, offset: 7016},
+										pos:  position{line: 376, col: 42, offset: 14179},
 										name: "Block",
 									},
 								},
@@ -1755,77 +2689,77 @@ var g = &grammar{
 		},
 		{
 			name: "MatchExpr",
-			pos:  position{line: 215, col: 1, offset: 7111},
+			pos:  position{line: 380, col: 1, offset: 14289},
 			expr: &actionExpr{
-				pos: position{line: 215, col: 20, offset: 7130},
+				pos: position{line: 380, col: 20, offset: 14308},
 				run: (*parser).callonMatchExpr1,
 				expr: &seqExpr{
-					pos: position{line: 215, col: 20, offset: 7130},
+					pos: position{line: 380, col: 20, offset: 14308},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 215, col: 20, offset: 7130},
+							pos:  position{line: 380, col: 20, offset: 14308},
 							name: "MATCH",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 215, col: 26, offset: 7136},
+							pos:  position{line: 380, col: 26, offset: 14314},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 215, col: 29, offset: 7139},
+							pos:   position{line: 380, col: 29, offset: 14317},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 215, col: 31, offset: 7141},
+								pos:  position{line: 380, col: 31, offset: 14319},
 								name: "Expr",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 215, col: 36, offset: 7146},
+							pos: position{line: 380, col: 36, offset: 14324},
 							expr: &ruleRefExpr{
-								pos:  position{line: 215, col: 36, offset: 7146},
+								pos:  position{line: 380, col: 36, offset: 14324},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 215, col: 40, offset: 7150},
+							pos:        position{line: 380, col: 40, offset: 14328},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 215, col: 44, offset: 7154},
+							pos:  position{line: 380, col: 44, offset: 14332},
 							name: "Skip",
 						},
 						&labeledExpr{
-							pos:   position{line: 215, col: 49, offset: 7159},
+							pos:   position{line: 380, col: 49, offset: 14337},
 							label: "cases",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 215, col: 55, offset: 7165},
+								pos: position{line: 380, col: 55, offset: 14343},
 								expr: &ruleRefExpr{
-									pos:  position{line: 215, col: 55, offset: 7165},
+									pos:  position{line: 380, col: 55, offset: 14343},
 									name: "MatchCase",
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 215, col: 66, offset: 7176},
+							pos:        position{line: 380, col: 66, offset: 14354},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 215, col: 70, offset: 7180},
+							pos: position{line: 380, col: 70, offset: 14358},
 							expr: &ruleRefExpr{
-								pos:  position{line: 215, col: 70, offset: 7180},
+								pos:  position{line: 380, col: 70, offset: 14358},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 215, col: 74, offset: 7184},
+							pos:   position{line: 380, col: 74, offset: 14362},
 							label: "elseCase",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 215, col: 83, offset: 7193},
+								pos: position{line: 380, col: 83, offset: 14371},
 								expr: &ruleRefExpr{
-									pos:  position{line: 215, col: 83, offset: 7193},
+									pos:  position{line: 380, col: 83, offset: 14371},
 									name: "MatchElse",
 								},
 							},
@@ -1836,29 +2770,29 @@ var g = &grammar{
 		},
 		{
 			name: "MatchElse",
-			pos:  position{line: 228, col: 1, offset: 7546},
+			pos:  position{line: 393, col: 1, offset: 14739},
 			expr: &actionExpr{
-				pos: position{line: 228, col: 20, offset: 7565},
+				pos: position{line: 393, col: 20, offset: 14758},
 				run: (*parser).callonMatchElse1,
 				expr: &seqExpr{
-					pos: position{line: 228, col: 20, offset: 7565},
+					pos: position{line: 393, col: 20, offset: 14758},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 228, col: 20, offset: 7565},
+							pos:  position{line: 393, col: 20, offset: 14758},
 							name: "ELSE",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 228, col: 25, offset: 7570},
+							pos: position{line: 393, col: 25, offset: 14763},
 							expr: &ruleRefExpr{
-								pos:  position{line: 228, col: 25, offset: 7570},
+								pos:  position{line: 393, col: 25, offset: 14763},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 228, col: 29, offset: 7574},
+							pos:   position{line: 393, col: 29, offset: 14767},
 							label: "e",
 							expr: &ruleRefExpr{
-								pos:  position{line: 228, col: 31, offset: 7576},
+								pos:  position{line: 393, col: 31, offset: 14769},
 								name: "Expr",
 							},
 						},
@@ -1868,49 +2802,49 @@ var g = &grammar{
 		},
 		{
 			name: "MatchCase",
-			pos:  position{line: 230, col: 1, offset: 7611},
+			pos:  position{line: 395, col: 1, offset: 14804},
 			expr: &actionExpr{
-				pos: position{line: 230, col: 20, offset: 7630},
+				pos: position{line: 395, col: 20, offset: 14823},
 				run: (*parser).callonMatchCase1,
 				expr: &seqExpr{
-					pos: position{line: 230, col: 20, offset: 7630},
+					pos: position{line: 395, col: 20, offset: 14823},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 230, col: 20, offset: 7630},
+							pos:   position{line: 395, col: 20, offset: 14823},
 							label: "p",
 							expr: &ruleRefExpr{
-								pos:  position{line: 230, col: 22, offset: 7632},
+								pos:  position{line: 395, col: 22, offset: 14825},
 								name: "Pattern",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 230, col: 30, offset: 7640},
+							pos: position{line: 395, col: 30, offset: 14833},
 							expr: &ruleRefExpr{
-								pos:  position{line: 230, col: 30, offset: 7640},
+								pos:  position{line: 395, col: 30, offset: 14833},
 								name: "WS",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 230, col: 34, offset: 7644},
+							pos:  position{line: 395, col: 34, offset: 14837},
 							name: "ARROW",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 230, col: 40, offset: 7650},
+							pos: position{line: 395, col: 40, offset: 14843},
 							expr: &ruleRefExpr{
-								pos:  position{line: 230, col: 40, offset: 7650},
+								pos:  position{line: 395, col: 40, offset: 14843},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 230, col: 44, offset: 7654},
+							pos:   position{line: 395, col: 44, offset: 14847},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 230, col: 46, offset: 7656},
+								pos:  position{line: 395, col: 46, offset: 14849},
 								name: "Expr",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 230, col: 51, offset: 7661},
+							pos:  position{line: 395, col: 51, offset: 14854},
 							name: "Terminator",
 						},
 					},
@@ -1919,111 +2853,280 @@ var g = &grammar{
 		},
 		{
 			name: "Pattern",
-			pos:  position{line: 234, col: 1, offset: 7755},
+			pos:  position{line: 399, col: 1, offset: 14963},
 			expr: &choiceExpr{
-				pos: position{line: 234, col: 20, offset: 7774},
+				pos: position{line: 399, col: 20, offset: 14982},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 234, col: 20, offset: 7774},
+						pos:  position{line: 399, col: 20, offset: 14982},
+						name: "VariantPattern",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 399, col: 37, offset: 14999},
 						name: "RecordPattern",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 234, col: 36, offset: 7790},
+						pos:  position{line: 399, col: 53, offset: 15015},
 						name: "LiteralPattern",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 234, col: 53, offset: 7807},
+						pos:  position{line: 399, col: 70, offset: 15032},
 						name: "WildcardPattern",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 234, col: 71, offset: 7825},
+						pos:  position{line: 399, col: 88, offset: 15050},
 						name: "VarPattern",
 					},
 				},
 			},
 		},
 		{
-			name: "WildcardPattern",
-			pos:  position{line: 236, col: 1, offset: 7837},
-			expr: &actionExpr{
-				pos: position{line: 236, col: 20, offset: 7856},
-				run: (*parser).callonWildcardPattern1,
-				expr: &litMatcher{
-					pos:        position{line: 236, col: 20, offset: 7856},
-					val:        "_",
-					ignoreCase: false,
-					want:       "\"_\"",
-				},
-			},
-		},
-		{
-			name: "VarPattern",
-			pos:  position{line: 238, col: 1, offset: 7900},
+			name: "VariantPattern",
+			pos:  position{line: 403, col: 1, offset: 15167},
 			expr: &actionExpr{
-				pos: position{line: 238, col: 20, offset: 7919},
-				run: (*parser).callonVarPattern1,
-				expr: &labeledExpr{
-					pos:   position{line: 238, col: 20, offset: 7919},
-					label: "i",
-					expr: &ruleRefExpr{
-						pos:  position{line: 238, col: 22, offset: 7921},
-						name: "Ident",
-					},
-				},
-			},
-		},
-		{
-			name: "LiteralPattern",
-			pos:  position{line: 240, col: 1, offset: 7978},
-			expr: &choiceExpr{
-				pos: position{line: 240, col: 20, offset: 7997},
-				alternatives: []any{
-					&actionExpr{
-						pos: position{line: 240, col: 20, offset: 7997},
-						run: (*parser).callonLiteralPattern2,
-						expr: &labeledExpr{
-							pos:   position{line: 240, col: 20, offset: 7997},
-							label: "s",
-							expr: &ruleRefExpr{
-								pos:  position{line: 240, col: 22, offset: 7999},
-								name: "StringLit",
+				pos: position{line: 403, col: 20, offset: 15186},
+				run: (*parser).callonVariantPattern1,
+				expr: &seqExpr{
+					pos: position{line: 403, col: 20, offset: 15186},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 403, col: 20, offset: 15186},
+							label: "t",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 403, col: 22, offset: 15188},
+								expr: &seqExpr{
+									pos: position{line: 403, col: 23, offset: 15189},
+									exprs: []any{
+										&ruleRefExpr{
+											pos:  position{line: 403, col: 23, offset: 15189},
+											name: "TypeIdent",
+										},
+										&litMatcher{
+											pos:        position{line: 403, col: 33, offset: 15199},
+											val:        ".",
+											ignoreCase: false,
+											want:       "\".\"",
+										},
+									},
+								},
 							},
 						},
-					},
-					&actionExpr{
-						pos: position{line: 241, col: 19, offset: 8096},
-						run: (*parser).callonLiteralPattern5,
-						expr: &labeledExpr{
-							pos:   position{line: 241, col: 19, offset: 8096},
-							label: "i",
+						&labeledExpr{
+							pos:   position{line: 403, col: 39, offset: 15205},
+							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 241, col: 21, offset: 8098},
-								name: "IntLit",
+								pos:  position{line: 403, col: 44, offset: 15210},
+								name: "Ident",
 							},
 						},
-					},
-					&actionExpr{
-						pos: position{line: 242, col: 19, offset: 8192},
-						run: (*parser).callonLiteralPattern8,
-						expr: &labeledExpr{
-							pos:   position{line: 242, col: 19, offset: 8192},
-							label: "b",
+						&zeroOrOneExpr{
+							pos: position{line: 403, col: 50, offset: 15216},
 							expr: &ruleRefExpr{
-								pos:  position{line: 242, col: 21, offset: 8194},
-								name: "BoolLit",
+								pos:  position{line: 403, col: 50, offset: 15216},
+								name: "WS",
 							},
 						},
-					},
-					&actionExpr{
-						pos: position{line: 243, col: 19, offset: 8289},
-						run: (*parser).callonLiteralPattern11,
-						expr: &labeledExpr{
-							pos:   position{line: 243, col: 19, offset: 8289},
-							label: "n",
-							expr: &ruleRefExpr{
-								pos:  position{line: 243, col: 21, offset: 8291},
-								name: "NullLit",
-							},
+						&litMatcher{
+							pos:        position{line: 403, col: 54, offset: 15220},
+							val:        "(",
+							ignoreCase: false,
+							want:       "\"(\"",
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 403, col: 58, offset: 15224},
+							expr: &ruleRefExpr{
+								pos:  position{line: 403, col: 58, offset: 15224},
+								name: "WS",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 403, col: 62, offset: 15228},
+							label: "fields",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 403, col: 69, offset: 15235},
+								expr: &ruleRefExpr{
+									pos:  position{line: 403, col: 69, offset: 15235},
+									name: "PatternList",
+								},
+							},
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 403, col: 82, offset: 15248},
+							expr: &ruleRefExpr{
+								pos:  position{line: 403, col: 82, offset: 15248},
+								name: "WS",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 403, col: 86, offset: 15252},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PatternList",
+			pos:  position{line: 417, col: 1, offset: 15634},
+			expr: &actionExpr{
+				pos: position{line: 417, col: 20, offset: 15653},
+				run: (*parser).callonPatternList1,
+				expr: &seqExpr{
+					pos: position{line: 417, col: 20, offset: 15653},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 417, col: 20, offset: 15653},
+							label: "head",
+							expr: &ruleRefExpr{
+								pos:  position{line: 417, col: 25, offset: 15658},
+								name: "Pattern",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 417, col: 33, offset: 15666},
+							label: "tail",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 417, col: 38, offset: 15671},
+								expr: &seqExpr{
+									pos: position{line: 417, col: 39, offset: 15672},
+									exprs: []any{
+										&zeroOrOneExpr{
+											pos: position{line: 417, col: 39, offset: 15672},
+											expr: &ruleRefExpr{
+												pos:  position{line: 417, col: 39, offset: 15672},
+												name: "WS",
+											},
+										},
+										&litMatcher{
+											pos:        position{line: 417, col: 43, offset: 15676},
+											val:        ",",
+											ignoreCase: false,
+											want:       "\",\"",
+										},
+										&zeroOrOneExpr{
+											pos: position{line: 417, col: 47, offset: 15680},
+											expr: &ruleRefExpr{
+												pos:  position{line: 417, col: 47, offset: 15680},
+												name: "WS",
+											},
+										},
+										&ruleRefExpr{
+											pos:  position{line: 417, col: 51, offset: 15684},
+											name: "Pattern",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "WildcardPattern",
+			pos:  position{line: 426, col: 1, offset: 15889},
+			expr: &actionExpr{
+				pos: position{line: 426, col: 20, offset: 15908},
+				run: (*parser).callonWildcardPattern1,
+				expr: &litMatcher{
+					pos:        position{line: 426, col: 20, offset: 15908},
+					val:        "_",
+					ignoreCase: false,
+					want:       "\"_\"",
+				},
+			},
+		},
+		{
+			name: "VarPattern",
+			pos:  position{line: 428, col: 1, offset: 15965},
+			expr: &actionExpr{
+				pos: position{line: 428, col: 20, offset: 15984},
+				run: (*parser).callonVarPattern1,
+				expr: &labeledExpr{
+					pos:   position{line: 428, col: 20, offset: 15984},
+					label: "i",
+					expr: &ruleRefExpr{
+						pos:  position{line: 428, col: 22, offset: 15986},
+						name: "Ident",
+					},
+				},
+			},
+		},
+		{
+			name: "LiteralPattern",
+			pos:  position{line: 430, col: 1, offset: 16058},
+			expr: &choiceExpr{
+				pos: position{line: 430, col: 20, offset: 16077},
+				alternatives: []any{
+					&actionExpr{
+						pos: position{line: 430, col: 20, offset: 16077},
+						run: (*parser).callonLiteralPattern2,
+						expr: &labeledExpr{
+							pos:   position{line: 430, col: 20, offset: 16077},
+							label: "s",
+							expr: &ruleRefExpr{
+								pos:  position{line: 430, col: 22, offset: 16079},
+								name: "StringLit",
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 438, col: 19, offset: 16410},
+						run: (*parser).callonLiteralPattern5,
+						expr: &labeledExpr{
+							pos:   position{line: 438, col: 19, offset: 16410},
+							label: "n",
+							expr: &choiceExpr{
+								pos: position{line: 438, col: 22, offset: 16413},
+								alternatives: []any{
+									&ruleRefExpr{
+										pos:  position{line: 438, col: 22, offset: 16413},
+										name: "NumberLit",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 438, col: 34, offset: 16425},
+										name: "NegIntLit",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 439, col: 19, offset: 16528},
+						run: (*parser).callonLiteralPattern10,
+						expr: &labeledExpr{
+							pos:   position{line: 439, col: 19, offset: 16528},
+							label: "ch",
+							expr: &ruleRefExpr{
+								pos:  position{line: 439, col: 22, offset: 16531},
+								name: "CharLit",
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 440, col: 19, offset: 16641},
+						run: (*parser).callonLiteralPattern13,
+						expr: &labeledExpr{
+							pos:   position{line: 440, col: 19, offset: 16641},
+							label: "b",
+							expr: &ruleRefExpr{
+								pos:  position{line: 440, col: 21, offset: 16643},
+								name: "BoolLit",
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 441, col: 19, offset: 16753},
+						run: (*parser).callonLiteralPattern16,
+						expr: &labeledExpr{
+							pos:   position{line: 441, col: 19, offset: 16753},
+							label: "n",
+							expr: &ruleRefExpr{
+								pos:  position{line: 441, col: 21, offset: 16755},
+								name: "NullLit",
+							},
 						},
 					},
 				},
@@ -2031,33 +3134,33 @@ var g = &grammar{
 		},
 		{
 			name: "RecordPattern",
-			pos:  position{line: 245, col: 1, offset: 8369},
+			pos:  position{line: 443, col: 1, offset: 16848},
 			expr: &actionExpr{
-				pos: position{line: 245, col: 20, offset: 8388},
+				pos: position{line: 443, col: 20, offset: 16867},
 				run: (*parser).callonRecordPattern1,
 				expr: &seqExpr{
-					pos: position{line: 245, col: 20, offset: 8388},
+					pos: position{line: 443, col: 20, offset: 16867},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 245, col: 20, offset: 8388},
+							pos:   position{line: 443, col: 20, offset: 16867},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 245, col: 22, offset: 8390},
+								pos:  position{line: 443, col: 22, offset: 16869},
 								name: "TypeIdent",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 245, col: 32, offset: 8400},
+							pos: position{line: 443, col: 32, offset: 16879},
 							expr: &ruleRefExpr{
-								pos:  position{line: 245, col: 32, offset: 8400},
+								pos:  position{line: 443, col: 32, offset: 16879},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 245, col: 36, offset: 8404},
+							pos:   position{line: 443, col: 36, offset: 16883},
 							label: "body",
 							expr: &ruleRefExpr{
-								pos:  position{line: 245, col: 41, offset: 8409},
+								pos:  position{line: 443, col: 41, offset: 16888},
 								name: "RecordPatternBody",
 							},
 						},
@@ -2067,49 +3170,49 @@ var g = &grammar{
 		},
 		{
 			name: "RecordPatternBody",
-			pos:  position{line: 257, col: 1, offset: 8772},
+			pos:  position{line: 455, col: 1, offset: 17266},
 			expr: &choiceExpr{
-				pos: position{line: 257, col: 22, offset: 8793},
+				pos: position{line: 455, col: 22, offset: 17287},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 257, col: 22, offset: 8793},
+						pos: position{line: 455, col: 22, offset: 17287},
 						run: (*parser).callonRecordPatternBody2,
 						expr: &seqExpr{
-							pos: position{line: 257, col: 22, offset: 8793},
+							pos: position{line: 455, col: 22, offset: 17287},
 							exprs: []any{
 								&litMatcher{
-									pos:        position{line: 257, col: 22, offset: 8793},
+									pos:        position{line: 455, col: 22, offset: 17287},
 									val:        "(",
 									ignoreCase: false,
 									want:       "\"(\"",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 257, col: 26, offset: 8797},
+									pos: position{line: 455, col: 26, offset: 17291},
 									expr: &ruleRefExpr{
-										pos:  position{line: 257, col: 26, offset: 8797},
+										pos:  position{line: 455, col: 26, offset: 17291},
 										name: "WS",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 257, col: 30, offset: 8801},
+									pos:   position{line: 455, col: 30, offset: 17295},
 									label: "f",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 257, col: 32, offset: 8803},
+										pos: position{line: 455, col: 32, offset: 17297},
 										expr: &ruleRefExpr{
-											pos:  position{line: 257, col: 32, offset: 8803},
+											pos:  position{line: 455, col: 32, offset: 17297},
 											name: "RecordPatternFields",
 										},
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 257, col: 53, offset: 8824},
+									pos: position{line: 455, col: 53, offset: 17318},
 									expr: &ruleRefExpr{
-										pos:  position{line: 257, col: 53, offset: 8824},
+										pos:  position{line: 455, col: 53, offset: 17318},
 										name: "WS",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 257, col: 57, offset: 8828},
+									pos:        position{line: 455, col: 57, offset: 17322},
 									val:        ")",
 									ignoreCase: false,
 									want:       "\")\"",
@@ -2118,44 +3221,44 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 258, col: 22, offset: 8871},
+						pos: position{line: 456, col: 22, offset: 17365},
 						run: (*parser).callonRecordPatternBody13,
 						expr: &seqExpr{
-							pos: position{line: 258, col: 22, offset: 8871},
+							pos: position{line: 456, col: 22, offset: 17365},
 							exprs: []any{
 								&litMatcher{
-									pos:        position{line: 258, col: 22, offset: 8871},
+									pos:        position{line: 456, col: 22, offset: 17365},
 									val:        "{",
 									ignoreCase: false,
 									want:       "\"{\"",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 258, col: 26, offset: 8875},
+									pos: position{line: 456, col: 26, offset: 17369},
 									expr: &ruleRefExpr{
-										pos:  position{line: 258, col: 26, offset: 8875},
+										pos:  position{line: 456, col: 26, offset: 17369},
 										name: "WS",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 258, col: 30, offset: 8879},
+									pos:   position{line: 456, col: 30, offset: 17373},
 									label: "f",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 258, col: 32, offset: 8881},
+										pos: position{line: 456, col: 32, offset: 17375},
 										expr: &ruleRefExpr{
-											pos:  position{line: 258, col: 32, offset: 8881},
+											pos:  position{line: 456, col: 32, offset: 17375},
 											name: "RecordPatternFields",
 										},
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 258, col: 53, offset: 8902},
+									pos: position{line: 456, col: 53, offset: 17396},
 									expr: &ruleRefExpr{
-										pos:  position{line: 258, col: 53, offset: 8902},
+										pos:  position{line: 456, col: 53, offset: 17396},
 										name: "WS",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 258, col: 57, offset: 8906},
+									pos:        position{line: 456, col: 57, offset: 17400},
 									val:        "}",
 									ignoreCase: false,
 									want:       "\"}\"",
@@ -2168,51 +3271,51 @@ var g = &grammar{
 		},
 		{
 			name: "RecordPatternFields",
-			pos:  position{line: 260, col: 1, offset: 8929},
+			pos:  position{line: 458, col: 1, offset: 17423},
 			expr: &actionExpr{
-				pos: position{line: 260, col: 24, offset: 8952},
+				pos: position{line: 458, col: 24, offset: 17446},
 				run: (*parser).callonRecordPatternFields1,
 				expr: &seqExpr{
-					pos: position{line: 260, col: 24, offset: 8952},
+					pos: position{line: 458, col: 24, offset: 17446},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 260, col: 24, offset: 8952},
+							pos:   position{line: 458, col: 24, offset: 17446},
 							label: "fp",
 							expr: &ruleRefExpr{
-								pos:  position{line: 260, col: 27, offset: 8955},
+								pos:  position{line: 458, col: 27, offset: 17449},
 								name: "RecordFieldPattern",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 260, col: 46, offset: 8974},
+							pos:   position{line: 458, col: 46, offset: 17468},
 							label: "more",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 260, col: 51, offset: 8979},
+								pos: position{line: 458, col: 51, offset: 17473},
 								expr: &seqExpr{
-									pos: position{line: 260, col: 52, offset: 8980},
+									pos: position{line: 458, col: 52, offset: 17474},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 260, col: 52, offset: 8980},
+											pos: position{line: 458, col: 52, offset: 17474},
 											expr: &ruleRefExpr{
-												pos:  position{line: 260, col: 52, offset: 8980},
+												pos:  position{line: 458, col: 52, offset: 17474},
 												name: "WS",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 260, col: 56, offset: 8984},
+											pos:        position{line: 458, col: 56, offset: 17478},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 260, col: 60, offset: 8988},
+											pos: position{line: 458, col: 60, offset: 17482},
 											expr: &ruleRefExpr{
-												pos:  position{line: 260, col: 60, offset: 8988},
+												pos:  position{line: 458, col: 60, offset: 17482},
 												name: "WS",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 260, col: 64, offset: 8992},
+											pos:  position{line: 458, col: 64, offset: 17486},
 											name: "RecordFieldPattern",
 										},
 									},
@@ -2225,46 +3328,46 @@ var g = &grammar{
 		},
 		{
 			name: "RecordFieldPattern",
-			pos:  position{line: 269, col: 1, offset: 9206},
+			pos:  position{line: 467, col: 1, offset: 17700},
 			expr: &actionExpr{
-				pos: position{line: 269, col: 23, offset: 9228},
+				pos: position{line: 467, col: 23, offset: 17722},
 				run: (*parser).callonRecordFieldPattern1,
 				expr: &seqExpr{
-					pos: position{line: 269, col: 23, offset: 9228},
+					pos: position{line: 467, col: 23, offset: 17722},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 269, col: 23, offset: 9228},
+							pos:   position{line: 467, col: 23, offset: 17722},
 							label: "n",
 							expr: &ruleRefExpr{
-								pos:  position{line: 269, col: 25, offset: 9230},
+								pos:  position{line: 467, col: 25, offset: 17724},
 								name: "Ident",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 269, col: 31, offset: 9236},
+							pos: position{line: 467, col: 31, offset: 17730},
 							expr: &ruleRefExpr{
-								pos:  position{line: 269, col: 31, offset: 9236},
+								pos:  position{line: 467, col: 31, offset: 17730},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 269, col: 35, offset: 9240},
+							pos:        position{line: 467, col: 35, offset: 17734},
 							val:        "=",
 							ignoreCase: false,
 							want:       "\"=\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 269, col: 39, offset: 9244},
+							pos: position{line: 467, col: 39, offset: 17738},
 							expr: &ruleRefExpr{
-								pos:  position{line: 269, col: 39, offset: 9244},
+								pos:  position{line: 467, col: 39, offset: 17738},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 269, col: 43, offset: 9248},
+							pos:   position{line: 467, col: 43, offset: 17742},
 							label: "p",
 							expr: &ruleRefExpr{
-								pos:  position{line: 269, col: 45, offset: 9250},
+								pos:  position{line: 467, col: 45, offset: 17744},
 								name: "Pattern",
 							},
 						},
@@ -2274,59 +3377,59 @@ var g = &grammar{
 		},
 		{
 			name: "Sum",
-			pos:  position{line: 273, col: 1, offset: 9348},
+			pos:  position{line: 471, col: 1, offset: 17857},
 			expr: &actionExpr{
-				pos: position{line: 273, col: 20, offset: 9367},
+				pos: position{line: 471, col: 20, offset: 17876},
 				run: (*parser).callonSum1,
 				expr: &seqExpr{
-					pos: position{line: 273, col: 20, offset: 9367},
+					pos: position{line: 471, col: 20, offset: 17876},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 273, col: 20, offset: 9367},
+							pos:   position{line: 471, col: 20, offset: 17876},
 							label: "l",
 							expr: &ruleRefExpr{
-								pos:  position{line: 273, col: 22, offset: 9369},
+								pos:  position{line: 471, col: 22, offset: 17878},
 								name: "Term",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 273, col: 27, offset: 9374},
+							pos:   position{line: 471, col: 27, offset: 17883},
 							label: "t",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 273, col: 29, offset: 9376},
+								pos: position{line: 471, col: 29, offset: 17885},
 								expr: &actionExpr{
-									pos: position{line: 273, col: 30, offset: 9377},
+									pos: position{line: 471, col: 30, offset: 17886},
 									run: (*parser).callonSum7,
 									expr: &seqExpr{
-										pos: position{line: 273, col: 30, offset: 9377},
+										pos: position{line: 471, col: 30, offset: 17886},
 										exprs: []any{
 											&zeroOrOneExpr{
-												pos: position{line: 273, col: 30, offset: 9377},
+												pos: position{line: 471, col: 30, offset: 17886},
 												expr: &ruleRefExpr{
-													pos:  position{line: 273, col: 30, offset: 9377},
+													pos:  position{line: 471, col: 30, offset: 17886},
 													name: "WS",
 												},
 											},
 											&labeledExpr{
-												pos:   position{line: 273, col: 34, offset: 9381},
+												pos:   position{line: 471, col: 34, offset: 17890},
 												label: "o",
 												expr: &ruleRefExpr{
-													pos:  position{line: 273, col: 36, offset: 9383},
+													pos:  position{line: 471, col: 36, offset: 17892},
 													name: "AddOp",
 												},
 											},
 											&zeroOrOneExpr{
-												pos: position{line: 273, col: 42, offset: 9389},
+												pos: position{line: 471, col: 42, offset: 17898},
 												expr: &ruleRefExpr{
-													pos:  position{line: 273, col: 42, offset: 9389},
+													pos:  position{line: 471, col: 42, offset: 17898},
 													name: "WS",
 												},
 											},
 											&labeledExpr{
-												pos:   position{line: 273, col: 46, offset: 9393},
+												pos:   position{line: 471, col: 46, offset: 17902},
 												label: "r",
 												expr: &ruleRefExpr{
-													pos:  position{line: 273, col: 48, offset: 9395},
+													pos:  position{line: 471, col: 48, offset: 17904},
 													name: "Term",
 												},
 											},
@@ -2341,60 +3444,60 @@ var g = &grammar{
 		},
 		{
 			name: "Term",
-			pos:  position{line: 285, col: 1, offset: 9729},
+			pos:  position{line: 475, col: 1, offset: 17986},
 			expr: &actionExpr{
-				pos: position{line: 285, col: 20, offset: 9748},
+				pos: position{line: 475, col: 20, offset: 18005},
 				run: (*parser).callonTerm1,
 				expr: &seqExpr{
-					pos: position{line: 285, col: 20, offset: 9748},
+					pos: position{line: 475, col: 20, offset: 18005},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 285, col: 20, offset: 9748},
+							pos:   position{line: 475, col: 20, offset: 18005},
 							label: "l",
 							expr: &ruleRefExpr{
-								pos:  position{line: 285, col: 22, offset: 9750},
-								name: "Factor",
+								pos:  position{line: 475, col: 22, offset: 18007},
+								name: "Unary",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 285, col: 29, offset: 9757},
+							pos:   position{line: 475, col: 28, offset: 18013},
 							label: "t",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 285, col: 31, offset: 9759},
+								pos: position{line: 475, col: 30, offset: 18015},
 								expr: &actionExpr{
-									pos: position{line: 285, col: 32, offset: 9760},
+									pos: position{line: 475, col: 31, offset: 18016},
 									run: (*parser).callonTerm7,
 									expr: &seqExpr{
-										pos: position{line: 285, col: 32, offset: 9760},
+										pos: position{line: 475, col: 31, offset: 18016},
 										exprs: []any{
 											&zeroOrOneExpr{
-												pos: position{line: 285, col: 32, offset: 9760},
+												pos: position{line: 475, col: 31, offset: 18016},
 												expr: &ruleRefExpr{
-													pos:  position{line: 285, col: 32, offset: 9760},
+													pos:  position{line: 475, col: 31, offset: 18016},
 													name: "WS",
 												},
 											},
 											&labeledExpr{
-												pos:   position{line: 285, col: 36, offset: 9764},
+												pos:   position{line: 475, col: 35, offset: 18020},
 												label: "o",
 												expr: &ruleRefExpr{
-													pos:  position{line: 285, col: 38, offset: 9766},
+													pos:  position{line: 475, col: 37, offset: 18022},
 													name: "MulOp",
 												},
 											},
 											&zeroOrOneExpr{
-												pos: position{line: 285, col: 44, offset: 9772},
+												pos: position{line: 475, col: 43, offset: 18028},
 												expr: &ruleRefExpr{
-													pos:  position{line: 285, col: 44, offset: 9772},
+													pos:  position{line: 475, col: 43, offset: 18028},
 													name: "WS",
 												},
 											},
 											&labeledExpr{
-												pos:   position{line: 285, col: 48, offset: 9776},
+												pos:   position{line: 475, col: 47, offset: 18032},
 												label: "r",
 												expr: &ruleRefExpr{
-													pos:  position{line: 285, col: 50, offset: 9778},
-													name: "Factor",
+													pos:  position{line: 475, col: 49, offset: 18034},
+													name: "Unary",
 												},
 											},
 										},
@@ -2406,30 +3509,99 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "Unary",
+			pos:  position{line: 479, col: 1, offset: 18117},
+			expr: &choiceExpr{
+				pos: position{line: 479, col: 20, offset: 18136},
+				alternatives: []any{
+					&actionExpr{
+						pos: position{line: 479, col: 20, offset: 18136},
+						run: (*parser).callonUnary2,
+						expr: &seqExpr{
+							pos: position{line: 479, col: 20, offset: 18136},
+							exprs: []any{
+								&labeledExpr{
+									pos:   position{line: 479, col: 20, offset: 18136},
+									label: "n",
+									expr: &ruleRefExpr{
+										pos:  position{line: 479, col: 22, offset: 18138},
+										name: "NegIntLit",
+									},
+								},
+								&notExpr{
+									pos: position{line: 479, col: 32, offset: 18148},
+									expr: &ruleRefExpr{
+										pos:  position{line: 479, col: 33, offset: 18149},
+										name: "AccessSuffix",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 480, col: 19, offset: 18198},
+						run: (*parser).callonUnary8,
+						expr: &seqExpr{
+							pos: position{line: 480, col: 19, offset: 18198},
+							exprs: []any{
+								&labeledExpr{
+									pos:   position{line: 480, col: 19, offset: 18198},
+									label: "o",
+									expr: &ruleRefExpr{
+										pos:  position{line: 480, col: 21, offset: 18200},
+										name: "UnaryOp",
+									},
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 480, col: 29, offset: 18208},
+									expr: &ruleRefExpr{
+										pos:  position{line: 480, col: 29, offset: 18208},
+										name: "WS",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 480, col: 33, offset: 18212},
+									label: "x",
+									expr: &ruleRefExpr{
+										pos:  position{line: 480, col: 35, offset: 18214},
+										name: "Unary",
+									},
+								},
+							},
+						},
+					},
+					&ruleRefExpr{
+						pos:  position{line: 483, col: 19, offset: 18325},
+						name: "Factor",
+					},
+				},
+			},
+		},
 		{
 			name: "Factor",
-			pos:  position{line: 297, col: 1, offset: 10114},
+			pos:  position{line: 485, col: 1, offset: 18333},
 			expr: &actionExpr{
-				pos: position{line: 297, col: 20, offset: 10133},
+				pos: position{line: 485, col: 20, offset: 18352},
 				run: (*parser).callonFactor1,
 				expr: &seqExpr{
-					pos: position{line: 297, col: 20, offset: 10133},
+					pos: position{line: 485, col: 20, offset: 18352},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 297, col: 20, offset: 10133},
+							pos:   position{line: 485, col: 20, offset: 18352},
 							label: "p",
 							expr: &ruleRefExpr{
-								pos:  position{line: 297, col: 22, offset: 10135},
+								pos:  position{line: 485, col: 22, offset: 18354},
 								name: "Primary",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 297, col: 30, offset: 10143},
+							pos:   position{line: 485, col: 30, offset: 18362},
 							label: "s",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 297, col: 32, offset: 10145},
+								pos: position{line: 485, col: 32, offset: 18364},
 								expr: &ruleRefExpr{
-									pos:  position{line: 297, col: 32, offset: 10145},
+									pos:  position{line: 485, col: 32, offset: 18364},
 									name: "AccessSuffix",
 								},
 							},
@@ -2440,90 +3612,106 @@ var g = &grammar{
 		},
 		{
 			name: "Primary",
-			pos:  position{line: 302, col: 1, offset: 10242},
+			pos:  position{line: 490, col: 1, offset: 18461},
 			expr: &choiceExpr{
-				pos: position{line: 302, col: 20, offset: 10261},
+				pos: position{line: 490, col: 20, offset: 18480},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 302, col: 20, offset: 10261},
-						name: "IntLit",
+						pos:  position{line: 490, col: 20, offset: 18480},
+						name: "NumberLit",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 490, col: 32, offset: 18492},
+						name: "CharLit",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 302, col: 29, offset: 10270},
+						pos:  position{line: 490, col: 42, offset: 18502},
 						name: "BoolLit",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 302, col: 39, offset: 10280},
+						pos:  position{line: 490, col: 52, offset: 18512},
 						name: "NullLit",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 302, col: 49, offset: 10290},
+						pos:  position{line: 490, col: 62, offset: 18522},
 						name: "StringLit",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 302, col: 61, offset: 10302},
+						pos:  position{line: 490, col: 74, offset: 18534},
 						name: "LambdaExpr",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 302, col: 74, offset: 10315},
+						pos:  position{line: 490, col: 87, offset: 18547},
 						name: "RecordLiteral",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 302, col: 90, offset: 10331},
+						pos:  position{line: 490, col: 103, offset: 18563},
 						name: "MapShorthandAlloc",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 302, col: 110, offset: 10351},
+						pos:  position{line: 490, col: 123, offset: 18583},
+						name: "EmptyMapLiteral",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 490, col: 141, offset: 18601},
 						name: "MapLiteral",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 302, col: 123, offset: 10364},
+						pos:  position{line: 490, col: 154, offset: 18614},
 						name: "MapAlloc",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 302, col: 134, offset: 10375},
+						pos:  position{line: 490, col: 165, offset: 18625},
 						name: "ArrayAlloc",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 302, col: 147, offset: 10388},
+						pos:  position{line: 490, col: 178, offset: 18638},
 						name: "CallExpr",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 302, col: 158, offset: 10399},
+						pos:  position{line: 490, col: 189, offset: 18649},
 						name: "VarRef",
 					},
-					&seqExpr{
-						pos: position{line: 302, col: 167, offset: 10408},
-						exprs: []any{
-							&litMatcher{
-								pos:        position{line: 302, col: 167, offset: 10408},
-								val:        "(",
-								ignoreCase: false,
-								want:       "\"(\"",
-							},
-							&zeroOrOneExpr{
-								pos: position{line: 302, col: 171, offset: 10412},
-								expr: &ruleRefExpr{
-									pos:  position{line: 302, col: 171, offset: 10412},
-									name: "WS",
+					&actionExpr{
+						pos: position{line: 490, col: 198, offset: 18658},
+						run: (*parser).callonPrimary16,
+						expr: &seqExpr{
+							pos: position{line: 490, col: 198, offset: 18658},
+							exprs: []any{
+								&litMatcher{
+									pos:        position{line: 490, col: 198, offset: 18658},
+									val:        "(",
+									ignoreCase: false,
+									want:       "\"(\"",
 								},
-							},
-							&ruleRefExpr{
-								pos:  position{line: 302, col: 175, offset: 10416},
-								name: "Expr",
-							},
-							&zeroOrOneExpr{
-								pos: position{line: 302, col: 180, offset: 10421},
-								expr: &ruleRefExpr{
-									pos:  position{line: 302, col: 180, offset: 10421},
-									name: "WS",
+								&zeroOrOneExpr{
+									pos: position{line: 490, col: 202, offset: 18662},
+									expr: &ruleRefExpr{
+										pos:  position{line: 490, col: 202, offset: 18662},
+										name: "WS",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 490, col: 206, offset: 18666},
+									label: "e",
+									expr: &ruleRefExpr{
+										pos:  position{line: 490, col: 208, offset: 18668},
+										name: "Expr",
+									},
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 490, col: 213, offset: 18673},
+									expr: &ruleRefExpr{
+										pos:  position{line: 490, col: 213, offset: 18673},
+										name: "WS",
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 490, col: 217, offset: 18677},
+									val:        ")",
+									ignoreCase: false,
+									want:       "\")\"",
 								},
-							},
-							&litMatcher{
-								pos:        position{line: 302, col: 184, offset: 10425},
-								val:        ")",
-								ignoreCase: false,
-								want:       "\")\"",
 							},
 						},
 					},
@@ -2532,61 +3720,61 @@ var g = &grammar{
 		},
 		{
 			name: "CallExpr",
-			pos:  position{line: 304, col: 1, offset: 10430},
+			pos:  position{line: 492, col: 1, offset: 18700},
 			expr: &actionExpr{
-				pos: position{line: 304, col: 20, offset: 10449},
+				pos: position{line: 492, col: 20, offset: 18719},
 				run: (*parser).callonCallExpr1,
 				expr: &seqExpr{
-					pos: position{line: 304, col: 20, offset: 10449},
+					pos: position{line: 492, col: 20, offset: 18719},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 304, col: 20, offset: 10449},
+							pos:   position{line: 492, col: 20, offset: 18719},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 304, col: 25, offset: 10454},
+								pos:  position{line: 492, col: 25, offset: 18724},
 								name: "Ident",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 304, col: 31, offset: 10460},
+							pos: position{line: 492, col: 31, offset: 18730},
 							expr: &ruleRefExpr{
-								pos:  position{line: 304, col: 31, offset: 10460},
+								pos:  position{line: 492, col: 31, offset: 18730},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 304, col: 35, offset: 10464},
+							pos:        position{line: 492, col: 35, offset: 18734},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 304, col: 39, offset: 10468},
+							pos: position{line: 492, col: 39, offset: 18738},
 							expr: &ruleRefExpr{
-								pos:  position{line: 304, col: 39, offset: 10468},
+								pos:  position{line: 492, col: 39, offset: 18738},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 304, col: 43, offset: 10472},
+							pos:   position{line: 492, col: 43, offset: 18742},
 							label: "args",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 304, col: 48, offset: 10477},
+								pos: position{line: 492, col: 48, offset: 18747},
 								expr: &ruleRefExpr{
-									pos:  position{line: 304, col: 48, offset: 10477},
+									pos:  position{line: 492, col: 48, offset: 18747},
 									name: "CallArgList",
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 304, col: 61, offset: 10490},
+							pos: position{line: 492, col: 61, offset: 18760},
 							expr: &ruleRefExpr{
-								pos:  position{line: 304, col: 61, offset: 10490},
+								pos:  position{line: 492, col: 61, offset: 18760},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 304, col: 65, offset: 10494},
+							pos:        position{line: 492, col: 65, offset: 18764},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -2597,51 +3785,51 @@ var g = &grammar{
 		},
 		{
 			name: "CallArgList",
-			pos:  position{line: 316, col: 1, offset: 10808},
+			pos:  position{line: 504, col: 1, offset: 19093},
 			expr: &actionExpr{
-				pos: position{line: 316, col: 20, offset: 10827},
+				pos: position{line: 504, col: 20, offset: 19112},
 				run: (*parser).callonCallArgList1,
 				expr: &seqExpr{
-					pos: position{line: 316, col: 20, offset: 10827},
+					pos: position{line: 504, col: 20, offset: 19112},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 316, col: 20, offset: 10827},
+							pos:   position{line: 504, col: 20, offset: 19112},
 							label: "a",
 							expr: &ruleRefExpr{
-								pos:  position{line: 316, col: 22, offset: 10829},
+								pos:  position{line: 504, col: 22, offset: 19114},
 								name: "Expr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 316, col: 27, offset: 10834},
+							pos:   position{line: 504, col: 27, offset: 19119},
 							label: "r",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 316, col: 29, offset: 10836},
+								pos: position{line: 504, col: 29, offset: 19121},
 								expr: &seqExpr{
-									pos: position{line: 316, col: 30, offset: 10837},
+									pos: position{line: 504, col: 30, offset: 19122},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 316, col: 30, offset: 10837},
+											pos: position{line: 504, col: 30, offset: 19122},
 											expr: &ruleRefExpr{
-												pos:  position{line: 316, col: 30, offset: 10837},
+												pos:  position{line: 504, col: 30, offset: 19122},
 												name: "WS",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 316, col: 34, offset: 10841},
+											pos:        position{line: 504, col: 34, offset: 19126},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 316, col: 38, offset: 10845},
+											pos: position{line: 504, col: 38, offset: 19130},
 											expr: &ruleRefExpr{
-												pos:  position{line: 316, col: 38, offset: 10845},
+												pos:  position{line: 504, col: 38, offset: 19130},
 												name: "WS",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 316, col: 42, offset: 10849},
+											pos:  position{line: 504, col: 42, offset: 19134},
 											name: "Expr",
 										},
 									},
@@ -2654,51 +3842,55 @@ var g = &grammar{
 		},
 		{
 			name: "RecordLiteral",
-			pos:  position{line: 327, col: 1, offset: 11107},
+			pos:  position{line: 515, col: 1, offset: 19392},
 			expr: &actionExpr{
-				pos: position{line: 327, col: 20, offset: 11126},
+				pos: position{line: 515, col: 20, offset: 19411},
 				run: (*parser).callonRecordLiteral1,
 				expr: &seqExpr{
-					pos: position{line: 327, col: 20, offset: 11126},
+					pos: position{line: 515, col: 20, offset: 19411},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 327, col: 20, offset: 11126},
+							pos:   position{line: 515, col: 20, offset: 19411},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 327, col: 25, offset: 11131},
+								pos:  position{line: 515, col: 25, offset: 19416},
 								name: "TypeIdent",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 327, col: 35, offset: 11141},
+							pos: position{line: 515, col: 35, offset: 19426},
 							expr: &ruleRefExpr{
-								pos:  position{line: 327, col: 35, offset: 11141},
+								pos:  position{line: 515, col: 35, offset: 19426},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 327, col: 39, offset: 11145},
+							pos:        position{line: 515, col: 39, offset: 19430},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 327, col: 43, offset: 11149},
+							pos:  position{line: 515, col: 43, offset: 19434},
 							name: "Skip",
 						},
 						&labeledExpr{
-							pos:   position{line: 327, col: 48, offset: 11154},
+							pos:   position{line: 515, col: 48, offset: 19439},
 							label: "f",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 327, col: 50, offset: 11156},
+								pos: position{line: 515, col: 50, offset: 19441},
 								expr: &ruleRefExpr{
-									pos:  position{line: 327, col: 50, offset: 11156},
+									pos:  position{line: 515, col: 50, offset: 19441},
 									name: "FieldAssignList",
 								},
 							},
 						},
+						&ruleRefExpr{
+							pos:  position{line: 515, col: 67, offset: 19458},
+							name: "Skip",
+						},
 						&litMatcher{
-							pos:        position{line: 327, col: 67, offset: 11173},
+							pos:        position{line: 515, col: 72, offset: 19463},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -2709,68 +3901,76 @@ var g = &grammar{
 		},
 		{
 			name: "TypeIdent",
-			pos:  position{line: 339, col: 1, offset: 11500},
-			expr: &seqExpr{
-				pos: position{line: 339, col: 20, offset: 11519},
-				exprs: []any{
-					&andExpr{
-						pos: position{line: 339, col: 20, offset: 11519},
-						expr: &charClassMatcher{
-							pos:        position{line: 339, col: 22, offset: 11521},
-							val:        "[A-Z]",
-							ranges:     []rune{'A', 'Z'},
-							ignoreCase: false,
-							inverted:   false,
+			pos:  position{line: 527, col: 1, offset: 19805},
+			expr: &actionExpr{
+				pos: position{line: 527, col: 20, offset: 19824},
+				run: (*parser).callonTypeIdent1,
+				expr: &seqExpr{
+					pos: position{line: 527, col: 20, offset: 19824},
+					exprs: []any{
+						&andExpr{
+							pos: position{line: 527, col: 20, offset: 19824},
+							expr: &charClassMatcher{
+								pos:        position{line: 527, col: 21, offset: 19825},
+								val:        "[A-Z]",
+								ranges:     []rune{'A', 'Z'},
+								ignoreCase: false,
+								inverted:   false,
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 527, col: 27, offset: 19831},
+							label: "i",
+							expr: &ruleRefExpr{
+								pos:  position{line: 527, col: 29, offset: 19833},
+								name: "Ident",
+							},
 						},
-					},
-					&ruleRefExpr{
-						pos:  position{line: 339, col: 29, offset: 11528},
-						name: "Ident",
 					},
 				},
 			},
 		},
 		{
 			name: "FieldAssignList",
-			pos:  position{line: 341, col: 1, offset: 11535},
+			pos:  position{line: 529, col: 1, offset: 19858},
 			expr: &actionExpr{
-				pos: position{line: 341, col: 20, offset: 11554},
+				pos: position{line: 529, col: 20, offset: 19877},
 				run: (*parser).callonFieldAssignList1,
 				expr: &seqExpr{
-					pos: position{line: 341, col: 20, offset: 11554},
+					pos: position{line: 529, col: 20, offset: 19877},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 341, col: 20, offset: 11554},
+							pos:   position{line: 529, col: 20, offset: 19877},
 							label: "a",
 							expr: &ruleRefExpr{
-								pos:  position{line: 341, col: 22, offset: 11556},
+								pos:  position{line: 529, col: 22, offset: 19879},
 								name: "FieldAssign",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 341, col: 34, offset: 11568},
+							pos:   position{line: 529, col: 34, offset: 19891},
 							label: "r",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 341, col: 36, offset: 11570},
+								pos: position{line: 529, col: 36, offset: 19893},
 								expr: &seqExpr{
-									pos: position{line: 341, col: 37, offset: 11571},
+									pos: position{line: 529, col: 37, offset: 19894},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 341, col: 37, offset: 11571},
+											pos:  position{line: 529, col: 37, offset: 19894},
 											name: "Skip",
 										},
 										&litMatcher{
-											pos:        position{line: 341, col: 42, offset: 11576},
+											pos:        position{line: 529, col: 42, offset: 19899},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 341, col: 46, offset: 11580},
+											pos:  position{line: 529, col: 46, offset: 19903},
 											name: "Skip",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 341, col: 51, offset: 11585},
+											pos:  position{line: 529, col: 51, offset: 19908},
 											name: "FieldAssign",
 										},
 									},
@@ -2783,46 +3983,46 @@ var g = &grammar{
 		},
 		{
 			name: "FieldAssign",
-			pos:  position{line: 350, col: 1, offset: 11777},
+			pos:  position{line: 538, col: 1, offset: 20100},
 			expr: &actionExpr{
-				pos: position{line: 350, col: 20, offset: 11796},
+				pos: position{line: 538, col: 20, offset: 20119},
 				run: (*parser).callonFieldAssign1,
 				expr: &seqExpr{
-					pos: position{line: 350, col: 20, offset: 11796},
+					pos: position{line: 538, col: 20, offset: 20119},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 350, col: 20, offset: 11796},
+							pos:   position{line: 538, col: 20, offset: 20119},
 							label: "n",
 							expr: &ruleRefExpr{
-								pos:  position{line: 350, col: 22, offset: 11798},
+								pos:  position{line: 538, col: 22, offset: 20121},
 								name: "Ident",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 350, col: 28, offset: 11804},
+							pos: position{line: 538, col: 28, offset: 20127},
 							expr: &ruleRefExpr{
-								pos:  position{line: 350, col: 28, offset: 11804},
+								pos:  position{line: 538, col: 28, offset: 20127},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 350, col: 32, offset: 11808},
+							pos:        position{line: 538, col: 32, offset: 20131},
 							val:        "=",
 							ignoreCase: false,
 							want:       "\"=\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 350, col: 36, offset: 11812},
+							pos: position{line: 538, col: 36, offset: 20135},
 							expr: &ruleRefExpr{
-								pos:  position{line: 350, col: 36, offset: 11812},
+								pos:  position{line: 538, col: 36, offset: 20135},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 350, col: 40, offset: 11816},
+							pos:   position{line: 538, col: 40, offset: 20139},
 							label: "e",
 							expr: &ruleRefExpr{
-								pos:  position{line: 350, col: 42, offset: 11818},
+								pos:  position{line: 538, col: 42, offset: 20141},
 								name: "Expr",
 							},
 						},
@@ -2832,84 +4032,84 @@ var g = &grammar{
 		},
 		{
 			name: "ArrayAlloc",
-			pos:  position{line: 352, col: 1, offset: 11880},
+			pos:  position{line: 540, col: 1, offset: 20203},
 			expr: &actionExpr{
-				pos: position{line: 352, col: 20, offset: 11899},
+				pos: position{line: 540, col: 20, offset: 20222},
 				run: (*parser).callonArrayAlloc1,
 				expr: &seqExpr{
-					pos: position{line: 352, col: 20, offset: 11899},
+					pos: position{line: 540, col: 20, offset: 20222},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 352, col: 20, offset: 11899},
+							pos:        position{line: 540, col: 20, offset: 20222},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 352, col: 24, offset: 11903},
+							pos: position{line: 540, col: 24, offset: 20226},
 							expr: &ruleRefExpr{
-								pos:  position{line: 352, col: 24, offset: 11903},
+								pos:  position{line: 540, col: 24, offset: 20226},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 352, col: 28, offset: 11907},
+							pos:   position{line: 540, col: 28, offset: 20230},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 352, col: 30, offset: 11909},
+								pos:  position{line: 540, col: 30, offset: 20232},
 								name: "Type",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 352, col: 35, offset: 11914},
+							pos: position{line: 540, col: 35, offset: 20237},
 							expr: &ruleRefExpr{
-								pos:  position{line: 352, col: 35, offset: 11914},
+								pos:  position{line: 540, col: 35, offset: 20237},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 352, col: 39, offset: 11918},
+							pos:        position{line: 540, col: 39, offset: 20241},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 352, col: 43, offset: 11922},
+							pos: position{line: 540, col: 43, offset: 20245},
 							expr: &ruleRefExpr{
-								pos:  position{line: 352, col: 43, offset: 11922},
+								pos:  position{line: 540, col: 43, offset: 20245},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 352, col: 47, offset: 11926},
+							pos:        position{line: 540, col: 47, offset: 20249},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 352, col: 51, offset: 11930},
+							pos: position{line: 540, col: 51, offset: 20253},
 							expr: &ruleRefExpr{
-								pos:  position{line: 352, col: 51, offset: 11930},
+								pos:  position{line: 540, col: 51, offset: 20253},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 352, col: 55, offset: 11934},
+							pos:   position{line: 540, col: 55, offset: 20257},
 							label: "e",
 							expr: &ruleRefExpr{
-								pos:  position{line: 352, col: 57, offset: 11936},
+								pos:  position{line: 540, col: 57, offset: 20259},
 								name: "Expr",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 352, col: 62, offset: 11941},
+							pos: position{line: 540, col: 62, offset: 20264},
 							expr: &ruleRefExpr{
-								pos:  position{line: 352, col: 62, offset: 11941},
+								pos:  position{line: 540, col: 62, offset: 20264},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 352, col: 66, offset: 11945},
+							pos:        position{line: 540, col: 66, offset: 20268},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -2920,105 +4120,109 @@ var g = &grammar{
 		},
 		{
 			name: "MapLiteral",
-			pos:  position{line: 356, col: 1, offset: 12035},
+			pos:  position{line: 544, col: 1, offset: 20373},
 			expr: &actionExpr{
-				pos: position{line: 356, col: 20, offset: 12054},
+				pos: position{line: 544, col: 20, offset: 20392},
 				run: (*parser).callonMapLiteral1,
 				expr: &seqExpr{
-					pos: position{line: 356, col: 20, offset: 12054},
+					pos: position{line: 544, col: 20, offset: 20392},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 356, col: 20, offset: 12054},
+							pos:        position{line: 544, col: 20, offset: 20392},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 356, col: 24, offset: 12058},
+							pos: position{line: 544, col: 24, offset: 20396},
 							expr: &ruleRefExpr{
-								pos:  position{line: 356, col: 24, offset: 12058},
+								pos:  position{line: 544, col: 24, offset: 20396},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 356, col: 28, offset: 12062},
+							pos:   position{line: 544, col: 28, offset: 20400},
 							label: "k",
 							expr: &ruleRefExpr{
-								pos:  position{line: 356, col: 30, offset: 12064},
+								pos:  position{line: 544, col: 30, offset: 20402},
 								name: "Type",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 356, col: 35, offset: 12069},
+							pos: position{line: 544, col: 35, offset: 20407},
 							expr: &ruleRefExpr{
-								pos:  position{line: 356, col: 35, offset: 12069},
+								pos:  position{line: 544, col: 35, offset: 20407},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 356, col: 39, offset: 12073},
+							pos:        position{line: 544, col: 39, offset: 20411},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 356, col: 43, offset: 12077},
+							pos: position{line: 544, col: 43, offset: 20415},
 							expr: &ruleRefExpr{
-								pos:  position{line: 356, col: 43, offset: 12077},
+								pos:  position{line: 544, col: 43, offset: 20415},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 356, col: 47, offset: 12081},
+							pos:   position{line: 544, col: 47, offset: 20419},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 356, col: 49, offset: 12083},
+								pos:  position{line: 544, col: 49, offset: 20421},
 								name: "Type",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 356, col: 54, offset: 12088},
+							pos: position{line: 544, col: 54, offset: 20426},
 							expr: &ruleRefExpr{
-								pos:  position{line: 356, col: 54, offset: 12088},
+								pos:  position{line: 544, col: 54, offset: 20426},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 356, col: 58, offset: 12092},
+							pos:        position{line: 544, col: 58, offset: 20430},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 356, col: 62, offset: 12096},
+							pos: position{line: 544, col: 62, offset: 20434},
 							expr: &ruleRefExpr{
-								pos:  position{line: 356, col: 62, offset: 12096},
+								pos:  position{line: 544, col: 62, offset: 20434},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 356, col: 66, offset: 12100},
+							pos:        position{line: 544, col: 66, offset: 20438},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 356, col: 70, offset: 12104},
+							pos:  position{line: 544, col: 70, offset: 20442},
 							name: "Skip",
 						},
 						&labeledExpr{
-							pos:   position{line: 356, col: 75, offset: 12109},
+							pos:   position{line: 544, col: 75, offset: 20447},
 							label: "e",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 356, col: 77, offset: 12111},
+								pos: position{line: 544, col: 77, offset: 20449},
 								expr: &ruleRefExpr{
-									pos:  position{line: 356, col: 77, offset: 12111},
+									pos:  position{line: 544, col: 77, offset: 20449},
 									name: "MapEntryList",
 								},
 							},
 						},
+						&ruleRefExpr{
+							pos:  position{line: 544, col: 91, offset: 20463},
+							name: "Skip",
+						},
 						&litMatcher{
-							pos:        position{line: 356, col: 91, offset: 12125},
+							pos:        position{line: 544, col: 96, offset: 20468},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -3029,112 +4233,112 @@ var g = &grammar{
 		},
 		{
 			name: "MapAlloc",
-			pos:  position{line: 369, col: 1, offset: 12567},
+			pos:  position{line: 556, col: 1, offset: 20836},
 			expr: &actionExpr{
-				pos: position{line: 369, col: 20, offset: 12586},
+				pos: position{line: 556, col: 20, offset: 20855},
 				run: (*parser).callonMapAlloc1,
 				expr: &seqExpr{
-					pos: position{line: 369, col: 20, offset: 12586},
+					pos: position{line: 556, col: 20, offset: 20855},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 369, col: 20, offset: 12586},
+							pos:        position{line: 556, col: 20, offset: 20855},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 369, col: 24, offset: 12590},
+							pos: position{line: 556, col: 24, offset: 20859},
 							expr: &ruleRefExpr{
-								pos:  position{line: 369, col: 24, offset: 12590},
+								pos:  position{line: 556, col: 24, offset: 20859},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 369, col: 28, offset: 12594},
+							pos:   position{line: 556, col: 28, offset: 20863},
 							label: "k",
 							expr: &ruleRefExpr{
-								pos:  position{line: 369, col: 30, offset: 12596},
+								pos:  position{line: 556, col: 30, offset: 20865},
 								name: "Type",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 369, col: 35, offset: 12601},
+							pos: position{line: 556, col: 35, offset: 20870},
 							expr: &ruleRefExpr{
-								pos:  position{line: 369, col: 35, offset: 12601},
+								pos:  position{line: 556, col: 35, offset: 20870},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 369, col: 39, offset: 12605},
+							pos:        position{line: 556, col: 39, offset: 20874},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 369, col: 43, offset: 12609},
+							pos: position{line: 556, col: 43, offset: 20878},
 							expr: &ruleRefExpr{
-								pos:  position{line: 369, col: 43, offset: 12609},
+								pos:  position{line: 556, col: 43, offset: 20878},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 369, col: 47, offset: 12613},
+							pos:   position{line: 556, col: 47, offset: 20882},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 369, col: 49, offset: 12615},
+								pos:  position{line: 556, col: 49, offset: 20884},
 								name: "Type",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 369, col: 54, offset: 12620},
+							pos: position{line: 556, col: 54, offset: 20889},
 							expr: &ruleRefExpr{
-								pos:  position{line: 369, col: 54, offset: 12620},
+								pos:  position{line: 556, col: 54, offset: 20889},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 369, col: 58, offset: 12624},
+							pos:        position{line: 556, col: 58, offset: 20893},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 369, col: 62, offset: 12628},
+							pos: position{line: 556, col: 62, offset: 20897},
 							expr: &ruleRefExpr{
-								pos:  position{line: 369, col: 62, offset: 12628},
+								pos:  position{line: 556, col: 62, offset: 20897},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 369, col: 66, offset: 12632},
+							pos:        position{line: 556, col: 66, offset: 20901},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 369, col: 70, offset: 12636},
+							pos: position{line: 556, col: 70, offset: 20905},
 							expr: &ruleRefExpr{
-								pos:  position{line: 369, col: 70, offset: 12636},
+								pos:  position{line: 556, col: 70, offset: 20905},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 369, col: 74, offset: 12640},
+							pos:   position{line: 556, col: 74, offset: 20909},
 							label: "e",
 							expr: &ruleRefExpr{
-								pos:  position{line: 369, col: 76, offset: 12642},
+								pos:  position{line: 556, col: 76, offset: 20911},
 								name: "Expr",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 369, col: 81, offset: 12647},
+							pos: position{line: 556, col: 81, offset: 20916},
 							expr: &ruleRefExpr{
-								pos:  position{line: 369, col: 81, offset: 12647},
+								pos:  position{line: 556, col: 81, offset: 20916},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 369, col: 85, offset: 12651},
+							pos:        position{line: 556, col: 85, offset: 20920},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -3145,82 +4349,82 @@ var g = &grammar{
 		},
 		{
 			name: "MapShorthandAlloc",
-			pos:  position{line: 373, col: 1, offset: 12762},
+			pos:  position{line: 560, col: 1, offset: 21046},
 			expr: &actionExpr{
-				pos: position{line: 373, col: 22, offset: 12783},
+				pos: position{line: 560, col: 22, offset: 21067},
 				run: (*parser).callonMapShorthandAlloc1,
 				expr: &seqExpr{
-					pos: position{line: 373, col: 22, offset: 12783},
+					pos: position{line: 560, col: 22, offset: 21067},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 373, col: 22, offset: 12783},
+							pos:        position{line: 560, col: 22, offset: 21067},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 373, col: 26, offset: 12787},
+							pos: position{line: 560, col: 26, offset: 21071},
 							expr: &ruleRefExpr{
-								pos:  position{line: 373, col: 26, offset: 12787},
+								pos:  position{line: 560, col: 26, offset: 21071},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 373, col: 30, offset: 12791},
+							pos:        position{line: 560, col: 30, offset: 21075},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 373, col: 34, offset: 12795},
+							pos: position{line: 560, col: 34, offset: 21079},
 							expr: &ruleRefExpr{
-								pos:  position{line: 373, col: 34, offset: 12795},
+								pos:  position{line: 560, col: 34, offset: 21079},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 373, col: 38, offset: 12799},
+							pos:        position{line: 560, col: 38, offset: 21083},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 373, col: 42, offset: 12803},
+							pos: position{line: 560, col: 42, offset: 21087},
 							expr: &ruleRefExpr{
-								pos:  position{line: 373, col: 42, offset: 12803},
+								pos:  position{line: 560, col: 42, offset: 21087},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 373, col: 46, offset: 12807},
+							pos:        position{line: 560, col: 46, offset: 21091},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 373, col: 50, offset: 12811},
+							pos: position{line: 560, col: 50, offset: 21095},
 							expr: &ruleRefExpr{
-								pos:  position{line: 373, col: 50, offset: 12811},
+								pos:  position{line: 560, col: 50, offset: 21095},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 373, col: 54, offset: 12815},
+							pos:   position{line: 560, col: 54, offset: 21099},
 							label: "e",
 							expr: &ruleRefExpr{
-								pos:  position{line: 373, col: 56, offset: 12817},
+								pos:  position{line: 560, col: 56, offset: 21101},
 								name: "Expr",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 373, col: 61, offset: 12822},
+							pos: position{line: 560, col: 61, offset: 21106},
 							expr: &ruleRefExpr{
-								pos:  position{line: 373, col: 61, offset: 12822},
+								pos:  position{line: 560, col: 61, offset: 21106},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 373, col: 65, offset: 12826},
+							pos:        position{line: 560, col: 65, offset: 21110},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -3230,46 +4434,91 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "MapEntryList",
-			pos:  position{line: 377, col: 1, offset: 12933},
+			name: "EmptyMapLiteral",
+			pos:  position{line: 566, col: 1, offset: 21383},
 			expr: &actionExpr{
-				pos: position{line: 377, col: 20, offset: 12952},
-				run: (*parser).callonMapEntryList1,
+				pos: position{line: 566, col: 20, offset: 21402},
+				run: (*parser).callonEmptyMapLiteral1,
 				expr: &seqExpr{
-					pos: position{line: 377, col: 20, offset: 12952},
+					pos: position{line: 566, col: 20, offset: 21402},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 566, col: 20, offset: 21402},
+							val:        "[",
+							ignoreCase: false,
+							want:       "\"[\"",
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 566, col: 24, offset: 21406},
+							expr: &ruleRefExpr{
+								pos:  position{line: 566, col: 24, offset: 21406},
+								name: "WS",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 566, col: 28, offset: 21410},
+							val:        ":",
+							ignoreCase: false,
+							want:       "\":\"",
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 566, col: 32, offset: 21414},
+							expr: &ruleRefExpr{
+								pos:  position{line: 566, col: 32, offset: 21414},
+								name: "WS",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 566, col: 36, offset: 21418},
+							val:        "]",
+							ignoreCase: false,
+							want:       "\"]\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "MapEntryList",
+			pos:  position{line: 570, col: 1, offset: 21510},
+			expr: &actionExpr{
+				pos: position{line: 570, col: 20, offset: 21529},
+				run: (*parser).callonMapEntryList1,
+				expr: &seqExpr{
+					pos: position{line: 570, col: 20, offset: 21529},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 377, col: 20, offset: 12952},
+							pos:   position{line: 570, col: 20, offset: 21529},
 							label: "e",
 							expr: &ruleRefExpr{
-								pos:  position{line: 377, col: 22, offset: 12954},
+								pos:  position{line: 570, col: 22, offset: 21531},
 								name: "MapEntry",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 377, col: 31, offset: 12963},
+							pos:   position{line: 570, col: 31, offset: 21540},
 							label: "r",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 377, col: 33, offset: 12965},
+								pos: position{line: 570, col: 33, offset: 21542},
 								expr: &seqExpr{
-									pos: position{line: 377, col: 34, offset: 12966},
+									pos: position{line: 570, col: 34, offset: 21543},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 377, col: 34, offset: 12966},
+											pos:  position{line: 570, col: 34, offset: 21543},
 											name: "Skip",
 										},
 										&litMatcher{
-											pos:        position{line: 377, col: 39, offset: 12971},
+											pos:        position{line: 570, col: 39, offset: 21548},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 377, col: 43, offset: 12975},
+											pos:  position{line: 570, col: 43, offset: 21552},
 											name: "Skip",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 377, col: 48, offset: 12980},
+											pos:  position{line: 570, col: 48, offset: 21557},
 											name: "MapEntry",
 										},
 									},
@@ -3282,46 +4531,46 @@ var g = &grammar{
 		},
 		{
 			name: "MapEntry",
-			pos:  position{line: 386, col: 1, offset: 13169},
+			pos:  position{line: 579, col: 1, offset: 21746},
 			expr: &actionExpr{
-				pos: position{line: 386, col: 20, offset: 13188},
+				pos: position{line: 579, col: 20, offset: 21765},
 				run: (*parser).callonMapEntry1,
 				expr: &seqExpr{
-					pos: position{line: 386, col: 20, offset: 13188},
+					pos: position{line: 579, col: 20, offset: 21765},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 386, col: 20, offset: 13188},
+							pos:   position{line: 579, col: 20, offset: 21765},
 							label: "k",
 							expr: &ruleRefExpr{
-								pos:  position{line: 386, col: 22, offset: 13190},
+								pos:  position{line: 579, col: 22, offset: 21767},
 								name: "Expr",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 386, col: 27, offset: 13195},
+							pos: position{line: 579, col: 27, offset: 21772},
 							expr: &ruleRefExpr{
-								pos:  position{line: 386, col: 27, offset: 13195},
+								pos:  position{line: 579, col: 27, offset: 21772},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 386, col: 31, offset: 13199},
+							pos:        position{line: 579, col: 31, offset: 21776},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 386, col: 35, offset: 13203},
+							pos: position{line: 579, col: 35, offset: 21780},
 							expr: &ruleRefExpr{
-								pos:  position{line: 386, col: 35, offset: 13203},
+								pos:  position{line: 579, col: 35, offset: 21780},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 386, col: 39, offset: 13207},
+							pos:   position{line: 579, col: 39, offset: 21784},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 386, col: 41, offset: 13209},
+								pos:  position{line: 579, col: 41, offset: 21786},
 								name: "Expr",
 							},
 						},
@@ -3331,241 +4580,211 @@ var g = &grammar{
 		},
 		{
 			name: "VarRef",
-			pos:  position{line: 388, col: 1, offset: 13273},
+			pos:  position{line: 581, col: 1, offset: 21881},
 			expr: &actionExpr{
-				pos: position{line: 388, col: 20, offset: 13292},
+				pos: position{line: 581, col: 20, offset: 21900},
 				run: (*parser).callonVarRef1,
 				expr: &labeledExpr{
-					pos:   position{line: 388, col: 20, offset: 13292},
+					pos:   position{line: 581, col: 20, offset: 21900},
 					label: "i",
 					expr: &ruleRefExpr{
-						pos:  position{line: 388, col: 22, offset: 13294},
+						pos:  position{line: 581, col: 22, offset: 21902},
 						name: "Ident",
 					},
 				},
 			},
 		},
 		{
-			name: "IntLit",
-			pos:  position{line: 390, col: 1, offset: 13347},
-			expr: &actionExpr{
-				pos: position{line: 390, col: 20, offset: 13366},
-				run: (*parser).callonIntLit1,
-				expr: &labeledExpr{
-					pos:   position{line: 390, col: 20, offset: 13366},
-					label: "v",
-					expr: &oneOrMoreExpr{
-						pos: position{line: 390, col: 22, offset: 13368},
-						expr: &charClassMatcher{
-							pos:        position{line: 390, col: 22, offset: 13368},
-							val:        "[0-9]",
-							ranges:     []rune{'0', '9'},
-							ignoreCase: false,
-							inverted:   false,
-						},
+			name: "NumberLit",
+			pos:  position{line: 583, col: 1, offset: 21970},
+			expr: &choiceExpr{
+				pos: position{line: 583, col: 20, offset: 21989},
+				alternatives: []any{
+					&ruleRefExpr{
+						pos:  position{line: 583, col: 20, offset: 21989},
+						name: "FloatLit",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 583, col: 31, offset: 22000},
+						name: "IntLit",
 					},
 				},
 			},
 		},
 		{
-			name: "BoolLit",
-			pos:  position{line: 415, col: 1, offset: 13961},
+			name: "FloatLit",
+			pos:  position{line: 585, col: 1, offset: 22008},
 			expr: &choiceExpr{
-				pos: position{line: 415, col: 20, offset: 13980},
+				pos: position{line: 585, col: 20, offset: 22027},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 415, col: 20, offset: 13980},
-						run: (*parser).callonBoolLit2,
-						expr: &ruleRefExpr{
-							pos:  position{line: 415, col: 20, offset: 13980},
-							name: "TRUE",
+						pos: position{line: 585, col: 20, offset: 22027},
+						run: (*parser).callonFloatLit2,
+						expr: &seqExpr{
+							pos: position{line: 585, col: 20, offset: 22027},
+							exprs: []any{
+								&ruleRefExpr{
+									pos:  position{line: 585, col: 20, offset: 22027},
+									name: "DecDigits",
+								},
+								&choiceExpr{
+									pos: position{line: 585, col: 32, offset: 22039},
+									alternatives: []any{
+										&seqExpr{
+											pos: position{line: 585, col: 32, offset: 22039},
+											exprs: []any{
+												&litMatcher{
+													pos:        position{line: 585, col: 32, offset: 22039},
+													val:        ".",
+													ignoreCase: false,
+													want:       "\".\"",
+												},
+												&ruleRefExpr{
+													pos:  position{line: 585, col: 36, offset: 22043},
+													name: "DecDigits",
+												},
+												&zeroOrOneExpr{
+													pos: position{line: 585, col: 46, offset: 22053},
+													expr: &ruleRefExpr{
+														pos:  position{line: 585, col: 46, offset: 22053},
+														name: "Exponent",
+													},
+												},
+											},
+										},
+										&ruleRefExpr{
+											pos:  position{line: 585, col: 58, offset: 22065},
+											name: "Exponent",
+										},
+									},
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 585, col: 69, offset: 22076},
+									expr: &charClassMatcher{
+										pos:        position{line: 585, col: 69, offset: 22076},
+										val:        "[fFdD]",
+										chars:      []rune{'f', 'F', 'd', 'D'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+								&notExpr{
+									pos: position{line: 585, col: 77, offset: 22084},
+									expr: &ruleRefExpr{
+										pos:  position{line: 585, col: 78, offset: 22085},
+										name: "IdentChar",
+									},
+								},
+							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 416, col: 19, offset: 14049},
-						run: (*parser).callonBoolLit4,
-						expr: &ruleRefExpr{
-							pos:  position{line: 416, col: 19, offset: 14049},
-							name: "FALSE",
-						},
-					},
-				},
-			},
-		},
-		{
-			name: "NullLit",
-			pos:  position{line: 418, col: 1, offset: 14103},
-			expr: &actionExpr{
-				pos: position{line: 418, col: 20, offset: 14122},
-				run: (*parser).callonNullLit1,
-				expr: &ruleRefExpr{
-					pos:  position{line: 418, col: 20, offset: 14122},
-					name: "NULL",
-				},
-			},
-		},
-		{
-			name: "StringLit",
-			pos:  position{line: 420, col: 1, offset: 14163},
-			expr: &actionExpr{
-				pos: position{line: 420, col: 20, offset: 14182},
-				run: (*parser).callonStringLit1,
-				expr: &seqExpr{
-					pos: position{line: 420, col: 20, offset: 14182},
-					exprs: []any{
-						&litMatcher{
-							pos:        position{line: 420, col: 20, offset: 14182},
-							val:        "\"",
-							ignoreCase: false,
-							want:       "\"\\\"\"",
-						},
-						&labeledExpr{
-							pos:   position{line: 420, col: 25, offset: 14187},
-							label: "v",
-							expr: &zeroOrMoreExpr{
-								pos: position{line: 420, col: 27, offset: 14189},
-								expr: &charClassMatcher{
-									pos:        position{line: 420, col: 27, offset: 14189},
-									val:        "[^\"]",
-									chars:      []rune{'"'},
+						pos: position{line: 588, col: 19, offset: 22144},
+						run: (*parser).callonFloatLit16,
+						expr: &seqExpr{
+							pos: position{line: 588, col: 19, offset: 22144},
+							exprs: []any{
+								&ruleRefExpr{
+									pos:  position{line: 588, col: 19, offset: 22144},
+									name: "DecDigits",
+								},
+								&charClassMatcher{
+									pos:        position{line: 588, col: 29, offset: 22154},
+									val:        "[fFdD]",
+									chars:      []rune{'f', 'F', 'd', 'D'},
 									ignoreCase: false,
-									inverted:   true,
+									inverted:   false,
+								},
+								&notExpr{
+									pos: position{line: 588, col: 36, offset: 22161},
+									expr: &ruleRefExpr{
+										pos:  position{line: 588, col: 37, offset: 22162},
+										name: "IdentChar",
+									},
 								},
 							},
 						},
-						&litMatcher{
-							pos:        position{line: 420, col: 33, offset: 14195},
-							val:        "\"",
-							ignoreCase: false,
-							want:       "\"\\\"\"",
-						},
 					},
 				},
 			},
 		},
 		{
-			name: "Type",
-			pos:  position{line: 446, col: 1, offset: 14986},
+			name: "IntLit",
+			pos:  position{line: 592, col: 1, offset: 22204},
 			expr: &actionExpr{
-				pos: position{line: 446, col: 20, offset: 15005},
-				run: (*parser).callonType1,
+				pos: position{line: 592, col: 20, offset: 22223},
+				run: (*parser).callonIntLit1,
 				expr: &seqExpr{
-					pos: position{line: 446, col: 20, offset: 15005},
+					pos: position{line: 592, col: 20, offset: 22223},
 					exprs: []any{
-						&labeledExpr{
-							pos:   position{line: 446, col: 20, offset: 15005},
-							label: "t",
-							expr: &choiceExpr{
-								pos: position{line: 446, col: 23, offset: 15008},
-								alternatives: []any{
-									&ruleRefExpr{
-										pos:  position{line: 446, col: 23, offset: 15008},
-										name: "MapType",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 446, col: 33, offset: 15018},
-										name: "ArrayType",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 446, col: 45, offset: 15030},
-										name: "SimpleType",
+						&choiceExpr{
+							pos: position{line: 592, col: 22, offset: 22225},
+							alternatives: []any{
+								&seqExpr{
+									pos: position{line: 592, col: 22, offset: 22225},
+									exprs: []any{
+										&litMatcher{
+											pos:        position{line: 592, col: 22, offset: 22225},
+											val:        "0",
+											ignoreCase: false,
+											want:       "\"0\"",
+										},
+										&charClassMatcher{
+											pos:        position{line: 592, col: 26, offset: 22229},
+											val:        "[xX]",
+											chars:      []rune{'x', 'X'},
+											ignoreCase: false,
+											inverted:   false,
+										},
+										&ruleRefExpr{
+											pos:  position{line: 592, col: 31, offset: 22234},
+											name: "HexDigits",
+										},
 									},
 								},
-							},
-						},
-						&labeledExpr{
-							pos:   position{line: 446, col: 57, offset: 15042},
-							label: "q",
-							expr: &zeroOrOneExpr{
-								pos: position{line: 446, col: 59, offset: 15044},
-								expr: &seqExpr{
-									pos: position{line: 446, col: 60, offset: 15045},
+								&seqExpr{
+									pos: position{line: 592, col: 43, offset: 22246},
 									exprs: []any{
 										&litMatcher{
-											pos:        position{line: 446, col: 60, offset: 15045},
-											val:        "?",
+											pos:        position{line: 592, col: 43, offset: 22246},
+											val:        "0",
 											ignoreCase: false,
-											want:       "\"?\"",
+											want:       "\"0\"",
 										},
-										&zeroOrOneExpr{
-											pos: position{line: 446, col: 64, offset: 15049},
-											expr: &ruleRefExpr{
-												pos:  position{line: 446, col: 64, offset: 15049},
-												name: "WS",
-											},
+										&charClassMatcher{
+											pos:        position{line: 592, col: 47, offset: 22250},
+											val:        "[bB]",
+											chars:      []rune{'b', 'B'},
+											ignoreCase: false,
+											inverted:   false,
+										},
+										&ruleRefExpr{
+											pos:  position{line: 592, col: 52, offset: 22255},
+											name: "BinDigits",
 										},
 									},
 								},
+								&ruleRefExpr{
+									pos:  position{line: 592, col: 64, offset: 22267},
+									name: "DecDigits",
+								},
 							},
 						},
-					},
-				},
-			},
-		},
-		{
-			name: "SimpleType",
-			pos:  position{line: 453, col: 1, offset: 15139},
-			expr: &choiceExpr{
-				pos: position{line: 453, col: 20, offset: 15158},
-				alternatives: []any{
-					&actionExpr{
-						pos: position{line: 453, col: 20, offset: 15158},
-						run: (*parser).callonSimpleType2,
-						expr: &labeledExpr{
-							pos:   position{line: 453, col: 20, offset: 15158},
-							label: "t",
-							expr: &choiceExpr{
-								pos: position{line: 453, col: 23, offset: 15161},
-								alternatives: []any{
-									&ruleRefExpr{
-										pos:  position{line: 453, col: 23, offset: 15161},
-										name: "VOID",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 453, col: 30, offset: 15168},
-										name: "INT",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 453, col: 36, offset: 15174},
-										name: "LONG",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 453, col: 43, offset: 15181},
-										name: "FLOAT",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 453, col: 51, offset: 15189},
-										name: "DOUBLE",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 453, col: 60, offset: 15198},
-										name: "CHAR",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 453, col: 67, offset: 15205},
-										name: "BYTES",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 453, col: 75, offset: 15213},
-										name: "STRING",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 453, col: 84, offset: 15222},
-										name: "BOOL",
-									},
-								},
+						&zeroOrOneExpr{
+							pos: position{line: 592, col: 76, offset: 22279},
+							expr: &charClassMatcher{
+								pos:        position{line: 592, col: 76, offset: 22279},
+								val:        "[lL]",
+								chars:      []rune{'l', 'L'},
+								ignoreCase: false,
+								inverted:   false,
 							},
 						},
-					},
-					&actionExpr{
-						pos: position{line: 454, col: 19, offset: 15273},
-						run: (*parser).callonSimpleType14,
-						expr: &labeledExpr{
-							pos:   position{line: 454, col: 19, offset: 15273},
-							label: "i",
+						&notExpr{
+							pos: position{line: 592, col: 82, offset: 22285},
 							expr: &ruleRefExpr{
-								pos:  position{line: 454, col: 21, offset: 15275},
-								name: "Ident",
+								pos:  position{line: 592, col: 83, offset: 22286},
+								name: "IdentChar",
 							},
 						},
 					},
@@ -3573,119 +4792,881 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "ArrayType",
-			pos:  position{line: 456, col: 1, offset: 15309},
+			name: "NegIntLit",
+			pos:  position{line: 598, col: 1, offset: 22431},
 			expr: &actionExpr{
-				pos: position{line: 456, col: 20, offset: 15328},
-				run: (*parser).callonArrayType1,
+				pos: position{line: 598, col: 20, offset: 22450},
+				run: (*parser).callonNegIntLit1,
 				expr: &seqExpr{
-					pos: position{line: 456, col: 20, offset: 15328},
+					pos: position{line: 598, col: 20, offset: 22450},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 456, col: 20, offset: 15328},
-							val:        "[",
+							pos:        position{line: 598, col: 20, offset: 22450},
+							val:        "-",
 							ignoreCase: false,
-							want:       "\"[\"",
+							want:       "\"-\"",
 						},
-						&zeroOrOneExpr{
-							pos: position{line: 456, col: 24, offset: 15332},
-							expr: &ruleRefExpr{
-								pos:  position{line: 456, col: 24, offset: 15332},
-								name: "WS",
-							},
+						&ruleRefExpr{
+							pos:  position{line: 598, col: 24, offset: 22454},
+							name: "DecDigits",
 						},
-						&labeledExpr{
-							pos:   position{line: 456, col: 28, offset: 15336},
-							label: "t",
-							expr: &ruleRefExpr{
-								pos:  position{line: 456, col: 30, offset: 15338},
-								name: "Type",
+						&zeroOrOneExpr{
+							pos: position{line: 598, col: 34, offset: 22464},
+							expr: &charClassMatcher{
+								pos:        position{line: 598, col: 34, offset: 22464},
+								val:        "[lL]",
+								chars:      []rune{'l', 'L'},
+								ignoreCase: false,
+								inverted:   false,
 							},
 						},
-						&zeroOrOneExpr{
-							pos: position{line: 456, col: 35, offset: 15343},
+						&notExpr{
+							pos: position{line: 598, col: 40, offset: 22470},
 							expr: &ruleRefExpr{
-								pos:  position{line: 456, col: 35, offset: 15343},
-								name: "WS",
+								pos:  position{line: 598, col: 41, offset: 22471},
+								name: "IdentChar",
 							},
 						},
-						&litMatcher{
-							pos:        position{line: 456, col: 39, offset: 15347},
-							val:        "]",
-							ignoreCase: false,
-							want:       "\"]\"",
-						},
 					},
 				},
 			},
 		},
 		{
-			name: "MapType",
-			pos:  position{line: 458, col: 1, offset: 15391},
-			expr: &actionExpr{
-				pos: position{line: 458, col: 20, offset: 15410},
-				run: (*parser).callonMapType1,
-				expr: &seqExpr{
-					pos: position{line: 458, col: 20, offset: 15410},
-					exprs: []any{
-						&litMatcher{
-							pos:        position{line: 458, col: 20, offset: 15410},
-							val:        "[",
-							ignoreCase: false,
-							want:       "\"[\"",
-						},
-						&zeroOrOneExpr{
-							pos: position{line: 458, col: 24, offset: 15414},
-							expr: &ruleRefExpr{
-								pos:  position{line: 458, col: 24, offset: 15414},
-								name: "WS",
+			name: "DecDigits",
+			pos:  position{line: 602, col: 1, offset: 22511},
+			expr: &seqExpr{
+				pos: position{line: 602, col: 20, offset: 22530},
+				exprs: []any{
+					&charClassMatcher{
+						pos:        position{line: 602, col: 20, offset: 22530},
+						val:        "[0-9]",
+						ranges:     []rune{'0', '9'},
+						ignoreCase: false,
+						inverted:   false,
+					},
+					&zeroOrMoreExpr{
+						pos: position{line: 602, col: 26, offset: 22536},
+						expr: &seqExpr{
+							pos: position{line: 602, col: 28, offset: 22538},
+							exprs: []any{
+								&zeroOrMoreExpr{
+									pos: position{line: 602, col: 28, offset: 22538},
+									expr: &litMatcher{
+										pos:        position{line: 602, col: 28, offset: 22538},
+										val:        "_",
+										ignoreCase: false,
+										want:       "\"_\"",
+									},
+								},
+								&charClassMatcher{
+									pos:        position{line: 602, col: 33, offset: 22543},
+									val:        "[0-9]",
+									ranges:     []rune{'0', '9'},
+									ignoreCase: false,
+									inverted:   false,
+								},
 							},
 						},
-						&labeledExpr{
-							pos:   position{line: 458, col: 28, offset: 15418},
-							label: "k",
+					},
+				},
+			},
+		},
+		{
+			name: "HexDigits",
+			pos:  position{line: 603, col: 1, offset: 22552},
+			expr: &seqExpr{
+				pos: position{line: 603, col: 20, offset: 22571},
+				exprs: []any{
+					&charClassMatcher{
+						pos:        position{line: 603, col: 20, offset: 22571},
+						val:        "[0-9a-fA-F]",
+						ranges:     []rune{'0', '9', 'a', 'f', 'A', 'F'},
+						ignoreCase: false,
+						inverted:   false,
+					},
+					&zeroOrMoreExpr{
+						pos: position{line: 603, col: 32, offset: 22583},
+						expr: &seqExpr{
+							pos: position{line: 603, col: 34, offset: 22585},
+							exprs: []any{
+								&zeroOrMoreExpr{
+									pos: position{line: 603, col: 34, offset: 22585},
+									expr: &litMatcher{
+										pos:        position{line: 603, col: 34, offset: 22585},
+										val:        "_",
+										ignoreCase: false,
+										want:       "\"_\"",
+									},
+								},
+								&charClassMatcher{
+									pos:        position{line: 603, col: 39, offset: 22590},
+									val:        "[0-9a-fA-F]",
+									ranges:     []rune{'0', '9', 'a', 'f', 'A', 'F'},
+									ignoreCase: false,
+									inverted:   false,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "BinDigits",
+			pos:  position{line: 604, col: 1, offset: 22605},
+			expr: &seqExpr{
+				pos: position{line: 604, col: 20, offset: 22624},
+				exprs: []any{
+					&charClassMatcher{
+						pos:        position{line: 604, col: 20, offset: 22624},
+						val:        "[01]",
+						chars:      []rune{'0', '1'},
+						ignoreCase: false,
+						inverted:   false,
+					},
+					&zeroOrMoreExpr{
+						pos: position{line: 604, col: 25, offset: 22629},
+						expr: &seqExpr{
+							pos: position{line: 604, col: 27, offset: 22631},
+							exprs: []any{
+								&zeroOrMoreExpr{
+									pos: position{line: 604, col: 27, offset: 22631},
+									expr: &litMatcher{
+										pos:        position{line: 604, col: 27, offset: 22631},
+										val:        "_",
+										ignoreCase: false,
+										want:       "\"_\"",
+									},
+								},
+								&charClassMatcher{
+									pos:        position{line: 604, col: 32, offset: 22636},
+									val:        "[01]",
+									chars:      []rune{'0', '1'},
+									ignoreCase: false,
+									inverted:   false,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Exponent",
+			pos:  position{line: 605, col: 1, offset: 22644},
+			expr: &seqExpr{
+				pos: position{line: 605, col: 20, offset: 22663},
+				exprs: []any{
+					&charClassMatcher{
+						pos:        position{line: 605, col: 20, offset: 22663},
+						val:        "[eE]",
+						chars:      []rune{'e', 'E'},
+						ignoreCase: false,
+						inverted:   false,
+					},
+					&zeroOrOneExpr{
+						pos: position{line: 605, col: 25, offset: 22668},
+						expr: &charClassMatcher{
+							pos:        position{line: 605, col: 25, offset: 22668},
+							val:        "[+-]",
+							chars:      []rune{'+', '-'},
+							ignoreCase: false,
+							inverted:   false,
+						},
+					},
+					&ruleRefExpr{
+						pos:  position{line: 605, col: 31, offset: 22674},
+						name: "DecDigits",
+					},
+				},
+			},
+		},
+		{
+			name: "IdentChar",
+			pos:  position{line: 606, col: 1, offset: 22684},
+			expr: &charClassMatcher{
+				pos:        position{line: 606, col: 20, offset: 22703},
+				val:        "[A-Za-z0-9_]",
+				chars:      []rune{'_'},
+				ranges:     []rune{'A', 'Z', 'a', 'z', '0', '9'},
+				ignoreCase: false,
+				inverted:   false,
+			},
+		},
+		{
+			name: "CharLit",
+			pos:  position{line: 608, col: 1, offset: 22717},
+			expr: &actionExpr{
+				pos: position{line: 608, col: 20, offset: 22736},
+				run: (*parser).callonCharLit1,
+				expr: &seqExpr{
+					pos: position{line: 608, col: 20, offset: 22736},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 608, col: 20, offset: 22736},
+							val:        "'",
+							ignoreCase: false,
+							want:       "\"'\"",
+						},
+						&labeledExpr{
+							pos:   position{line: 608, col: 24, offset: 22740},
+							label: "ch",
+							expr: &ruleRefExpr{
+								pos:  position{line: 608, col: 27, offset: 22743},
+								name: "CharBody",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 608, col: 36, offset: 22752},
+							val:        "'",
+							ignoreCase: false,
+							want:       "\"'\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "CharBody",
+			pos:  position{line: 617, col: 1, offset: 22916},
+			expr: &choiceExpr{
+				pos: position{line: 617, col: 20, offset: 22935},
+				alternatives: []any{
+					&ruleRefExpr{
+						pos:  position{line: 617, col: 20, offset: 22935},
+						name: "EscapeSeq",
+					},
+					&actionExpr{
+						pos: position{line: 617, col: 32, offset: 22947},
+						run: (*parser).callonCharBody3,
+						expr: &seqExpr{
+							pos: position{line: 617, col: 32, offset: 22947},
+							exprs: []any{
+								&notExpr{
+									pos: position{line: 617, col: 32, offset: 22947},
+									expr: &choiceExpr{
+										pos: position{line: 617, col: 34, offset: 22949},
+										alternatives: []any{
+											&litMatcher{
+												pos:        position{line: 617, col: 34, offset: 22949},
+												val:        "'",
+												ignoreCase: false,
+												want:       "\"'\"",
+											},
+											&litMatcher{
+												pos:        position{line: 617, col: 40, offset: 22955},
+												val:        "\\",
+												ignoreCase: false,
+												want:       "\"\\\\\"",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 617, col: 47, offset: 22962},
+												name: "NL",
+											},
+										},
+									},
+								},
+								&anyMatcher{
+									line: 617, col: 51, offset: 22966,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "BoolLit",
+			pos:  position{line: 619, col: 1, offset: 23000},
+			expr: &choiceExpr{
+				pos: position{line: 619, col: 20, offset: 23019},
+				alternatives: []any{
+					&actionExpr{
+						pos: position{line: 619, col: 20, offset: 23019},
+						run: (*parser).callonBoolLit2,
+						expr: &ruleRefExpr{
+							pos:  position{line: 619, col: 20, offset: 23019},
+							name: "TRUE",
+						},
+					},
+					&actionExpr{
+						pos: position{line: 620, col: 19, offset: 23103},
+						run: (*parser).callonBoolLit4,
+						expr: &ruleRefExpr{
+							pos:  position{line: 620, col: 19, offset: 23103},
+							name: "FALSE",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "NullLit",
+			pos:  position{line: 622, col: 1, offset: 23172},
+			expr: &actionExpr{
+				pos: position{line: 622, col: 20, offset: 23191},
+				run: (*parser).callonNullLit1,
+				expr: &ruleRefExpr{
+					pos:  position{line: 622, col: 20, offset: 23191},
+					name: "NULL",
+				},
+			},
+		},
+		{
+			name: "StringLit",
+			pos:  position{line: 624, col: 1, offset: 23245},
+			expr: &actionExpr{
+				pos: position{line: 624, col: 20, offset: 23264},
+				run: (*parser).callonStringLit1,
+				expr: &seqExpr{
+					pos: position{line: 624, col: 20, offset: 23264},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 624, col: 20, offset: 23264},
+							val:        "\"",
+							ignoreCase: false,
+							want:       "\"\\\"\"",
+						},
+						&labeledExpr{
+							pos:   position{line: 624, col: 25, offset: 23269},
+							label: "parts",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 624, col: 31, offset: 23275},
+								expr: &ruleRefExpr{
+									pos:  position{line: 624, col: 31, offset: 23275},
+									name: "StringPart",
+								},
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 624, col: 43, offset: 23287},
+							val:        "\"",
+							ignoreCase: false,
+							want:       "\"\\\"\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "StringPart",
+			pos:  position{line: 641, col: 1, offset: 23771},
+			expr: &choiceExpr{
+				pos: position{line: 641, col: 20, offset: 23790},
+				alternatives: []any{
+					&actionExpr{
+						pos: position{line: 641, col: 20, offset: 23790},
+						run: (*parser).callonStringPart2,
+						expr: &seqExpr{
+							pos: position{line: 641, col: 20, offset: 23790},
+							exprs: []any{
+								&litMatcher{
+									pos:        position{line: 641, col: 20, offset: 23790},
+									val:        "${",
+									ignoreCase: false,
+									want:       "\"${\"",
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 641, col: 25, offset: 23795},
+									expr: &ruleRefExpr{
+										pos:  position{line: 641, col: 25, offset: 23795},
+										name: "WS",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 641, col: 29, offset: 23799},
+									label: "e",
+									expr: &ruleRefExpr{
+										pos:  position{line: 641, col: 31, offset: 23801},
+										name: "Expr",
+									},
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 641, col: 36, offset: 23806},
+									expr: &ruleRefExpr{
+										pos:  position{line: 641, col: 36, offset: 23806},
+										name: "WS",
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 641, col: 40, offset: 23810},
+									val:        "}",
+									ignoreCase: false,
+									want:       "\"}\"",
+								},
+							},
+						},
+					},
+					&ruleRefExpr{
+						pos:  position{line: 642, col: 19, offset: 23850},
+						name: "StringText",
+					},
+				},
+			},
+		},
+		{
+			name: "StringText",
+			pos:  position{line: 644, col: 1, offset: 23862},
+			expr: &actionExpr{
+				pos: position{line: 644, col: 20, offset: 23881},
+				run: (*parser).callonStringText1,
+				expr: &labeledExpr{
+					pos:   position{line: 644, col: 20, offset: 23881},
+					label: "chunks",
+					expr: &oneOrMoreExpr{
+						pos: position{line: 644, col: 27, offset: 23888},
+						expr: &ruleRefExpr{
+							pos:  position{line: 644, col: 27, offset: 23888},
+							name: "StringChar",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "StringChar",
+			pos:  position{line: 652, col: 1, offset: 24101},
+			expr: &choiceExpr{
+				pos: position{line: 652, col: 20, offset: 24120},
+				alternatives: []any{
+					&ruleRefExpr{
+						pos:  position{line: 652, col: 20, offset: 24120},
+						name: "EscapeSeq",
+					},
+					&actionExpr{
+						pos: position{line: 653, col: 19, offset: 24148},
+						run: (*parser).callonStringChar3,
+						expr: &seqExpr{
+							pos: position{line: 653, col: 19, offset: 24148},
+							exprs: []any{
+								&notExpr{
+									pos: position{line: 653, col: 19, offset: 24148},
+									expr: &choiceExpr{
+										pos: position{line: 653, col: 21, offset: 24150},
+										alternatives: []any{
+											&litMatcher{
+												pos:        position{line: 653, col: 21, offset: 24150},
+												val:        "\"",
+												ignoreCase: false,
+												want:       "\"\\\"\"",
+											},
+											&litMatcher{
+												pos:        position{line: 653, col: 28, offset: 24157},
+												val:        "\\",
+												ignoreCase: false,
+												want:       "\"\\\\\"",
+											},
+											&litMatcher{
+												pos:        position{line: 653, col: 35, offset: 24164},
+												val:        "${",
+												ignoreCase: false,
+												want:       "\"${\"",
+											},
+										},
+									},
+								},
+								&anyMatcher{
+									line: 653, col: 41, offset: 24170,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "EscapeSeq",
+			pos:  position{line: 655, col: 1, offset: 24204},
+			expr: &choiceExpr{
+				pos: position{line: 655, col: 20, offset: 24223},
+				alternatives: []any{
+					&actionExpr{
+						pos: position{line: 655, col: 20, offset: 24223},
+						run: (*parser).callonEscapeSeq2,
+						expr: &litMatcher{
+							pos:        position{line: 655, col: 20, offset: 24223},
+							val:        "\\n",
+							ignoreCase: false,
+							want:       "\"\\\\n\"",
+						},
+					},
+					&actionExpr{
+						pos: position{line: 656, col: 19, offset: 24268},
+						run: (*parser).callonEscapeSeq4,
+						expr: &litMatcher{
+							pos:        position{line: 656, col: 19, offset: 24268},
+							val:        "\\t",
+							ignoreCase: false,
+							want:       "\"\\\\t\"",
+						},
+					},
+					&actionExpr{
+						pos: position{line: 657, col: 19, offset: 24313},
+						run: (*parser).callonEscapeSeq6,
+						expr: &litMatcher{
+							pos:        position{line: 657, col: 19, offset: 24313},
+							val:        "\\r",
+							ignoreCase: false,
+							want:       "\"\\\\r\"",
+						},
+					},
+					&actionExpr{
+						pos: position{line: 658, col: 19, offset: 24358},
+						run: (*parser).callonEscapeSeq8,
+						expr: &litMatcher{
+							pos:        position{line: 658, col: 19, offset: 24358},
+							val:        "\\0",
+							ignoreCase: false,
+							want:       "\"\\\\0\"",
+						},
+					},
+					&actionExpr{
+						pos: position{line: 659, col: 19, offset: 24405},
+						run: (*parser).callonEscapeSeq10,
+						expr: &litMatcher{
+							pos:        position{line: 659, col: 19, offset: 24405},
+							val:        "\\\"",
+							ignoreCase: false,
+							want:       "\"\\\\\\\"\"",
+						},
+					},
+					&actionExpr{
+						pos: position{line: 660, col: 19, offset: 24451},
+						run: (*parser).callonEscapeSeq12,
+						expr: &litMatcher{
+							pos:        position{line: 660, col: 19, offset: 24451},
+							val:        "\\\\",
+							ignoreCase: false,
+							want:       "\"\\\\\\\\\"",
+						},
+					},
+					&actionExpr{
+						pos: position{line: 661, col: 19, offset: 24497},
+						run: (*parser).callonEscapeSeq14,
+						expr: &litMatcher{
+							pos:        position{line: 661, col: 19, offset: 24497},
+							val:        "\\$",
+							ignoreCase: false,
+							want:       "\"\\\\$\"",
+						},
+					},
+					&actionExpr{
+						pos: position{line: 662, col: 19, offset: 24541},
+						run: (*parser).callonEscapeSeq16,
+						expr: &litMatcher{
+							pos:        position{line: 662, col: 19, offset: 24541},
+							val:        "\\'",
+							ignoreCase: false,
+							want:       "\"\\\\'\"",
+						},
+					},
+					&actionExpr{
+						pos: position{line: 663, col: 19, offset: 24585},
+						run: (*parser).callonEscapeSeq18,
+						expr: &seqExpr{
+							pos: position{line: 663, col: 19, offset: 24585},
+							exprs: []any{
+								&litMatcher{
+									pos:        position{line: 663, col: 19, offset: 24585},
+									val:        "\\u{",
+									ignoreCase: false,
+									want:       "\"\\\\u{\"",
+								},
+								&oneOrMoreExpr{
+									pos: position{line: 663, col: 26, offset: 24592},
+									expr: &charClassMatcher{
+										pos:        position{line: 663, col: 26, offset: 24592},
+										val:        "[0-9a-fA-F]",
+										ranges:     []rune{'0', '9', 'a', 'f', 'A', 'F'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 663, col: 39, offset: 24605},
+									val:        "}",
+									ignoreCase: false,
+									want:       "\"}\"",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 671, col: 19, offset: 24922},
+						run: (*parser).callonEscapeSeq24,
+						expr: &seqExpr{
+							pos: position{line: 671, col: 19, offset: 24922},
+							exprs: []any{
+								&litMatcher{
+									pos:        position{line: 671, col: 19, offset: 24922},
+									val:        "\\",
+									ignoreCase: false,
+									want:       "\"\\\\\"",
+								},
+								&anyMatcher{
+									line: 671, col: 24, offset: 24927,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Type",
+			pos:  position{line: 675, col: 1, offset: 24998},
+			expr: &actionExpr{
+				pos: position{line: 675, col: 20, offset: 25017},
+				run: (*parser).callonType1,
+				expr: &seqExpr{
+					pos: position{line: 675, col: 20, offset: 25017},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 675, col: 20, offset: 25017},
+							label: "t",
+							expr: &choiceExpr{
+								pos: position{line: 675, col: 23, offset: 25020},
+								alternatives: []any{
+									&ruleRefExpr{
+										pos:  position{line: 675, col: 23, offset: 25020},
+										name: "MapType",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 675, col: 33, offset: 25030},
+										name: "ArrayType",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 675, col: 45, offset: 25042},
+										name: "SimpleType",
+									},
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 675, col: 57, offset: 25054},
+							label: "q",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 675, col: 59, offset: 25056},
+								expr: &seqExpr{
+									pos: position{line: 675, col: 60, offset: 25057},
+									exprs: []any{
+										&litMatcher{
+											pos:        position{line: 675, col: 60, offset: 25057},
+											val:        "?",
+											ignoreCase: false,
+											want:       "\"?\"",
+										},
+										&zeroOrOneExpr{
+											pos: position{line: 675, col: 64, offset: 25061},
+											expr: &ruleRefExpr{
+												pos:  position{line: 675, col: 64, offset: 25061},
+												name: "WS",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "SimpleType",
+			pos:  position{line: 682, col: 1, offset: 25151},
+			expr: &choiceExpr{
+				pos: position{line: 682, col: 20, offset: 25170},
+				alternatives: []any{
+					&actionExpr{
+						pos: position{line: 682, col: 20, offset: 25170},
+						run: (*parser).callonSimpleType2,
+						expr: &seqExpr{
+							pos: position{line: 682, col: 20, offset: 25170},
+							exprs: []any{
+								&labeledExpr{
+									pos:   position{line: 682, col: 20, offset: 25170},
+									label: "t",
+									expr: &choiceExpr{
+										pos: position{line: 682, col: 23, offset: 25173},
+										alternatives: []any{
+											&ruleRefExpr{
+												pos:  position{line: 682, col: 23, offset: 25173},
+												name: "VOID",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 682, col: 30, offset: 25180},
+												name: "INT",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 682, col: 36, offset: 25186},
+												name: "LONG",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 682, col: 43, offset: 25193},
+												name: "FLOAT",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 682, col: 51, offset: 25201},
+												name: "DOUBLE",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 682, col: 60, offset: 25210},
+												name: "CHAR",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 682, col: 67, offset: 25217},
+												name: "BYTES",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 682, col: 75, offset: 25225},
+												name: "STRING",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 682, col: 84, offset: 25234},
+												name: "BOOL",
+											},
+										},
+									},
+								},
+								&notExpr{
+									pos: position{line: 682, col: 90, offset: 25240},
+									expr: &ruleRefExpr{
+										pos:  position{line: 682, col: 91, offset: 25241},
+										name: "IdentChar",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 683, col: 19, offset: 25296},
+						run: (*parser).callonSimpleType17,
+						expr: &labeledExpr{
+							pos:   position{line: 683, col: 19, offset: 25296},
+							label: "i",
+							expr: &ruleRefExpr{
+								pos:  position{line: 683, col: 21, offset: 25298},
+								name: "Ident",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ArrayType",
+			pos:  position{line: 685, col: 1, offset: 25332},
+			expr: &actionExpr{
+				pos: position{line: 685, col: 20, offset: 25351},
+				run: (*parser).callonArrayType1,
+				expr: &seqExpr{
+					pos: position{line: 685, col: 20, offset: 25351},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 685, col: 20, offset: 25351},
+							val:        "[",
+							ignoreCase: false,
+							want:       "\"[\"",
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 685, col: 24, offset: 25355},
+							expr: &ruleRefExpr{
+								pos:  position{line: 685, col: 24, offset: 25355},
+								name: "WS",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 685, col: 28, offset: 25359},
+							label: "t",
+							expr: &ruleRefExpr{
+								pos:  position{line: 685, col: 30, offset: 25361},
+								name: "Type",
+							},
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 685, col: 35, offset: 25366},
+							expr: &ruleRefExpr{
+								pos:  position{line: 685, col: 35, offset: 25366},
+								name: "WS",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 685, col: 39, offset: 25370},
+							val:        "]",
+							ignoreCase: false,
+							want:       "\"]\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "MapType",
+			pos:  position{line: 687, col: 1, offset: 25414},
+			expr: &actionExpr{
+				pos: position{line: 687, col: 20, offset: 25433},
+				run: (*parser).callonMapType1,
+				expr: &seqExpr{
+					pos: position{line: 687, col: 20, offset: 25433},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 687, col: 20, offset: 25433},
+							val:        "[",
+							ignoreCase: false,
+							want:       "\"[\"",
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 687, col: 24, offset: 25437},
+							expr: &ruleRefExpr{
+								pos:  position{line: 687, col: 24, offset: 25437},
+								name: "WS",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 687, col: 28, offset: 25441},
+							label: "k",
 							expr: &ruleRefExpr{
-								pos:  position{line: 458, col: 30, offset: 15420},
+								pos:  position{line: 687, col: 30, offset: 25443},
 								name: "Type",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 458, col: 35, offset: 15425},
+							pos: position{line: 687, col: 35, offset: 25448},
 							expr: &ruleRefExpr{
-								pos:  position{line: 458, col: 35, offset: 15425},
+								pos:  position{line: 687, col: 35, offset: 25448},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 458, col: 39, offset: 15429},
+							pos:        position{line: 687, col: 39, offset: 25452},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 458, col: 43, offset: 15433},
+							pos: position{line: 687, col: 43, offset: 25456},
 							expr: &ruleRefExpr{
-								pos:  position{line: 458, col: 43, offset: 15433},
+								pos:  position{line: 687, col: 43, offset: 25456},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 458, col: 47, offset: 15437},
+							pos:   position{line: 687, col: 47, offset: 25460},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 458, col: 49, offset: 15439},
+								pos:  position{line: 687, col: 49, offset: 25462},
 								name: "Type",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 458, col: 54, offset: 15444},
+							pos: position{line: 687, col: 54, offset: 25467},
 							expr: &ruleRefExpr{
-								pos:  position{line: 458, col: 54, offset: 15444},
+								pos:  position{line: 687, col: 54, offset: 25467},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 458, col: 58, offset: 15448},
+							pos:        position{line: 687, col: 58, offset: 25471},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
@@ -3696,37 +5677,37 @@ var g = &grammar{
 		},
 		{
 			name: "QualifiedName",
-			pos:  position{line: 460, col: 1, offset: 15511},
+			pos:  position{line: 689, col: 1, offset: 25534},
 			expr: &actionExpr{
-				pos: position{line: 460, col: 20, offset: 15530},
+				pos: position{line: 689, col: 20, offset: 25553},
 				run: (*parser).callonQualifiedName1,
 				expr: &seqExpr{
-					pos: position{line: 460, col: 20, offset: 15530},
+					pos: position{line: 689, col: 20, offset: 25553},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 460, col: 20, offset: 15530},
+							pos:   position{line: 689, col: 20, offset: 25553},
 							label: "head",
 							expr: &ruleRefExpr{
-								pos:  position{line: 460, col: 25, offset: 15535},
+								pos:  position{line: 689, col: 25, offset: 25558},
 								name: "Ident",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 460, col: 31, offset: 15541},
+							pos:   position{line: 689, col: 31, offset: 25564},
 							label: "tail",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 460, col: 36, offset: 15546},
+								pos: position{line: 689, col: 36, offset: 25569},
 								expr: &seqExpr{
-									pos: position{line: 460, col: 37, offset: 15547},
+									pos: position{line: 689, col: 37, offset: 25570},
 									exprs: []any{
 										&litMatcher{
-											pos:        position{line: 460, col: 37, offset: 15547},
+											pos:        position{line: 689, col: 37, offset: 25570},
 											val:        ".",
 											ignoreCase: false,
 											want:       "\".\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 460, col: 41, offset: 15551},
+											pos:  position{line: 689, col: 41, offset: 25574},
 											name: "Ident",
 										},
 									},
@@ -3738,68 +5719,406 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "Ident",
-			pos:  position{line: 472, col: 1, offset: 15815},
-			expr: &actionExpr{
-				pos: position{line: 472, col: 20, offset: 15834},
-				run: (*parser).callonIdent1,
-				expr: &seqExpr{
-					pos: position{line: 472, col: 20, offset: 15834},
-					exprs: []any{
-						&labeledExpr{
-							pos:   position{line: 472, col: 20, offset: 15834},
-							label: "head",
-							expr: &charClassMatcher{
-								pos:        position{line: 472, col: 25, offset: 15839},
-								val:        "[A-Za-z_]",
-								chars:      []rune{'_'},
-								ranges:     []rune{'A', 'Z', 'a', 'z'},
-								ignoreCase: false,
-								inverted:   false,
-							},
+			name: "Ident",
+			pos:  position{line: 701, col: 1, offset: 25838},
+			expr: &actionExpr{
+				pos: position{line: 701, col: 20, offset: 25857},
+				run: (*parser).callonIdent1,
+				expr: &seqExpr{
+					pos: position{line: 701, col: 20, offset: 25857},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 701, col: 20, offset: 25857},
+							label: "head",
+							expr: &charClassMatcher{
+								pos:        position{line: 701, col: 25, offset: 25862},
+								val:        "[A-Za-z_]",
+								chars:      []rune{'_'},
+								ranges:     []rune{'A', 'Z', 'a', 'z'},
+								ignoreCase: false,
+								inverted:   false,
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 701, col: 35, offset: 25872},
+							label: "tail",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 701, col: 40, offset: 25877},
+								expr: &charClassMatcher{
+									pos:        position{line: 701, col: 40, offset: 25877},
+									val:        "[A-Za-z0-9_]",
+									chars:      []rune{'_'},
+									ranges:     []rune{'A', 'Z', 'a', 'z', '0', '9'},
+									ignoreCase: false,
+									inverted:   false,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AddOp",
+			pos:  position{line: 732, col: 1, offset: 26679},
+			expr: &actionExpr{
+				pos: position{line: 732, col: 20, offset: 26698},
+				run: (*parser).callonAddOp1,
+				expr: &labeledExpr{
+					pos:   position{line: 732, col: 20, offset: 26698},
+					label: "o",
+					expr: &choiceExpr{
+						pos: position{line: 732, col: 23, offset: 26701},
+						alternatives: []any{
+							&litMatcher{
+								pos:        position{line: 732, col: 23, offset: 26701},
+								val:        "+",
+								ignoreCase: false,
+								want:       "\"+\"",
+							},
+							&litMatcher{
+								pos:        position{line: 732, col: 29, offset: 26707},
+								val:        "-",
+								ignoreCase: false,
+								want:       "\"-\"",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "MulOp",
+			pos:  position{line: 733, col: 1, offset: 26748},
+			expr: &actionExpr{
+				pos: position{line: 733, col: 20, offset: 26767},
+				run: (*parser).callonMulOp1,
+				expr: &labeledExpr{
+					pos:   position{line: 733, col: 20, offset: 26767},
+					label: "o",
+					expr: &choiceExpr{
+						pos: position{line: 733, col: 23, offset: 26770},
+						alternatives: []any{
+							&litMatcher{
+								pos:        position{line: 733, col: 23, offset: 26770},
+								val:        "*",
+								ignoreCase: false,
+								want:       "\"*\"",
+							},
+							&litMatcher{
+								pos:        position{line: 733, col: 29, offset: 26776},
+								val:        "/",
+								ignoreCase: false,
+								want:       "\"/\"",
+							},
+							&litMatcher{
+								pos:        position{line: 733, col: 35, offset: 26782},
+								val:        "%",
+								ignoreCase: false,
+								want:       "\"%\"",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "EqualityOp",
+			pos:  position{line: 734, col: 1, offset: 26823},
+			expr: &actionExpr{
+				pos: position{line: 734, col: 20, offset: 26842},
+				run: (*parser).callonEqualityOp1,
+				expr: &labeledExpr{
+					pos:   position{line: 734, col: 20, offset: 26842},
+					label: "o",
+					expr: &choiceExpr{
+						pos: position{line: 734, col: 23, offset: 26845},
+						alternatives: []any{
+							&litMatcher{
+								pos:        position{line: 734, col: 23, offset: 26845},
+								val:        "==",
+								ignoreCase: false,
+								want:       "\"==\"",
+							},
+							&litMatcher{
+								pos:        position{line: 734, col: 30, offset: 26852},
+								val:        "!=",
+								ignoreCase: false,
+								want:       "\"!=\"",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "CompareOp",
+			pos:  position{line: 735, col: 1, offset: 26894},
+			expr: &actionExpr{
+				pos: position{line: 735, col: 20, offset: 26913},
+				run: (*parser).callonCompareOp1,
+				expr: &labeledExpr{
+					pos:   position{line: 735, col: 20, offset: 26913},
+					label: "o",
+					expr: &choiceExpr{
+						pos: position{line: 735, col: 23, offset: 26916},
+						alternatives: []any{
+							&litMatcher{
+								pos:        position{line: 735, col: 23, offset: 26916},
+								val:        "<=",
+								ignoreCase: false,
+								want:       "\"<=\"",
+							},
+							&litMatcher{
+								pos:        position{line: 735, col: 30, offset: 26923},
+								val:        "<",
+								ignoreCase: false,
+								want:       "\"<\"",
+							},
+							&litMatcher{
+								pos:        position{line: 735, col: 36, offset: 26929},
+								val:        ">=",
+								ignoreCase: false,
+								want:       "\">=\"",
+							},
+							&litMatcher{
+								pos:        position{line: 735, col: 43, offset: 26936},
+								val:        ">",
+								ignoreCase: false,
+								want:       "\">\"",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "IterMethod",
+			pos:  position{line: 736, col: 1, offset: 26977},
+			expr: &actionExpr{
+				pos: position{line: 736, col: 20, offset: 26996},
+				run: (*parser).callonIterMethod1,
+				expr: &seqExpr{
+					pos: position{line: 736, col: 20, offset: 26996},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 736, col: 20, offset: 26996},
+							label: "m",
+							expr: &choiceExpr{
+								pos: position{line: 736, col: 23, offset: 26999},
+								alternatives: []any{
+									&litMatcher{
+										pos:        position{line: 736, col: 23, offset: 26999},
+										val:        "each",
+										ignoreCase: false,
+										want:       "\"each\"",
+									},
+									&litMatcher{
+										pos:        position{line: 736, col: 32, offset: 27008},
+										val:        "withIndex",
+										ignoreCase: false,
+										want:       "\"withIndex\"",
+									},
+								},
+							},
+						},
+						&notExpr{
+							pos: position{line: 736, col: 45, offset: 27021},
+							expr: &ruleRefExpr{
+								pos:  position{line: 736, col: 46, offset: 27022},
+								name: "IdentChar",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AndOp",
+			pos:  position{line: 737, col: 1, offset: 27067},
+			expr: &actionExpr{
+				pos: position{line: 737, col: 20, offset: 27086},
+				run: (*parser).callonAndOp1,
+				expr: &litMatcher{
+					pos:        position{line: 737, col: 20, offset: 27086},
+					val:        "&&",
+					ignoreCase: false,
+					want:       "\"&&\"",
+				},
+			},
+		},
+		{
+			name: "OrOp",
+			pos:  position{line: 738, col: 1, offset: 27112},
+			expr: &actionExpr{
+				pos: position{line: 738, col: 20, offset: 27131},
+				run: (*parser).callonOrOp1,
+				expr: &litMatcher{
+					pos:        position{line: 738, col: 20, offset: 27131},
+					val:        "||",
+					ignoreCase: false,
+					want:       "\"||\"",
+				},
+			},
+		},
+		{
+			name: "UnaryOp",
+			pos:  position{line: 739, col: 1, offset: 27157},
+			expr: &choiceExpr{
+				pos: position{line: 739, col: 20, offset: 27176},
+				alternatives: []any{
+					&actionExpr{
+						pos: position{line: 739, col: 20, offset: 27176},
+						run: (*parser).callonUnaryOp2,
+						expr: &seqExpr{
+							pos: position{line: 739, col: 20, offset: 27176},
+							exprs: []any{
+								&litMatcher{
+									pos:        position{line: 739, col: 20, offset: 27176},
+									val:        "!",
+									ignoreCase: false,
+									want:       "\"!\"",
+								},
+								&notExpr{
+									pos: position{line: 739, col: 24, offset: 27180},
+									expr: &litMatcher{
+										pos:        position{line: 739, col: 25, offset: 27181},
+										val:        "=",
+										ignoreCase: false,
+										want:       "\"=\"",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 739, col: 51, offset: 27207},
+						run: (*parser).callonUnaryOp7,
+						expr: &litMatcher{
+							pos:        position{line: 739, col: 51, offset: 27207},
+							val:        "-",
+							ignoreCase: false,
+							want:       "\"-\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Terminator",
+			pos:  position{line: 741, col: 1, offset: 27232},
+			expr: &seqExpr{
+				pos: position{line: 741, col: 20, offset: 27251},
+				exprs: []any{
+					&zeroOrMoreExpr{
+						pos: position{line: 741, col: 20, offset: 27251},
+						expr: &ruleRefExpr{
+							pos:  position{line: 741, col: 20, offset: 27251},
+							name: "WS",
+						},
+					},
+					&zeroOrOneExpr{
+						pos: position{line: 741, col: 24, offset: 27255},
+						expr: &seqExpr{
+							pos: position{line: 741, col: 25, offset: 27256},
+							exprs: []any{
+								&litMatcher{
+									pos:        position{line: 741, col: 25, offset: 27256},
+									val:        ";",
+									ignoreCase: false,
+									want:       "\";\"",
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 741, col: 29, offset: 27260},
+									expr: &ruleRefExpr{
+										pos:  position{line: 741, col: 29, offset: 27260},
+										name: "WS",
+									},
+								},
+							},
+						},
+					},
+					&ruleRefExpr{
+						pos:  position{line: 741, col: 35, offset: 27266},
+						name: "Skip",
+					},
+				},
+			},
+		},
+		{
+			name: "Skip",
+			pos:  position{line: 743, col: 1, offset: 27272},
+			expr: &zeroOrMoreExpr{
+				pos: position{line: 743, col: 20, offset: 27291},
+				expr: &choiceExpr{
+					pos: position{line: 743, col: 21, offset: 27292},
+					alternatives: []any{
+						&ruleRefExpr{
+							pos:  position{line: 743, col: 21, offset: 27292},
+							name: "WS",
 						},
-						&labeledExpr{
-							pos:   position{line: 472, col: 35, offset: 15849},
-							label: "tail",
-							expr: &zeroOrMoreExpr{
-								pos: position{line: 472, col: 40, offset: 15854},
-								expr: &charClassMatcher{
-									pos:        position{line: 472, col: 40, offset: 15854},
-									val:        "[A-Za-z0-9_]",
-									chars:      []rune{'_'},
-									ranges:     []rune{'A', 'Z', 'a', 'z', '0', '9'},
-									ignoreCase: false,
-									inverted:   false,
-								},
-							},
+						&ruleRefExpr{
+							pos:  position{line: 743, col: 26, offset: 27297},
+							name: "NL",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 743, col: 31, offset: 27302},
+							name: "Comment",
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "AddOp",
-			pos:  position{line: 503, col: 1, offset: 16656},
-			expr: &actionExpr{
-				pos: position{line: 503, col: 20, offset: 16675},
-				run: (*parser).callonAddOp1,
-				expr: &labeledExpr{
-					pos:   position{line: 503, col: 20, offset: 16675},
-					label: "o",
-					expr: &choiceExpr{
-						pos: position{line: 503, col: 23, offset: 16678},
-						alternatives: []any{
-							&litMatcher{
-								pos:        position{line: 503, col: 23, offset: 16678},
-								val:        "+",
-								ignoreCase: false,
-								want:       "\"+\"",
+			name: "WS",
+			pos:  position{line: 744, col: 1, offset: 27312},
+			expr: &oneOrMoreExpr{
+				pos: position{line: 744, col: 20, offset: 27331},
+				expr: &charClassMatcher{
+					pos:        position{line: 744, col: 20, offset: 27331},
+					val:        "[ \\t\\r]",
+					chars:      []rune{' ', '\t', '\r'},
+					ignoreCase: false,
+					inverted:   false,
+				},
+			},
+		},
+		{
+			name: "NL",
+			pos:  position{line: 745, col: 1, offset: 27340},
+			expr: &oneOrMoreExpr{
+				pos: position{line: 745, col: 20, offset: 27359},
+				expr: &litMatcher{
+					pos:        position{line: 745, col: 20, offset: 27359},
+					val:        "\n",
+					ignoreCase: false,
+					want:       "\"\\n\"",
+				},
+			},
+		},
+		{
+			name: "Comment",
+			pos:  position{line: 747, col: 1, offset: 27366},
+			expr: &choiceExpr{
+				pos: position{line: 747, col: 20, offset: 27385},
+				alternatives: []any{
+					&ruleRefExpr{
+						pos:  position{line: 747, col: 20, offset: 27385},
+						name: "BlockComment",
+					},
+					&seqExpr{
+						pos: position{line: 747, col: 35, offset: 27400},
+						exprs: []any{
+							&notExpr{
+								pos: position{line: 747, col: 35, offset: 27400},
+								expr: &ruleRefExpr{
+									pos:  position{line: 747, col: 36, offset: 27401},
+									name: "AttachedDoc",
+								},
 							},
-							&litMatcher{
-								pos:        position{line: 503, col: 29, offset: 16684},
-								val:        "-",
-								ignoreCase: false,
-								want:       "\"-\"",
+							&ruleRefExpr{
+								pos:  position{line: 747, col: 48, offset: 27413},
+								name: "LineComment",
 							},
 						},
 					},
@@ -3807,28 +6126,45 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "MulOp",
-			pos:  position{line: 504, col: 1, offset: 16725},
-			expr: &actionExpr{
-				pos: position{line: 504, col: 20, offset: 16744},
-				run: (*parser).callonMulOp1,
-				expr: &labeledExpr{
-					pos:   position{line: 504, col: 20, offset: 16744},
-					label: "o",
-					expr: &choiceExpr{
-						pos: position{line: 504, col: 23, offset: 16747},
+			name: "LineComment",
+			pos:  position{line: 748, col: 1, offset: 27425},
+			expr: &seqExpr{
+				pos: position{line: 748, col: 20, offset: 27444},
+				exprs: []any{
+					&litMatcher{
+						pos:        position{line: 748, col: 20, offset: 27444},
+						val:        "//",
+						ignoreCase: false,
+						want:       "\"//\"",
+					},
+					&zeroOrMoreExpr{
+						pos: position{line: 748, col: 25, offset: 27449},
+						expr: &seqExpr{
+							pos: position{line: 748, col: 26, offset: 27450},
+							exprs: []any{
+								&notExpr{
+									pos: position{line: 748, col: 26, offset: 27450},
+									expr: &ruleRefExpr{
+										pos:  position{line: 748, col: 27, offset: 27451},
+										name: "NL",
+									},
+								},
+								&anyMatcher{
+									line: 748, col: 30, offset: 27454,
+								},
+							},
+						},
+					},
+					&choiceExpr{
+						pos: position{line: 748, col: 35, offset: 27459},
 						alternatives: []any{
-							&litMatcher{
-								pos:        position{line: 504, col: 23, offset: 16747},
-								val:        "*",
-								ignoreCase: false,
-								want:       "\"*\"",
+							&ruleRefExpr{
+								pos:  position{line: 748, col: 35, offset: 27459},
+								name: "NL",
 							},
-							&litMatcher{
-								pos:        position{line: 504, col: 29, offset: 16753},
-								val:        "/",
-								ignoreCase: false,
-								want:       "\"/\"",
+							&ruleRefExpr{
+								pos:  position{line: 748, col: 40, offset: 27464},
+								name: "EOF",
 							},
 						},
 					},
@@ -3836,158 +6172,181 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "EqualityOp",
-			pos:  position{line: 505, col: 1, offset: 16794},
-			expr: &actionExpr{
-				pos: position{line: 505, col: 20, offset: 16813},
-				run: (*parser).callonEqualityOp1,
-				expr: &labeledExpr{
-					pos:   position{line: 505, col: 20, offset: 16813},
-					label: "o",
-					expr: &choiceExpr{
-						pos: position{line: 505, col: 23, offset: 16816},
-						alternatives: []any{
-							&litMatcher{
-								pos:        position{line: 505, col: 23, offset: 16816},
-								val:        "==",
-								ignoreCase: false,
-								want:       "\"==\"",
-							},
-							&litMatcher{
-								pos:        position{line: 505, col: 30, offset: 16823},
-								val:        "!=",
-								ignoreCase: false,
-								want:       "\"!=\"",
+			name: "BlockComment",
+			pos:  position{line: 749, col: 1, offset: 27469},
+			expr: &seqExpr{
+				pos: position{line: 749, col: 20, offset: 27488},
+				exprs: []any{
+					&litMatcher{
+						pos:        position{line: 749, col: 20, offset: 27488},
+						val:        "/*",
+						ignoreCase: false,
+						want:       "\"/*\"",
+					},
+					&zeroOrMoreExpr{
+						pos: position{line: 749, col: 25, offset: 27493},
+						expr: &seqExpr{
+							pos: position{line: 749, col: 26, offset: 27494},
+							exprs: []any{
+								&notExpr{
+									pos: position{line: 749, col: 26, offset: 27494},
+									expr: &litMatcher{
+										pos:        position{line: 749, col: 27, offset: 27495},
+										val:        "*/",
+										ignoreCase: false,
+										want:       "\"*/\"",
+									},
+								},
+								&anyMatcher{
+									line: 749, col: 32, offset: 27500,
+								},
 							},
 						},
 					},
+					&litMatcher{
+						pos:        position{line: 749, col: 36, offset: 27504},
+						val:        "*/",
+						ignoreCase: false,
+						want:       "\"*/\"",
+					},
 				},
 			},
 		},
 		{
-			name: "CompareOp",
-			pos:  position{line: 506, col: 1, offset: 16865},
-			expr: &actionExpr{
-				pos: position{line: 506, col: 20, offset: 16884},
-				run: (*parser).callonCompareOp1,
-				expr: &labeledExpr{
-					pos:   position{line: 506, col: 20, offset: 16884},
-					label: "o",
-					expr: &choiceExpr{
-						pos: position{line: 506, col: 23, offset: 16887},
-						alternatives: []any{
-							&litMatcher{
-								pos:        position{line: 506, col: 23, offset: 16887},
-								val:        "<=",
-								ignoreCase: false,
-								want:       "\"<=\"",
-							},
-							&litMatcher{
-								pos:        position{line: 506, col: 30, offset: 16894},
-								val:        "<",
-								ignoreCase: false,
-								want:       "\"<\"",
-							},
-							&litMatcher{
-								pos:        position{line: 506, col: 36, offset: 16900},
-								val:        ">=",
-								ignoreCase: false,
-								want:       "\">=\"",
-							},
-							&litMatcher{
-								pos:        position{line: 506, col: 43, offset: 16907},
-								val:        ">",
-								ignoreCase: false,
-								want:       "\">\"",
-							},
-						},
+			name: "AttachedDoc",
+			pos:  position{line: 753, col: 1, offset: 27654},
+			expr: &seqExpr{
+				pos: position{line: 753, col: 20, offset: 27673},
+				exprs: []any{
+					&ruleRefExpr{
+						pos:  position{line: 753, col: 20, offset: 27673},
+						name: "DocComment",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 753, col: 31, offset: 27684},
+						name: "DocTarget",
 					},
 				},
 			},
 		},
 		{
-			name: "Terminator",
-			pos:  position{line: 508, col: 1, offset: 16949},
+			name: "DocTarget",
+			pos:  position{line: 754, col: 1, offset: 27694},
 			expr: &choiceExpr{
-				pos: position{line: 508, col: 20, offset: 16968},
+				pos: position{line: 754, col: 20, offset: 27713},
 				alternatives: []any{
 					&seqExpr{
-						pos: position{line: 508, col: 20, offset: 16968},
+						pos: position{line: 754, col: 20, offset: 27713},
 						exprs: []any{
-							&zeroOrMoreExpr{
-								pos: position{line: 508, col: 20, offset: 16968},
-								expr: &ruleRefExpr{
-									pos:  position{line: 508, col: 20, offset: 16968},
-									name: "WS",
-								},
-							},
 							&zeroOrOneExpr{
-								pos: position{line: 508, col: 24, offset: 16972},
+								pos: position{line: 754, col: 20, offset: 27713},
 								expr: &seqExpr{
-									pos: position{line: 508, col: 25, offset: 16973},
+									pos: position{line: 754, col: 21, offset: 27714},
 									exprs: []any{
-										&litMatcher{
-											pos:        position{line: 508, col: 25, offset: 16973},
-											val:        ";",
-											ignoreCase: false,
-											want:       "\";\"",
+										&ruleRefExpr{
+											pos:  position{line: 754, col: 21, offset: 27714},
+											name: "EXTERN",
 										},
-										&zeroOrMoreExpr{
-											pos: position{line: 508, col: 29, offset: 16977},
-											expr: &ruleRefExpr{
-												pos:  position{line: 508, col: 29, offset: 16977},
-												name: "WS",
-											},
+										&ruleRefExpr{
+											pos:  position{line: 754, col: 28, offset: 27721},
+											name: "WS",
 										},
 									},
 								},
 							},
-							&zeroOrMoreExpr{
-								pos: position{line: 508, col: 35, offset: 16983},
-								expr: &seqExpr{
-									pos: position{line: 508, col: 36, offset: 16984},
-									exprs: []any{
-										&ruleRefExpr{
-											pos:  position{line: 508, col: 36, offset: 16984},
-											name: "NL",
-										},
-										&zeroOrMoreExpr{
-											pos: position{line: 508, col: 39, offset: 16987},
-											expr: &ruleRefExpr{
-												pos:  position{line: 508, col: 39, offset: 16987},
-												name: "WS",
-											},
-										},
-									},
+							&ruleRefExpr{
+								pos:  position{line: 754, col: 33, offset: 27726},
+								name: "FUN",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 754, col: 37, offset: 27730},
+								name: "WS",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 754, col: 40, offset: 27733},
+								name: "Type",
+							},
+							&zeroOrOneExpr{
+								pos: position{line: 754, col: 45, offset: 27738},
+								expr: &ruleRefExpr{
+									pos:  position{line: 754, col: 45, offset: 27738},
+									name: "WS",
 								},
 							},
+							&ruleRefExpr{
+								pos:  position{line: 754, col: 49, offset: 27742},
+								name: "Ident",
+							},
+						},
+					},
+					&seqExpr{
+						pos: position{line: 754, col: 57, offset: 27750},
+						exprs: []any{
+							&ruleRefExpr{
+								pos:  position{line: 754, col: 57, offset: 27750},
+								name: "RECORD",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 754, col: 64, offset: 27757},
+								name: "WS",
+							},
+						},
+					},
+					&seqExpr{
+						pos: position{line: 754, col: 69, offset: 27762},
+						exprs: []any{
+							&ruleRefExpr{
+								pos:  position{line: 754, col: 69, offset: 27762},
+								name: "TYPE",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 754, col: 74, offset: 27767},
+								name: "WS",
+							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 508, col: 47, offset: 16995},
+						pos: position{line: 754, col: 79, offset: 27772},
 						exprs: []any{
+							&ruleRefExpr{
+								pos:  position{line: 754, col: 79, offset: 27772},
+								name: "FieldDecl",
+							},
 							&zeroOrMoreExpr{
-								pos: position{line: 508, col: 47, offset: 16995},
+								pos: position{line: 754, col: 89, offset: 27782},
 								expr: &ruleRefExpr{
-									pos:  position{line: 508, col: 47, offset: 16995},
+									pos:  position{line: 754, col: 89, offset: 27782},
 									name: "WS",
 								},
 							},
-							&andExpr{
-								pos: position{line: 508, col: 51, offset: 16999},
-								expr: &choiceExpr{
-									pos: position{line: 508, col: 54, offset: 17002},
-									alternatives: []any{
-										&litMatcher{
-											pos:        position{line: 508, col: 54, offset: 17002},
-											val:        "}",
-											ignoreCase: false,
-											want:       "\"}\"",
-										},
-										&ruleRefExpr{
-											pos:  position{line: 508, col: 60, offset: 17008},
-											name: "EOF",
-										},
+							&choiceExpr{
+								pos: position{line: 754, col: 94, offset: 27787},
+								alternatives: []any{
+									&ruleRefExpr{
+										pos:  position{line: 754, col: 94, offset: 27787},
+										name: "NL",
+									},
+									&litMatcher{
+										pos:        position{line: 754, col: 99, offset: 27792},
+										val:        "}",
+										ignoreCase: false,
+										want:       "\"}\"",
+									},
+									&litMatcher{
+										pos:        position{line: 754, col: 105, offset: 27798},
+										val:        ";",
+										ignoreCase: false,
+										want:       "\";\"",
+									},
+									&litMatcher{
+										pos:        position{line: 754, col: 111, offset: 27804},
+										val:        "//",
+										ignoreCase: false,
+										want:       "\"//\"",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 754, col: 118, offset: 27811},
+										name: "EOF",
 									},
 								},
 							},
@@ -3997,101 +6356,95 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "Skip",
-			pos:  position{line: 510, col: 1, offset: 17015},
-			expr: &zeroOrMoreExpr{
-				pos: position{line: 510, col: 20, offset: 17034},
-				expr: &choiceExpr{
-					pos: position{line: 510, col: 21, offset: 17035},
-					alternatives: []any{
-						&ruleRefExpr{
-							pos:  position{line: 510, col: 21, offset: 17035},
-							name: "WS",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 510, col: 26, offset: 17040},
-							name: "NL",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 510, col: 31, offset: 17045},
-							name: "Comment",
+			name: "DocComment",
+			pos:  position{line: 756, col: 1, offset: 27817},
+			expr: &actionExpr{
+				pos: position{line: 756, col: 20, offset: 27836},
+				run: (*parser).callonDocComment1,
+				expr: &labeledExpr{
+					pos:   position{line: 756, col: 20, offset: 27836},
+					label: "lines",
+					expr: &oneOrMoreExpr{
+						pos: position{line: 756, col: 26, offset: 27842},
+						expr: &ruleRefExpr{
+							pos:  position{line: 756, col: 26, offset: 27842},
+							name: "DocLine",
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "WS",
-			pos:  position{line: 511, col: 1, offset: 17055},
-			expr: &oneOrMoreExpr{
-				pos: position{line: 511, col: 20, offset: 17074},
-				expr: &charClassMatcher{
-					pos:        position{line: 511, col: 20, offset: 17074},
-					val:        "[ \\t\\r]",
-					chars:      []rune{' ', '\t', '\r'},
-					ignoreCase: false,
-					inverted:   false,
-				},
-			},
-		},
-		{
-			name: "NL",
-			pos:  position{line: 512, col: 1, offset: 17083},
-			expr: &oneOrMoreExpr{
-				pos: position{line: 512, col: 20, offset: 17102},
-				expr: &litMatcher{
-					pos:        position{line: 512, col: 20, offset: 17102},
-					val:        "\n",
-					ignoreCase: false,
-					want:       "\"\\n\"",
-				},
-			},
-		},
-		{
-			name: "Comment",
-			pos:  position{line: 514, col: 1, offset: 17109},
-			expr: &seqExpr{
-				pos: position{line: 514, col: 20, offset: 17128},
-				exprs: []any{
-					&litMatcher{
-						pos:        position{line: 514, col: 20, offset: 17128},
-						val:        "//",
-						ignoreCase: false,
-						want:       "\"//\"",
-					},
-					&zeroOrMoreExpr{
-						pos: position{line: 514, col: 25, offset: 17133},
-						expr: &seqExpr{
-							pos: position{line: 514, col: 26, offset: 17134},
-							exprs: []any{
-								&notExpr{
-									pos: position{line: 514, col: 26, offset: 17134},
-									expr: &ruleRefExpr{
-										pos:  position{line: 514, col: 27, offset: 17135},
-										name: "NL",
+			name: "DocLine",
+			pos:  position{line: 765, col: 1, offset: 28036},
+			expr: &actionExpr{
+				pos: position{line: 765, col: 20, offset: 28055},
+				run: (*parser).callonDocLine1,
+				expr: &seqExpr{
+					pos: position{line: 765, col: 20, offset: 28055},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 765, col: 20, offset: 28055},
+							val:        "///",
+							ignoreCase: false,
+							want:       "\"///\"",
+						},
+						&labeledExpr{
+							pos:   position{line: 765, col: 26, offset: 28061},
+							label: "text",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 765, col: 31, offset: 28066},
+								expr: &seqExpr{
+									pos: position{line: 765, col: 32, offset: 28067},
+									exprs: []any{
+										&notExpr{
+											pos: position{line: 765, col: 32, offset: 28067},
+											expr: &ruleRefExpr{
+												pos:  position{line: 765, col: 33, offset: 28068},
+												name: "NL",
+											},
+										},
+										&anyMatcher{
+											line: 765, col: 36, offset: 28071,
+										},
 									},
 								},
-								&anyMatcher{
-									line: 514, col: 30, offset: 17138,
+							},
+						},
+						&choiceExpr{
+							pos: position{line: 765, col: 41, offset: 28076},
+							alternatives: []any{
+								&litMatcher{
+									pos:        position{line: 765, col: 41, offset: 28076},
+									val:        "\n",
+									ignoreCase: false,
+									want:       "\"\\n\"",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 765, col: 48, offset: 28083},
+									name: "EOF",
 								},
 							},
 						},
-					},
-					&ruleRefExpr{
-						pos:  position{line: 514, col: 34, offset: 17142},
-						name: "NL",
+						&zeroOrMoreExpr{
+							pos: position{line: 765, col: 53, offset: 28088},
+							expr: &ruleRefExpr{
+								pos:  position{line: 765, col: 53, offset: 28088},
+								name: "WS",
+							},
+						},
 					},
 				},
 			},
 		},
 		{
 			name: "VOID",
-			pos:  position{line: 516, col: 1, offset: 17146},
+			pos:  position{line: 773, col: 1, offset: 28286},
 			expr: &actionExpr{
-				pos: position{line: 516, col: 20, offset: 17165},
+				pos: position{line: 773, col: 20, offset: 28305},
 				run: (*parser).callonVOID1,
 				expr: &litMatcher{
-					pos:        position{line: 516, col: 20, offset: 17165},
+					pos:        position{line: 773, col: 20, offset: 28305},
 					val:        "void",
 					ignoreCase: false,
 					want:       "\"void\"",
@@ -4100,12 +6453,12 @@ var g = &grammar{
 		},
 		{
 			name: "INT",
-			pos:  position{line: 517, col: 1, offset: 17197},
+			pos:  position{line: 774, col: 1, offset: 28337},
 			expr: &actionExpr{
-				pos: position{line: 517, col: 20, offset: 17216},
+				pos: position{line: 774, col: 20, offset: 28356},
 				run: (*parser).callonINT1,
 				expr: &litMatcher{
-					pos:        position{line: 517, col: 20, offset: 17216},
+					pos:        position{line: 774, col: 20, offset: 28356},
 					val:        "int",
 					ignoreCase: false,
 					want:       "\"int\"",
@@ -4114,12 +6467,12 @@ var g = &grammar{
 		},
 		{
 			name: "LONG",
-			pos:  position{line: 518, col: 1, offset: 17247},
+			pos:  position{line: 775, col: 1, offset: 28387},
 			expr: &actionExpr{
-				pos: position{line: 518, col: 20, offset: 17266},
+				pos: position{line: 775, col: 20, offset: 28406},
 				run: (*parser).callonLONG1,
 				expr: &litMatcher{
-					pos:        position{line: 518, col: 20, offset: 17266},
+					pos:        position{line: 775, col: 20, offset: 28406},
 					val:        "long",
 					ignoreCase: false,
 					want:       "\"long\"",
@@ -4128,12 +6481,12 @@ var g = &grammar{
 		},
 		{
 			name: "FLOAT",
-			pos:  position{line: 519, col: 1, offset: 17298},
+			pos:  position{line: 776, col: 1, offset: 28438},
 			expr: &actionExpr{
-				pos: position{line: 519, col: 20, offset: 17317},
+				pos: position{line: 776, col: 20, offset: 28457},
 				run: (*parser).callonFLOAT1,
 				expr: &litMatcher{
-					pos:        position{line: 519, col: 20, offset: 17317},
+					pos:        position{line: 776, col: 20, offset: 28457},
 					val:        "float",
 					ignoreCase: false,
 					want:       "\"float\"",
@@ -4142,12 +6495,12 @@ var g = &grammar{
 		},
 		{
 			name: "DOUBLE",
-			pos:  position{line: 520, col: 1, offset: 17350},
+			pos:  position{line: 777, col: 1, offset: 28490},
 			expr: &actionExpr{
-				pos: position{line: 520, col: 20, offset: 17369},
+				pos: position{line: 777, col: 20, offset: 28509},
 				run: (*parser).callonDOUBLE1,
 				expr: &litMatcher{
-					pos:        position{line: 520, col: 20, offset: 17369},
+					pos:        position{line: 777, col: 20, offset: 28509},
 					val:        "double",
 					ignoreCase: false,
 					want:       "\"double\"",
@@ -4156,12 +6509,12 @@ var g = &grammar{
 		},
 		{
 			name: "CHAR",
-			pos:  position{line: 521, col: 1, offset: 17403},
+			pos:  position{line: 778, col: 1, offset: 28543},
 			expr: &actionExpr{
-				pos: position{line: 521, col: 20, offset: 17422},
+				pos: position{line: 778, col: 20, offset: 28562},
 				run: (*parser).callonCHAR1,
 				expr: &litMatcher{
-					pos:        position{line: 521, col: 20, offset: 17422},
+					pos:        position{line: 778, col: 20, offset: 28562},
 					val:        "char",
 					ignoreCase: false,
 					want:       "\"char\"",
@@ -4170,12 +6523,12 @@ var g = &grammar{
 		},
 		{
 			name: "BYTES",
-			pos:  position{line: 522, col: 1, offset: 17454},
+			pos:  position{line: 779, col: 1, offset: 28594},
 			expr: &actionExpr{
-				pos: position{line: 522, col: 20, offset: 17473},
+				pos: position{line: 779, col: 20, offset: 28613},
 				run: (*parser).callonBYTES1,
 				expr: &litMatcher{
-					pos:        position{line: 522, col: 20, offset: 17473},
+					pos:        position{line: 779, col: 20, offset: 28613},
 					val:        "bytes",
 					ignoreCase: false,
 					want:       "\"bytes\"",
@@ -4184,12 +6537,12 @@ var g = &grammar{
 		},
 		{
 			name: "STRING",
-			pos:  position{line: 523, col: 1, offset: 17506},
+			pos:  position{line: 780, col: 1, offset: 28646},
 			expr: &actionExpr{
-				pos: position{line: 523, col: 20, offset: 17525},
+				pos: position{line: 780, col: 20, offset: 28665},
 				run: (*parser).callonSTRING1,
 				expr: &litMatcher{
-					pos:        position{line: 523, col: 20, offset: 17525},
+					pos:        position{line: 780, col: 20, offset: 28665},
 					val:        "string",
 					ignoreCase: false,
 					want:       "\"string\"",
@@ -4198,12 +6551,12 @@ var g = &grammar{
 		},
 		{
 			name: "BOOL",
-			pos:  position{line: 524, col: 1, offset: 17559},
+			pos:  position{line: 781, col: 1, offset: 28699},
 			expr: &actionExpr{
-				pos: position{line: 524, col: 20, offset: 17578},
+				pos: position{line: 781, col: 20, offset: 28718},
 				run: (*parser).callonBOOL1,
 				expr: &litMatcher{
-					pos:        position{line: 524, col: 20, offset: 17578},
+					pos:        position{line: 781, col: 20, offset: 28718},
 					val:        "bool",
 					ignoreCase: false,
 					want:       "\"bool\"",
@@ -4212,9 +6565,9 @@ var g = &grammar{
 		},
 		{
 			name: "TRUE",
-			pos:  position{line: 526, col: 1, offset: 17611},
+			pos:  position{line: 783, col: 1, offset: 28751},
 			expr: &litMatcher{
-				pos:        position{line: 526, col: 20, offset: 17630},
+				pos:        position{line: 783, col: 20, offset: 28770},
 				val:        "true",
 				ignoreCase: false,
 				want:       "\"true\"",
@@ -4222,9 +6575,9 @@ var g = &grammar{
 		},
 		{
 			name: "FALSE",
-			pos:  position{line: 527, col: 1, offset: 17637},
+			pos:  position{line: 784, col: 1, offset: 28777},
 			expr: &litMatcher{
-				pos:        position{line: 527, col: 20, offset: 17656},
+				pos:        position{line: 784, col: 20, offset: 28796},
 				val:        "false",
 				ignoreCase: false,
 				want:       "\"false\"",
@@ -4232,9 +6585,9 @@ var g = &grammar{
 		},
 		{
 			name: "NULL",
-			pos:  position{line: 528, col: 1, offset: 17664},
+			pos:  position{line: 785, col: 1, offset: 28804},
 			expr: &litMatcher{
-				pos:        position{line: 528, col: 20, offset: 17683},
+				pos:        position{line: 785, col: 20, offset: 28823},
 				val:        "null",
 				ignoreCase: false,
 				want:       "\"null\"",
@@ -4242,9 +6595,9 @@ var g = &grammar{
 		},
 		{
 			name: "VAL",
-			pos:  position{line: 529, col: 1, offset: 17690},
+			pos:  position{line: 786, col: 1, offset: 28830},
 			expr: &litMatcher{
-				pos:        position{line: 529, col: 20, offset: 17709},
+				pos:        position{line: 786, col: 20, offset: 28849},
 				val:        "val",
 				ignoreCase: false,
 				want:       "\"val\"",
@@ -4252,9 +6605,9 @@ var g = &grammar{
 		},
 		{
 			name: "VAR",
-			pos:  position{line: 530, col: 1, offset: 17715},
+			pos:  position{line: 787, col: 1, offset: 28855},
 			expr: &litMatcher{
-				pos:        position{line: 530, col: 20, offset: 17734},
+				pos:        position{line: 787, col: 20, offset: 28874},
 				val:        "var",
 				ignoreCase: false,
 				want:       "\"var\"",
@@ -4262,9 +6615,9 @@ var g = &grammar{
 		},
 		{
 			name: "CONST",
-			pos:  position{line: 531, col: 1, offset: 17740},
+			pos:  position{line: 788, col: 1, offset: 28880},
 			expr: &litMatcher{
-				pos:        position{line: 531, col: 20, offset: 17759},
+				pos:        position{line: 788, col: 20, offset: 28899},
 				val:        "const",
 				ignoreCase: false,
 				want:       "\"const\"",
@@ -4272,19 +6625,29 @@ var g = &grammar{
 		},
 		{
 			name: "FUN",
-			pos:  position{line: 532, col: 1, offset: 17767},
+			pos:  position{line: 789, col: 1, offset: 28907},
 			expr: &litMatcher{
-				pos:        position{line: 532, col: 20, offset: 17786},
+				pos:        position{line: 789, col: 20, offset: 28926},
 				val:        "fun",
 				ignoreCase: false,
 				want:       "\"fun\"",
 			},
 		},
+		{
+			name: "EXTERN",
+			pos:  position{line: 790, col: 1, offset: 28932},
+			expr: &litMatcher{
+				pos:        position{line: 790, col: 20, offset: 28951},
+				val:        "extern",
+				ignoreCase: false,
+				want:       "\"extern\"",
+			},
+		},
 		{
 			name: "RECORD",
-			pos:  position{line: 533, col: 1, offset: 17792},
+			pos:  position{line: 791, col: 1, offset: 28960},
 			expr: &litMatcher{
-				pos:        position{line: 533, col: 20, offset: 17811},
+				pos:        position{line: 791, col: 20, offset: 28979},
 				val:        "record",
 				ignoreCase: false,
 				want:       "\"record\"",
@@ -4292,9 +6655,9 @@ var g = &grammar{
 		},
 		{
 			name: "PRINT",
-			pos:  position{line: 534, col: 1, offset: 17820},
+			pos:  position{line: 792, col: 1, offset: 28988},
 			expr: &litMatcher{
-				pos:        position{line: 534, col: 20, offset: 17839},
+				pos:        position{line: 792, col: 20, offset: 29007},
 				val:        "print",
 				ignoreCase: false,
 				want:       "\"print\"",
@@ -4302,9 +6665,9 @@ var g = &grammar{
 		},
 		{
 			name: "RETURN",
-			pos:  position{line: 535, col: 1, offset: 17847},
+			pos:  position{line: 793, col: 1, offset: 29015},
 			expr: &litMatcher{
-				pos:        position{line: 535, col: 20, offset: 17866},
+				pos:        position{line: 793, col: 20, offset: 29034},
 				val:        "return",
 				ignoreCase: false,
 				want:       "\"return\"",
@@ -4312,19 +6675,49 @@ var g = &grammar{
 		},
 		{
 			name: "IF",
-			pos:  position{line: 536, col: 1, offset: 17875},
+			pos:  position{line: 794, col: 1, offset: 29043},
 			expr: &litMatcher{
-				pos:        position{line: 536, col: 20, offset: 17894},
+				pos:        position{line: 794, col: 20, offset: 29062},
 				val:        "if",
 				ignoreCase: false,
 				want:       "\"if\"",
 			},
 		},
+		{
+			name: "WHILE",
+			pos:  position{line: 795, col: 1, offset: 29067},
+			expr: &litMatcher{
+				pos:        position{line: 795, col: 20, offset: 29086},
+				val:        "while",
+				ignoreCase: false,
+				want:       "\"while\"",
+			},
+		},
+		{
+			name: "BREAK",
+			pos:  position{line: 796, col: 1, offset: 29094},
+			expr: &litMatcher{
+				pos:        position{line: 796, col: 20, offset: 29113},
+				val:        "break",
+				ignoreCase: false,
+				want:       "\"break\"",
+			},
+		},
+		{
+			name: "CONTINUE",
+			pos:  position{line: 797, col: 1, offset: 29121},
+			expr: &litMatcher{
+				pos:        position{line: 797, col: 20, offset: 29140},
+				val:        "continue",
+				ignoreCase: false,
+				want:       "\"continue\"",
+			},
+		},
 		{
 			name: "ELSE",
-			pos:  position{line: 537, col: 1, offset: 17899},
+			pos:  position{line: 798, col: 1, offset: 29151},
 			expr: &litMatcher{
-				pos:        position{line: 537, col: 20, offset: 17918},
+				pos:        position{line: 798, col: 20, offset: 29170},
 				val:        "else",
 				ignoreCase: false,
 				want:       "\"else\"",
@@ -4332,9 +6725,9 @@ var g = &grammar{
 		},
 		{
 			name: "MATCH",
-			pos:  position{line: 538, col: 1, offset: 17925},
+			pos:  position{line: 799, col: 1, offset: 29177},
 			expr: &litMatcher{
-				pos:        position{line: 538, col: 20, offset: 17944},
+				pos:        position{line: 799, col: 20, offset: 29196},
 				val:        "match",
 				ignoreCase: false,
 				want:       "\"match\"",
@@ -4342,9 +6735,9 @@ var g = &grammar{
 		},
 		{
 			name: "PACKAGE",
-			pos:  position{line: 539, col: 1, offset: 17952},
+			pos:  position{line: 800, col: 1, offset: 29204},
 			expr: &litMatcher{
-				pos:        position{line: 539, col: 20, offset: 17971},
+				pos:        position{line: 800, col: 20, offset: 29223},
 				val:        "package",
 				ignoreCase: false,
 				want:       "\"package\"",
@@ -4352,9 +6745,9 @@ var g = &grammar{
 		},
 		{
 			name: "IMPORT",
-			pos:  position{line: 540, col: 1, offset: 17981},
+			pos:  position{line: 801, col: 1, offset: 29233},
 			expr: &litMatcher{
-				pos:        position{line: 540, col: 20, offset: 18000},
+				pos:        position{line: 801, col: 20, offset: 29252},
 				val:        "import",
 				ignoreCase: false,
 				want:       "\"import\"",
@@ -4362,109 +6755,470 @@ var g = &grammar{
 		},
 		{
 			name: "TYPE",
-			pos:  position{line: 541, col: 1, offset: 18009},
+			pos:  position{line: 802, col: 1, offset: 29261},
 			expr: &litMatcher{
-				pos:        position{line: 541, col: 20, offset: 18028},
+				pos:        position{line: 802, col: 20, offset: 29280},
 				val:        "type",
 				ignoreCase: false,
 				want:       "\"type\"",
 			},
 		},
+		{
+			name: "ARROW",
+			pos:  position{line: 803, col: 1, offset: 29287},
+			expr: &litMatcher{
+				pos:        position{line: 803, col: 20, offset: 29306},
+				val:        "->",
+				ignoreCase: false,
+				want:       "\"->\"",
+			},
+		},
 		{
 			name: "EOF",
-			pos:  position{line: 543, col: 1, offset: 18036},
+			pos:  position{line: 805, col: 1, offset: 29312},
 			expr: &notExpr{
-				pos: position{line: 543, col: 20, offset: 18055},
+				pos: position{line: 805, col: 20, offset: 29331},
 				expr: &anyMatcher{
-					line: 543, col: 21, offset: 18056,
+					line: 805, col: 21, offset: 29332,
 				},
 			},
 		},
 		{
 			name: "LambdaExpr",
-			pos:  position{line: 544, col: 1, offset: 18058},
+			pos:  position{line: 806, col: 1, offset: 29334},
 			expr: &actionExpr{
-				pos: position{line: 544, col: 20, offset: 18077},
+				pos: position{line: 806, col: 20, offset: 29353},
 				run: (*parser).callonLambdaExpr1,
 				expr: &seqExpr{
-					pos: position{line: 544, col: 20, offset: 18077},
+					pos: position{line: 806, col: 20, offset: 29353},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 544, col: 20, offset: 18077},
+							pos:  position{line: 806, col: 20, offset: 29353},
 							name: "FUN",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 544, col: 24, offset: 18081},
+							pos:  position{line: 806, col: 24, offset: 29357},
+							name: "WS",
+						},
+						&labeledExpr{
+							pos:   position{line: 806, col: 27, offset: 29360},
+							label: "ret",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 806, col: 31, offset: 29364},
+								expr: &ruleRefExpr{
+									pos:  position{line: 806, col: 31, offset: 29364},
+									name: "Type",
+								},
+							},
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 806, col: 37, offset: 29370},
+							expr: &ruleRefExpr{
+								pos:  position{line: 806, col: 37, offset: 29370},
+								name: "WS",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 806, col: 41, offset: 29374},
+							val:        "(",
+							ignoreCase: false,
+							want:       "\"(\"",
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 806, col: 45, offset: 29378},
+							expr: &ruleRefExpr{
+								pos:  position{line: 806, col: 45, offset: 29378},
+								name: "WS",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 806, col: 49, offset: 29382},
+							label: "params",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 806, col: 56, offset: 29389},
+								expr: &ruleRefExpr{
+									pos:  position{line: 806, col: 56, offset: 29389},
+									name: "ParamList",
+								},
+							},
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 806, col: 67, offset: 29400},
+							expr: &ruleRefExpr{
+								pos:  position{line: 806, col: 67, offset: 29400},
+								name: "WS",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 806, col: 71, offset: 29404},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 806, col: 75, offset: 29408},
+							expr: &ruleRefExpr{
+								pos:  position{line: 806, col: 75, offset: 29408},
+								name: "WS",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 806, col: 79, offset: 29412},
+							label: "block",
+							expr: &ruleRefExpr{
+								pos:  position{line: 806, col: 85, offset: 29418},
+								name: "Block",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "SumTypeDecl",
+			pos:  position{line: 821, col: 1, offset: 29865},
+			expr: &actionExpr{
+				pos: position{line: 821, col: 20, offset: 29884},
+				run: (*parser).callonSumTypeDecl1,
+				expr: &seqExpr{
+					pos: position{line: 821, col: 20, offset: 29884},
+					exprs: []any{
+						&ruleRefExpr{
+							pos:  position{line: 821, col: 20, offset: 29884},
+							name: "TYPE",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 821, col: 25, offset: 29889},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 544, col: 27, offset: 18084},
-							label: "ret",
-							expr: &zeroOrOneExpr{
-								pos: position{line: 544, col: 31, offset: 18088},
-								expr: &ruleRefExpr{
-									pos:  position{line: 544, col: 31, offset: 18088},
-									name: "Type",
-								},
+							pos:   position{line: 821, col: 28, offset: 29892},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 821, col: 33, offset: 29897},
+								name: "Ident",
+							},
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 821, col: 39, offset: 29903},
+							expr: &ruleRefExpr{
+								pos:  position{line: 821, col: 39, offset: 29903},
+								name: "WS",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 821, col: 43, offset: 29907},
+							val:        "=",
+							ignoreCase: false,
+							want:       "\"=\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 821, col: 47, offset: 29911},
+							name: "Skip",
+						},
+						&labeledExpr{
+							pos:   position{line: 821, col: 52, offset: 29916},
+							label: "variants",
+							expr: &ruleRefExpr{
+								pos:  position{line: 821, col: 61, offset: 29925},
+								name: "VariantList",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 821, col: 73, offset: 29937},
+							name: "Terminator",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "VariantList",
+			pos:  position{line: 825, col: 1, offset: 30063},
+			expr: &choiceExpr{
+				pos: position{line: 825, col: 20, offset: 30082},
+				alternatives: []any{
+					&actionExpr{
+						pos: position{line: 825, col: 20, offset: 30082},
+						run: (*parser).callonVariantList2,
+						expr: &seqExpr{
+							pos: position{line: 825, col: 20, offset: 30082},
+							exprs: []any{
+								&labeledExpr{
+									pos:   position{line: 825, col: 20, offset: 30082},
+									label: "head",
+									expr: &ruleRefExpr{
+										pos:  position{line: 825, col: 25, offset: 30087},
+										name: "VariantDecl",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 825, col: 37, offset: 30099},
+									label: "tail",
+									expr: &zeroOrMoreExpr{
+										pos: position{line: 825, col: 42, offset: 30104},
+										expr: &seqExpr{
+											pos: position{line: 825, col: 43, offset: 30105},
+											exprs: []any{
+												&ruleRefExpr{
+													pos:  position{line: 825, col: 43, offset: 30105},
+													name: "Skip",
+												},
+												&litMatcher{
+													pos:        position{line: 825, col: 48, offset: 30110},
+													val:        "|",
+													ignoreCase: false,
+													want:       "\"|\"",
+												},
+												&zeroOrMoreExpr{
+													pos: position{line: 825, col: 52, offset: 30114},
+													expr: &ruleRefExpr{
+														pos:  position{line: 825, col: 52, offset: 30114},
+														name: "WS",
+													},
+												},
+												&ruleRefExpr{
+													pos:  position{line: 825, col: 56, offset: 30118},
+													name: "VariantDecl",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 835, col: 19, offset: 30434},
+						run: (*parser).callonVariantList14,
+						expr: &seqExpr{
+							pos: position{line: 835, col: 19, offset: 30434},
+							exprs: []any{
+								&litMatcher{
+									pos:        position{line: 835, col: 19, offset: 30434},
+									val:        "|",
+									ignoreCase: false,
+									want:       "\"|\"",
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 835, col: 23, offset: 30438},
+									expr: &ruleRefExpr{
+										pos:  position{line: 835, col: 23, offset: 30438},
+										name: "WS",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 835, col: 27, offset: 30442},
+									label: "first",
+									expr: &ruleRefExpr{
+										pos:  position{line: 835, col: 33, offset: 30448},
+										name: "VariantDecl",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 835, col: 45, offset: 30460},
+									label: "tail",
+									expr: &zeroOrMoreExpr{
+										pos: position{line: 835, col: 50, offset: 30465},
+										expr: &seqExpr{
+											pos: position{line: 835, col: 51, offset: 30466},
+											exprs: []any{
+												&ruleRefExpr{
+													pos:  position{line: 835, col: 51, offset: 30466},
+													name: "Skip",
+												},
+												&litMatcher{
+													pos:        position{line: 835, col: 56, offset: 30471},
+													val:        "|",
+													ignoreCase: false,
+													want:       "\"|\"",
+												},
+												&zeroOrMoreExpr{
+													pos: position{line: 835, col: 60, offset: 30475},
+													expr: &ruleRefExpr{
+														pos:  position{line: 835, col: 60, offset: 30475},
+														name: "WS",
+													},
+												},
+												&ruleRefExpr{
+													pos:  position{line: 835, col: 64, offset: 30479},
+													name: "VariantDecl",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "VariantDecl",
+			pos:  position{line: 846, col: 1, offset: 30779},
+			expr: &actionExpr{
+				pos: position{line: 846, col: 20, offset: 30798},
+				run: (*parser).callonVariantDecl1,
+				expr: &seqExpr{
+					pos: position{line: 846, col: 20, offset: 30798},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 846, col: 20, offset: 30798},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 846, col: 25, offset: 30803},
+								name: "Ident",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 544, col: 37, offset: 18094},
+							pos: position{line: 846, col: 31, offset: 30809},
 							expr: &ruleRefExpr{
-								pos:  position{line: 544, col: 37, offset: 18094},
+								pos:  position{line: 846, col: 31, offset: 30809},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 544, col: 41, offset: 18098},
+							pos:        position{line: 846, col: 35, offset: 30813},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 544, col: 45, offset: 18102},
+							pos: position{line: 846, col: 39, offset: 30817},
 							expr: &ruleRefExpr{
-								pos:  position{line: 544, col: 45, offset: 18102},
+								pos:  position{line: 846, col: 39, offset: 30817},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 544, col: 49, offset: 18106},
-							label: "params",
+							pos:   position{line: 846, col: 43, offset: 30821},
+							label: "fields",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 544, col: 56, offset: 18113},
+								pos: position{line: 846, col: 50, offset: 30828},
 								expr: &ruleRefExpr{
-									pos:  position{line: 544, col: 56, offset: 18113},
-									name: "ParamList",
+									pos:  position{line: 846, col: 50, offset: 30828},
+									name: "VariantFieldList",
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 544, col: 67, offset: 18124},
+							pos: position{line: 846, col: 68, offset: 30846},
 							expr: &ruleRefExpr{
-								pos:  position{line: 544, col: 67, offset: 18124},
+								pos:  position{line: 846, col: 68, offset: 30846},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 544, col: 71, offset: 18128},
+							pos:        position{line: 846, col: 72, offset: 30850},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
 						},
+					},
+				},
+			},
+		},
+		{
+			name: "VariantFieldList",
+			pos:  position{line: 858, col: 1, offset: 31205},
+			expr: &actionExpr{
+				pos: position{line: 858, col: 21, offset: 31225},
+				run: (*parser).callonVariantFieldList1,
+				expr: &seqExpr{
+					pos: position{line: 858, col: 21, offset: 31225},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 858, col: 21, offset: 31225},
+							label: "head",
+							expr: &ruleRefExpr{
+								pos:  position{line: 858, col: 26, offset: 31230},
+								name: "VariantField",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 858, col: 39, offset: 31243},
+							label: "tail",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 858, col: 44, offset: 31248},
+								expr: &seqExpr{
+									pos: position{line: 858, col: 45, offset: 31249},
+									exprs: []any{
+										&zeroOrOneExpr{
+											pos: position{line: 858, col: 45, offset: 31249},
+											expr: &ruleRefExpr{
+												pos:  position{line: 858, col: 45, offset: 31249},
+												name: "WS",
+											},
+										},
+										&litMatcher{
+											pos:        position{line: 858, col: 49, offset: 31253},
+											val:        ",",
+											ignoreCase: false,
+											want:       "\",\"",
+										},
+										&zeroOrOneExpr{
+											pos: position{line: 858, col: 53, offset: 31257},
+											expr: &ruleRefExpr{
+												pos:  position{line: 858, col: 53, offset: 31257},
+												name: "WS",
+											},
+										},
+										&ruleRefExpr{
+											pos:  position{line: 858, col: 57, offset: 31261},
+											name: "VariantField",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "VariantField",
+			pos:  position{line: 869, col: 1, offset: 31514},
+			expr: &actionExpr{
+				pos: position{line: 869, col: 20, offset: 31533},
+				run: (*parser).callonVariantField1,
+				expr: &seqExpr{
+					pos: position{line: 869, col: 20, offset: 31533},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 869, col: 20, offset: 31533},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 869, col: 25, offset: 31538},
+								name: "Ident",
+							},
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 869, col: 31, offset: 31544},
+							expr: &ruleRefExpr{
+								pos:  position{line: 869, col: 31, offset: 31544},
+								name: "WS",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 869, col: 35, offset: 31548},
+							val:        ":",
+							ignoreCase: false,
+							want:       "\":\"",
+						},
 						&zeroOrOneExpr{
-							pos: position{line: 544, col: 75, offset: 18132},
+							pos: position{line: 869, col: 39, offset: 31552},
 							expr: &ruleRefExpr{
-								pos:  position{line: 544, col: 75, offset: 18132},
+								pos:  position{line: 869, col: 39, offset: 31552},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 544, col: 79, offset: 18136},
-							label: "block",
+							pos:   position{line: 869, col: 43, offset: 31556},
+							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 544, col: 85, offset: 18142},
-								name: "Block",
+								pos:  position{line: 869, col: 45, offset: 31558},
+								name: "Type",
 							},
 						},
 					},
@@ -4479,6 +7233,7 @@ func (c *current) onProgram1(pkg, imps, d any) (any, error) {
 	records := []*ast.RecordDecl{}
 	funcs := []*ast.FunctionDecl{}
 	aliases := []*ast.TypeAliasDecl{}
+	sums := []*ast.SumTypeDecl{}
 	for _, item := range decls {
 		switch v := item.(type) {
 		case *ast.TypeAliasDecl:
@@ -4487,6 +7242,8 @@ func (c *current) onProgram1(pkg, imps, d any) (any, error) {
 			records = append(records, v)
 		case *ast.FunctionDecl:
 			funcs = append(funcs, v)
+		case *ast.SumTypeDecl:
+			sums = append(sums, v)
 		}
 	}
 	var pkgDecl *ast.PackageDecl
@@ -4500,7 +7257,7 @@ func (c *current) onProgram1(pkg, imps, d any) (any, error) {
 			imports = append(imports, item.(*ast.ImportDecl))
 		}
 	}
-	return &ast.Program{Package: pkgDecl, Imports: imports, TypeAliases: aliases, Records: records, Functions: funcs}, nil
+	return &ast.Program{Package: pkgDecl, Imports: imports, TypeAliases: aliases, Records: records, SumTypes: sums, Functions: funcs, Pos: c.span()}, nil
 }
 
 func (p *parser) callonProgram1() (any, error) {
@@ -4510,7 +7267,7 @@ func (p *parser) callonProgram1() (any, error) {
 }
 
 func (c *current) onPackageDecl1(q any) (any, error) {
-	return &ast.PackageDecl{Name: q.(string)}, nil
+	return &ast.PackageDecl{Name: q.(string), Pos: c.span()}, nil
 }
 
 func (p *parser) callonPackageDecl1() (any, error) {
@@ -4520,7 +7277,7 @@ func (p *parser) callonPackageDecl1() (any, error) {
 }
 
 func (c *current) onImportDecl1(q any) (any, error) {
-	return &ast.ImportDecl{Name: q.(string)}, nil
+	return &ast.ImportDecl{Name: q.(string), Pos: c.span()}, nil
 }
 
 func (p *parser) callonImportDecl1() (any, error) {
@@ -4529,8 +7286,21 @@ func (p *parser) callonImportDecl1() (any, error) {
 	return p.cur.onImportDecl1(stack["q"])
 }
 
+func (c *current) onDecl1(doc, d any) (any, error) {
+	if doc != nil {
+		attachDoc(d, doc.(string))
+	}
+	return d, nil
+}
+
+func (p *parser) callonDecl1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDecl1(stack["doc"], stack["d"])
+}
+
 func (c *current) onTypeAliasDecl1(name, t any) (any, error) {
-	return &ast.TypeAliasDecl{Name: name.(string), TargetType: t.(string)}, nil
+	return &ast.TypeAliasDecl{Name: name.(string), TargetType: t.(string), Pos: c.span()}, nil
 }
 
 func (p *parser) callonTypeAliasDecl1() (any, error) {
@@ -4545,7 +7315,7 @@ func (c *current) onRecordDecl1(name, f any) (any, error) {
 	for i, field := range fields {
 		out[i] = field.(*ast.RecordField)
 	}
-	return &ast.RecordDecl{Name: name.(string), Fields: out}, nil
+	return &ast.RecordDecl{Name: name.(string), Fields: out, Pos: c.span()}, nil
 }
 
 func (p *parser) callonRecordDecl1() (any, error) {
@@ -4554,18 +7324,32 @@ func (p *parser) callonRecordDecl1() (any, error) {
 	return p.cur.onRecordDecl1(stack["name"], stack["f"])
 }
 
-func (c *current) onRecordField1(m, t, n any) (any, error) {
+func (c *current) onRecordField1(doc, f any) (any, error) {
+	field := f.(*ast.RecordField)
+	if doc != nil {
+		field.Doc = doc.(string)
+	}
+	return field, nil
+}
+
+func (p *parser) callonRecordField1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onRecordField1(stack["doc"], stack["f"])
+}
+
+func (c *current) onFieldDecl1(m, t, n any) (any, error) {
 	mut := "var"
 	if m != nil {
 		mut = m.(string)
 	}
-	return &ast.RecordField{Name: n.(string), Type: t.(string), Mutability: mut}, nil
+	return &ast.RecordField{Name: n.(string), Type: t.(string), Mutability: mut, Pos: c.span()}, nil
 }
 
-func (p *parser) callonRecordField1() (any, error) {
+func (p *parser) callonFieldDecl1() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onRecordField1(stack["m"], stack["t"], stack["n"])
+	return p.cur.onFieldDecl1(stack["m"], stack["t"], stack["n"])
 }
 
 func (c *current) onFieldMutability1() (any, error) {
@@ -4587,7 +7371,7 @@ func (c *current) onFuncDecl1(t, name, p, b any) (any, error) {
 			params[i] = item.(*ast.Param)
 		}
 	}
-	return &ast.FunctionDecl{Name: name.(string), Params: params, ReturnType: t.(string), Body: b.(*ast.Block)}, nil
+	return &ast.FunctionDecl{Name: name.(string), Params: params, ReturnType: t.(string), Body: b.(*ast.Block), Pos: c.span()}, nil
 }
 
 func (p *parser) callonFuncDecl1() (any, error) {
@@ -4596,6 +7380,24 @@ func (p *parser) callonFuncDecl1() (any, error) {
 	return p.cur.onFuncDecl1(stack["t"], stack["name"], stack["p"], stack["b"])
 }
 
+func (c *current) onExternFuncDecl1(t, name, p any) (any, error) {
+	params := []*ast.Param{}
+	if p != nil {
+		list := p.([]interface{})
+		params = make([]*ast.Param, len(list))
+		for i, item := range list {
+			params[i] = item.(*ast.Param)
+		}
+	}
+	return &ast.FunctionDecl{Name: name.(string), Params: params, ReturnType: t.(string), Extern: true, Pos: c.span()}, nil
+}
+
+func (p *parser) callonExternFuncDecl1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onExternFuncDecl1(stack["t"], stack["name"], stack["p"])
+}
+
 func (c *current) onParamList1(p, r any) (any, error) {
 	out := []interface{}{p}
 	for _, item := range r.([]interface{}) {
@@ -4612,7 +7414,7 @@ func (p *parser) callonParamList1() (any, error) {
 }
 
 func (c *current) onParam1(t, n any) (any, error) {
-	return &ast.Param{Name: n.(string), Type: t.(string)}, nil
+	return &ast.Param{Name: n.(string), Type: t.(string), Pos: c.span()}, nil
 }
 
 func (p *parser) callonParam1() (any, error) {
@@ -4627,7 +7429,7 @@ func (c *current) onBlock1(s any) (any, error) {
 	for i, st := range stmts {
 		out[i] = st.(ast.Statement)
 	}
-	return &ast.Block{Statements: out}, nil
+	return &ast.Block{Statements: out, Pos: c.span()}, nil
 }
 
 func (p *parser) callonBlock1() (any, error) {
@@ -4646,22 +7448,72 @@ func (p *parser) callonStatement1() (any, error) {
 	return p.cur.onStatement1(stack["s"])
 }
 
-func (c *current) onVarDecl1(k, n, t, e any) (any, error) {
+func (c *current) onWhileStmt1(cond, b any) (any, error) {
+	return &ast.WhileStmt{Condition: cond.(ast.Expr), Body: b.(*ast.Block), Pos: c.span()}, nil
+}
+
+func (p *parser) callonWhileStmt1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onWhileStmt1(stack["cond"], stack["b"])
+}
+
+func (c *current) onBreakStmt1() (any, error) {
+	return &ast.BreakStmt{Pos: c.span()}, nil
+}
+
+func (p *parser) callonBreakStmt1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onBreakStmt1()
+}
+
+func (c *current) onContinueStmt1() (any, error) {
+	return &ast.ContinueStmt{Pos: c.span()}, nil
+}
+
+func (p *parser) callonContinueStmt1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onContinueStmt1()
+}
+
+func (c *current) onIncDecStmt1(t, o any) (any, error) {
+	return &ast.IncDecStmt{Target: t.(ast.Expr), Op: string(o.([]byte)), Pos: c.span()}, nil
+}
+
+func (p *parser) callonIncDecStmt1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onIncDecStmt1(stack["t"], stack["o"])
+}
+
+func (c *current) onVarDecl2(k, t, n, e any) (any, error) {
+	return &ast.VarDecl{Name: n.(string), Type: t.(string), Mutability: k.(string), Value: e.(ast.Expr), Pos: c.span()}, nil
+}
+
+func (p *parser) callonVarDecl2() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onVarDecl2(stack["k"], stack["t"], stack["n"], stack["e"])
+}
+
+func (c *current) onVarDecl20(k, n, t, e any) (any, error) {
 	typeName := ""
 	if t != nil {
 		typeName = t.(string)
 	}
-	return &ast.VarDecl{Name: n.(string), Type: typeName, Mutability: k.(string), Value: e.(ast.Expr)}, nil
+	return &ast.VarDecl{Name: n.(string), Type: typeName, Mutability: k.(string), Value: e.(ast.Expr), Pos: c.span()}, nil
 }
 
-func (p *parser) callonVarDecl1() (any, error) {
+func (p *parser) callonVarDecl20() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onVarDecl1(stack["k"], stack["n"], stack["t"], stack["e"])
+	return p.cur.onVarDecl20(stack["k"], stack["n"], stack["t"], stack["e"])
 }
 
 func (c *current) onImplicitTypedDecl1(n, t, e any) (any, error) {
-	return &ast.VarDecl{Name: n.(string), Type: t.(string), Mutability: "var", Value: e.(ast.Expr)}, nil
+	return &ast.VarDecl{Name: n.(string), Type: t.(string), Mutability: "var", Value: e.(ast.Expr), Pos: c.span()}, nil
 }
 
 func (p *parser) callonImplicitTypedDecl1() (any, error) {
@@ -4710,14 +7562,34 @@ func (p *parser) callonVarKind6() (any, error) {
 	return p.cur.onVarKind6()
 }
 
-func (c *current) onAssignStmt1(t, e any) (any, error) {
-	return &ast.AssignStmt{Target: t.(ast.Expr), Value: e.(ast.Expr)}, nil
+func (c *current) onAssignStmt1(t, o, e any) (any, error) {
+	return &ast.AssignStmt{Target: t.(ast.Expr), Op: o.(string), Value: e.(ast.Expr), Pos: c.span()}, nil
 }
 
 func (p *parser) callonAssignStmt1() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onAssignStmt1(stack["t"], stack["e"])
+	return p.cur.onAssignStmt1(stack["t"], stack["o"], stack["e"])
+}
+
+func (c *current) onAssignOp2() (any, error) {
+	return "", nil
+}
+
+func (p *parser) callonAssignOp2() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAssignOp2()
+}
+
+func (c *current) onAssignOp7(o any) (any, error) {
+	return string(o.([]byte)), nil
+}
+
+func (p *parser) callonAssignOp7() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAssignOp7(stack["o"])
 }
 
 func (c *current) onAssignable1(p, s any) (any, error) {
@@ -4732,7 +7604,7 @@ func (p *parser) callonAssignable1() (any, error) {
 }
 
 func (c *current) onPrimaryAccess1(i any) (any, error) {
-	return &ast.VarRef{Name: i.(string)}, nil
+	return &ast.VarRef{Name: i.(string), Pos: c.span()}, nil
 }
 
 func (p *parser) callonPrimaryAccess1() (any, error) {
@@ -4742,7 +7614,7 @@ func (p *parser) callonPrimaryAccess1() (any, error) {
 }
 
 func (c *current) onAssignableSuffix2(i any) (any, error) {
-	return []interface{}{"field", i.(string)}, nil
+	return []interface{}{"field", i.(string), c.span()}, nil
 }
 
 func (p *parser) callonAssignableSuffix2() (any, error) {
@@ -4752,7 +7624,7 @@ func (p *parser) callonAssignableSuffix2() (any, error) {
 }
 
 func (c *current) onAssignableSuffix11(e any) (any, error) {
-	return []interface{}{"index", e.(ast.Expr)}, nil
+	return []interface{}{"index", e.(ast.Expr), c.span()}, nil
 }
 
 func (p *parser) callonAssignableSuffix11() (any, error) {
@@ -4761,38 +7633,68 @@ func (p *parser) callonAssignableSuffix11() (any, error) {
 	return p.cur.onAssignableSuffix11(stack["e"])
 }
 
-func (c *current) onAccessSuffix2(i any) (any, error) {
-	return []interface{}{"safe-field", i.(string)}, nil
+func (c *current) onAccessSuffix2(m, b any) (any, error) {
+	return []interface{}{"iterate", []interface{}{m, b}, c.span()}, nil
 }
 
 func (p *parser) callonAccessSuffix2() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onAccessSuffix2(stack["i"])
+	return p.cur.onAccessSuffix2(stack["m"], stack["b"])
+}
+
+func (c *current) onAccessSuffix15(i, args any) (any, error) {
+	return []interface{}{"safe-call", []interface{}{i, args}, c.span()}, nil
+}
+
+func (p *parser) callonAccessSuffix15() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAccessSuffix15(stack["i"], stack["args"])
+}
+
+func (c *current) onAccessSuffix35(i, args any) (any, error) {
+	return []interface{}{"call", []interface{}{i, args}, c.span()}, nil
+}
+
+func (p *parser) callonAccessSuffix35() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAccessSuffix35(stack["i"], stack["args"])
+}
+
+func (c *current) onAccessSuffix55(i any) (any, error) {
+	return []interface{}{"safe-field", i.(string), c.span()}, nil
+}
+
+func (p *parser) callonAccessSuffix55() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAccessSuffix55(stack["i"])
 }
 
-func (c *current) onAccessSuffix11(i any) (any, error) {
-	return []interface{}{"field", i.(string)}, nil
+func (c *current) onAccessSuffix64(i any) (any, error) {
+	return []interface{}{"field", i.(string), c.span()}, nil
 }
 
-func (p *parser) callonAccessSuffix11() (any, error) {
+func (p *parser) callonAccessSuffix64() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onAccessSuffix11(stack["i"])
+	return p.cur.onAccessSuffix64(stack["i"])
 }
 
-func (c *current) onAccessSuffix20(e any) (any, error) {
-	return []interface{}{"index", e.(ast.Expr)}, nil
+func (c *current) onAccessSuffix73(e any) (any, error) {
+	return []interface{}{"index", e.(ast.Expr), c.span()}, nil
 }
 
-func (p *parser) callonAccessSuffix20() (any, error) {
+func (p *parser) callonAccessSuffix73() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onAccessSuffix20(stack["e"])
+	return p.cur.onAccessSuffix73(stack["e"])
 }
 
 func (c *current) onPrintStmt1(e any) (any, error) {
-	return &ast.PrintStmt{Expr: e.(ast.Expr)}, nil
+	return &ast.PrintStmt{Expr: e.(ast.Expr), Pos: c.span()}, nil
 }
 
 func (p *parser) callonPrintStmt1() (any, error) {
@@ -4806,7 +7708,7 @@ func (c *current) onReturnStmt1(e any) (any, error) {
 	if e != nil {
 		expr = e.(ast.Expr)
 	}
-	return &ast.ReturnStmt{Expr: expr}, nil
+	return &ast.ReturnStmt{Expr: expr, Pos: c.span()}, nil
 }
 
 func (p *parser) callonReturnStmt1() (any, error) {
@@ -4816,7 +7718,7 @@ func (p *parser) callonReturnStmt1() (any, error) {
 }
 
 func (c *current) onExprStmt1(e any) (any, error) {
-	return &ast.ExprStmt{Expr: e.(ast.Expr)}, nil
+	return &ast.ExprStmt{Expr: e.(ast.Expr), Pos: c.span()}, nil
 }
 
 func (p *parser) callonExprStmt1() (any, error) {
@@ -4826,7 +7728,7 @@ func (p *parser) callonExprStmt1() (any, error) {
 }
 
 func (c *current) onElvis2(m, r any) (any, error) {
-	return &ast.ElvisExpr{Left: m.(ast.Expr), Right: r.(ast.Expr)}, nil
+	return &ast.ElvisExpr{Left: m.(ast.Expr), Right: r.(ast.Expr), Pos: c.span()}, nil
 }
 
 func (p *parser) callonElvis2() (any, error) {
@@ -4836,7 +7738,7 @@ func (p *parser) callonElvis2() (any, error) {
 }
 
 func (c *current) onTernary2(m, t, f any) (any, error) {
-	return &ast.TernaryExpr{Condition: m.(ast.Expr), IfTrue: t.(ast.Expr), IfFalse: f.(ast.Expr)}, nil
+	return &ast.TernaryExpr{Condition: m.(ast.Expr), IfTrue: t.(ast.Expr), IfFalse: f.(ast.Expr), Pos: c.span()}, nil
 }
 
 func (p *parser) callonTernary2() (any, error) {
@@ -4845,6 +7747,46 @@ func (p *parser) callonTernary2() (any, error) {
 	return p.cur.onTernary2(stack["m"], stack["t"], stack["f"])
 }
 
+func (c *current) onLogicalOr7(o, r any) (any, error) {
+	return []interface{}{o, r}, nil
+}
+
+func (p *parser) callonLogicalOr7() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onLogicalOr7(stack["o"], stack["r"])
+}
+
+func (c *current) onLogicalOr1(l, t any) (any, error) {
+	return binaryChain(l, t), nil
+}
+
+func (p *parser) callonLogicalOr1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onLogicalOr1(stack["l"], stack["t"])
+}
+
+func (c *current) onLogicalAnd7(o, r any) (any, error) {
+	return []interface{}{o, r}, nil
+}
+
+func (p *parser) callonLogicalAnd7() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onLogicalAnd7(stack["o"], stack["r"])
+}
+
+func (c *current) onLogicalAnd1(l, t any) (any, error) {
+	return binaryChain(l, t), nil
+}
+
+func (p *parser) callonLogicalAnd1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onLogicalAnd1(stack["l"], stack["t"])
+}
+
 func (c *current) onEquality7(o, r any) (any, error) {
 	return []interface{}{o, r}, nil
 }
@@ -4856,15 +7798,7 @@ func (p *parser) callonEquality7() (any, error) {
 }
 
 func (c *current) onEquality1(l, t any) (any, error) {
-	left := l.(ast.Expr)
-	tails := t.([]interface{})
-	for _, item := range tails {
-		parts := item.([]interface{})
-		op := parts[0].(string)
-		right := parts[1].(ast.Expr)
-		left = &ast.BinaryOp{Op: op, Left: left, Right: right}
-	}
-	return left, nil
+	return binaryChain(l, t), nil
 }
 
 func (p *parser) callonEquality1() (any, error) {
@@ -4884,15 +7818,7 @@ func (p *parser) callonComparison7() (any, error) {
 }
 
 func (c *current) onComparison1(l, t any) (any, error) {
-	left := l.(ast.Expr)
-	tails := t.([]interface{})
-	for _, item := range tails {
-		parts := item.([]interface{})
-		op := parts[0].(string)
-		right := parts[1].(ast.Expr)
-		left = &ast.BinaryOp{Op: op, Left: left, Right: right}
-	}
-	return left, nil
+	return binaryChain(l, t), nil
 }
 
 func (p *parser) callonComparison1() (any, error) {
@@ -4902,7 +7828,7 @@ func (p *parser) callonComparison1() (any, error) {
 }
 
 func (c *current) onIfExpr2(cond, tb, eb any) (any, error) {
-	return &ast.IfExpr{Condition: cond.(ast.Expr), ThenBlock: tb.(*ast.Block), ElseBlock: eb.(*ast.Block)}, nil
+	return &ast.IfExpr{Condition: cond.(ast.Expr), ThenBlock: tb.(*ast.Block), ElseBlock: eb.(*ast.Block), Pos: c.span()}, nil
 }
 
 func (p *parser) callonIfExpr2() (any, error) {
@@ -4912,7 +7838,7 @@ func (p *parser) callonIfExpr2() (any, error) {
 }
 
 func (c *current) onIfExpr19(cond, tb any) (any, error) {
-	return &ast.IfExpr{Condition: cond.(ast.Expr), ThenBlock: tb.(*ast.Block)}, nil
+	return &ast.IfExpr{Condition: cond.(ast.Expr), ThenBlock: tb.(*ast.Block), Pos: c.span()}, nil
 }
 
 func (p *parser) callonIfExpr19() (any, error) {
@@ -4931,7 +7857,7 @@ func (c *current) onMatchExpr1(v, cases, elseCase any) (any, error) {
 	if elseCase != nil {
 		fallback = elseCase.(ast.Expr)
 	}
-	return &ast.MatchExpr{Target: v.(ast.Expr), Cases: out, ElseExpr: fallback}, nil
+	return &ast.MatchExpr{Target: v.(ast.Expr), Cases: out, ElseExpr: fallback, Pos: c.span()}, nil
 }
 
 func (p *parser) callonMatchExpr1() (any, error) {
@@ -4951,7 +7877,7 @@ func (p *parser) callonMatchElse1() (any, error) {
 }
 
 func (c *current) onMatchCase1(p, v any) (any, error) {
-	return &ast.MatchCase{Pattern: p.(ast.Pattern), Value: v.(ast.Expr)}, nil
+	return &ast.MatchCase{Pattern: p.(ast.Pattern), Value: v.(ast.Expr), Pos: c.span()}, nil
 }
 
 func (p *parser) callonMatchCase1() (any, error) {
@@ -4960,8 +7886,43 @@ func (p *parser) callonMatchCase1() (any, error) {
 	return p.cur.onMatchCase1(stack["p"], stack["v"])
 }
 
+func (c *current) onVariantPattern1(t, name, fields any) (any, error) {
+	typeName := ""
+	if t != nil {
+		typeName = t.([]interface{})[0].(string)
+	}
+	var list []ast.Pattern
+	if fields != nil {
+		for _, item := range fields.([]interface{}) {
+			list = append(list, item.(ast.Pattern))
+		}
+	}
+	return &ast.VariantPattern{TypeName: typeName, Variant: name.(string), Fields: list, Pos: c.span()}, nil
+}
+
+func (p *parser) callonVariantPattern1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onVariantPattern1(stack["t"], stack["name"], stack["fields"])
+}
+
+func (c *current) onPatternList1(head, tail any) (any, error) {
+	out := []interface{}{head}
+	for _, item := range tail.([]interface{}) {
+		parts := item.([]interface{})
+		out = append(out, parts[len(parts)-1])
+	}
+	return out, nil
+}
+
+func (p *parser) callonPatternList1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPatternList1(stack["head"], stack["tail"])
+}
+
 func (c *current) onWildcardPattern1() (any, error) {
-	return &ast.WildcardPattern{}, nil
+	return &ast.WildcardPattern{Pos: c.span()}, nil
 }
 
 func (p *parser) callonWildcardPattern1() (any, error) {
@@ -4971,7 +7932,7 @@ func (p *parser) callonWildcardPattern1() (any, error) {
 }
 
 func (c *current) onVarPattern1(i any) (any, error) {
-	return &ast.VarPattern{Name: i.(string)}, nil
+	return &ast.VarPattern{Name: i.(string), Pos: c.span()}, nil
 }
 
 func (p *parser) callonVarPattern1() (any, error) {
@@ -4981,7 +7942,12 @@ func (p *parser) callonVarPattern1() (any, error) {
 }
 
 func (c *current) onLiteralPattern2(s any) (any, error) {
-	return &ast.LiteralPattern{Literal: s.(*ast.StringLiteral)}, nil
+	lit, ok := s.(*ast.StringLiteral)
+	if !ok {
+		pattern := &ast.LiteralPattern{Literal: &ast.StringLiteral{Pos: c.span()}, Pos: c.span()}
+		return pattern, fmt.Errorf("string patterns cannot use interpolation")
+	}
+	return &ast.LiteralPattern{Literal: lit, Pos: c.span()}, nil
 }
 
 func (p *parser) callonLiteralPattern2() (any, error) {
@@ -4990,34 +7956,44 @@ func (p *parser) callonLiteralPattern2() (any, error) {
 	return p.cur.onLiteralPattern2(stack["s"])
 }
 
-func (c *current) onLiteralPattern5(i any) (any, error) {
-	return &ast.LiteralPattern{Literal: i.(*ast.IntLiteral)}, nil
+func (c *current) onLiteralPattern5(n any) (any, error) {
+	return &ast.LiteralPattern{Literal: n.(ast.Expr), Pos: c.span()}, nil
 }
 
 func (p *parser) callonLiteralPattern5() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onLiteralPattern5(stack["i"])
+	return p.cur.onLiteralPattern5(stack["n"])
+}
+
+func (c *current) onLiteralPattern10(ch any) (any, error) {
+	return &ast.LiteralPattern{Literal: ch.(*ast.CharLiteral), Pos: c.span()}, nil
+}
+
+func (p *parser) callonLiteralPattern10() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onLiteralPattern10(stack["ch"])
 }
 
-func (c *current) onLiteralPattern8(b any) (any, error) {
-	return &ast.LiteralPattern{Literal: b.(*ast.BoolLiteral)}, nil
+func (c *current) onLiteralPattern13(b any) (any, error) {
+	return &ast.LiteralPattern{Literal: b.(*ast.BoolLiteral), Pos: c.span()}, nil
 }
 
-func (p *parser) callonLiteralPattern8() (any, error) {
+func (p *parser) callonLiteralPattern13() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onLiteralPattern8(stack["b"])
+	return p.cur.onLiteralPattern13(stack["b"])
 }
 
-func (c *current) onLiteralPattern11(n any) (any, error) {
-	return &ast.LiteralPattern{Literal: n.(*ast.NullLiteral)}, nil
+func (c *current) onLiteralPattern16(n any) (any, error) {
+	return &ast.LiteralPattern{Literal: n.(*ast.NullLiteral), Pos: c.span()}, nil
 }
 
-func (p *parser) callonLiteralPattern11() (any, error) {
+func (p *parser) callonLiteralPattern16() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onLiteralPattern11(stack["n"])
+	return p.cur.onLiteralPattern16(stack["n"])
 }
 
 func (c *current) onRecordPattern1(t, body any) (any, error) {
@@ -5029,7 +8005,7 @@ func (c *current) onRecordPattern1(t, body any) (any, error) {
 			fields[i] = item.(*ast.RecordFieldPattern)
 		}
 	}
-	return &ast.RecordPattern{TypeName: t.(string), Fields: fields}, nil
+	return &ast.RecordPattern{TypeName: t.(string), Fields: fields, Pos: c.span()}, nil
 }
 
 func (p *parser) callonRecordPattern1() (any, error) {
@@ -5074,7 +8050,7 @@ func (p *parser) callonRecordPatternFields1() (any, error) {
 }
 
 func (c *current) onRecordFieldPattern1(n, p any) (any, error) {
-	return &ast.RecordFieldPattern{Field: n.(string), Pattern: p.(ast.Pattern)}, nil
+	return &ast.RecordFieldPattern{Field: n.(string), Pattern: p.(ast.Pattern), Pos: c.span()}, nil
 }
 
 func (p *parser) callonRecordFieldPattern1() (any, error) {
@@ -5094,15 +8070,7 @@ func (p *parser) callonSum7() (any, error) {
 }
 
 func (c *current) onSum1(l, t any) (any, error) {
-	left := l.(ast.Expr)
-	tails := t.([]interface{})
-	for _, item := range tails {
-		parts := item.([]interface{})
-		op := parts[0].(string)
-		right := parts[1].(ast.Expr)
-		left = &ast.BinaryOp{Op: op, Left: left, Right: right}
-	}
-	return left, nil
+	return binaryChain(l, t), nil
 }
 
 func (p *parser) callonSum1() (any, error) {
@@ -5115,28 +8083,40 @@ func (c *current) onTerm7(o, r any) (any, error) {
 	return []interface{}{o, r}, nil
 }
 
-func (p *parser) callonTerm7() (any, error) {
+func (p *parser) callonTerm7() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onTerm7(stack["o"], stack["r"])
+}
+
+func (c *current) onTerm1(l, t any) (any, error) {
+	return binaryChain(l, t), nil
+}
+
+func (p *parser) callonTerm1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onTerm1(stack["l"], stack["t"])
+}
+
+func (c *current) onUnary2(n any) (any, error) {
+	return n, nil
+}
+
+func (p *parser) callonUnary2() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onTerm7(stack["o"], stack["r"])
+	return p.cur.onUnary2(stack["n"])
 }
 
-func (c *current) onTerm1(l, t any) (any, error) {
-	left := l.(ast.Expr)
-	tails := t.([]interface{})
-	for _, item := range tails {
-		parts := item.([]interface{})
-		op := parts[0].(string)
-		right := parts[1].(ast.Expr)
-		left = &ast.BinaryOp{Op: op, Left: left, Right: right}
-	}
-	return left, nil
+func (c *current) onUnary8(o, x any) (any, error) {
+	return &ast.UnaryOp{Op: o.(string), Operand: x.(ast.Expr), Pos: c.span()}, nil
 }
 
-func (p *parser) callonTerm1() (any, error) {
+func (p *parser) callonUnary8() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onTerm1(stack["l"], stack["t"])
+	return p.cur.onUnary8(stack["o"], stack["x"])
 }
 
 func (c *current) onFactor1(p, s any) (any, error) {
@@ -5150,6 +8130,16 @@ func (p *parser) callonFactor1() (any, error) {
 	return p.cur.onFactor1(stack["p"], stack["s"])
 }
 
+func (c *current) onPrimary16(e any) (any, error) {
+	return e, nil
+}
+
+func (p *parser) callonPrimary16() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPrimary16(stack["e"])
+}
+
 func (c *current) onCallExpr1(name, args any) (any, error) {
 	var arguments []ast.Expr
 	if args != nil {
@@ -5159,7 +8149,7 @@ func (c *current) onCallExpr1(name, args any) (any, error) {
 			arguments[i] = item.(ast.Expr)
 		}
 	}
-	return &ast.CallExpr{Callee: name.(string), Arguments: arguments}, nil
+	return &ast.CallExpr{Callee: name.(string), Arguments: arguments, Pos: c.span()}, nil
 }
 
 func (p *parser) callonCallExpr1() (any, error) {
@@ -5194,7 +8184,7 @@ func (c *current) onRecordLiteral1(name, f any) (any, error) {
 			fields[parts[0].(string)] = parts[1].(ast.Expr)
 		}
 	}
-	return &ast.RecordLiteral{TypeName: name.(string), Fields: fields}, nil
+	return &ast.RecordLiteral{TypeName: name.(string), Fields: fields, Pos: c.span()}, nil
 }
 
 func (p *parser) callonRecordLiteral1() (any, error) {
@@ -5203,6 +8193,16 @@ func (p *parser) callonRecordLiteral1() (any, error) {
 	return p.cur.onRecordLiteral1(stack["name"], stack["f"])
 }
 
+func (c *current) onTypeIdent1(i any) (any, error) {
+	return i, nil
+}
+
+func (p *parser) callonTypeIdent1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onTypeIdent1(stack["i"])
+}
+
 func (c *current) onFieldAssignList1(a, r any) (any, error) {
 	out := []interface{}{a}
 	for _, item := range r.([]interface{}) {
@@ -5229,7 +8229,7 @@ func (p *parser) callonFieldAssign1() (any, error) {
 }
 
 func (c *current) onArrayAlloc1(t, e any) (any, error) {
-	return &ast.ArrayAllocExpr{ElementType: t.(string), Size: e.(ast.Expr)}, nil
+	return &ast.ArrayAllocExpr{ElementType: t.(string), Size: e.(ast.Expr), Pos: c.span()}, nil
 }
 
 func (p *parser) callonArrayAlloc1() (any, error) {
@@ -5244,11 +8244,10 @@ func (c *current) onMapLiteral1(k, v, e any) (any, error) {
 		list := e.([]interface{})
 		entries = make([]*ast.MapEntryExpr, len(list))
 		for i, item := range list {
-			parts := item.([]interface{})
-			entries[i] = &ast.MapEntryExpr{Key: parts[0].(ast.Expr), Value: parts[1].(ast.Expr)}
+			entries[i] = item.(*ast.MapEntryExpr)
 		}
 	}
-	return &ast.MapLiteralExpr{KeyType: k.(string), ValueType: v.(string), Entries: entries}, nil
+	return &ast.MapLiteralExpr{KeyType: k.(string), ValueType: v.(string), Entries: entries, Pos: c.span()}, nil
 }
 
 func (p *parser) callonMapLiteral1() (any, error) {
@@ -5258,7 +8257,7 @@ func (p *parser) callonMapLiteral1() (any, error) {
 }
 
 func (c *current) onMapAlloc1(k, v, e any) (any, error) {
-	return &ast.MapAllocExpr{KeyType: k.(string), ValueType: v.(string), Capacity: e.(ast.Expr)}, nil
+	return &ast.MapAllocExpr{KeyType: k.(string), ValueType: v.(string), Capacity: e.(ast.Expr), Pos: c.span()}, nil
 }
 
 func (p *parser) callonMapAlloc1() (any, error) {
@@ -5268,7 +8267,7 @@ func (p *parser) callonMapAlloc1() (any, error) {
 }
 
 func (c *current) onMapShorthandAlloc1(e any) (any, error) {
-	return &ast.MapAllocExpr{KeyType: "string", ValueType: "string", Capacity: e.(ast.Expr)}, nil
+	return &ast.MapAllocExpr{KeyType: "string", ValueType: "string", Capacity: e.(ast.Expr), Pos: c.span()}, nil
 }
 
 func (p *parser) callonMapShorthandAlloc1() (any, error) {
@@ -5277,6 +8276,16 @@ func (p *parser) callonMapShorthandAlloc1() (any, error) {
 	return p.cur.onMapShorthandAlloc1(stack["e"])
 }
 
+func (c *current) onEmptyMapLiteral1() (any, error) {
+	return &ast.MapLiteralExpr{Entries: []*ast.MapEntryExpr{}, Pos: c.span()}, nil
+}
+
+func (p *parser) callonEmptyMapLiteral1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEmptyMapLiteral1()
+}
+
 func (c *current) onMapEntryList1(e, r any) (any, error) {
 	out := []interface{}{e}
 	for _, item := range r.([]interface{}) {
@@ -5293,7 +8302,7 @@ func (p *parser) callonMapEntryList1() (any, error) {
 }
 
 func (c *current) onMapEntry1(k, v any) (any, error) {
-	return []interface{}{k.(ast.Expr), v.(ast.Expr)}, nil
+	return &ast.MapEntryExpr{Key: k.(ast.Expr), Value: v.(ast.Expr), Pos: c.span()}, nil
 }
 
 func (p *parser) callonMapEntry1() (any, error) {
@@ -5303,7 +8312,7 @@ func (p *parser) callonMapEntry1() (any, error) {
 }
 
 func (c *current) onVarRef1(i any) (any, error) {
-	return &ast.VarRef{Name: i.(string)}, nil
+	return &ast.VarRef{Name: i.(string), Pos: c.span()}, nil
 }
 
 func (p *parser) callonVarRef1() (any, error) {
@@ -5312,39 +8321,73 @@ func (p *parser) callonVarRef1() (any, error) {
 	return p.cur.onVarRef1(stack["i"])
 }
 
-func (c *current) onIntLit1(v any) (any, error) {
-	var digits []byte
-	switch bs := v.(type) {
-	case []uint8:
-		digits = append(digits, bs...)
-	case []interface{}:
-		for _, item := range bs {
-			switch b := item.(type) {
-			case uint8:
-				digits = append(digits, b)
-			case []uint8:
-				digits = append(digits, b...)
-			}
-		}
-	default:
-		return nil, fmt.Errorf("unexpected int literal type %T", v)
-	}
+func (c *current) onFloatLit2() (any, error) {
+	return floatLiteral(c)
+}
 
-	var n int64
-	for _, b := range digits {
-		n = n*10 + int64(b-'0')
-	}
-	return &ast.IntLiteral{Value: n}, nil
+func (p *parser) callonFloatLit2() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFloatLit2()
+}
+
+func (c *current) onFloatLit16() (any, error) {
+	return floatLiteral(c)
+}
+
+func (p *parser) callonFloatLit16() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFloatLit16()
+}
+
+func (c *current) onIntLit1() (any, error) {
+	return intLiteral(c)
 }
 
 func (p *parser) callonIntLit1() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onIntLit1(stack["v"])
+	return p.cur.onIntLit1()
+}
+
+func (c *current) onNegIntLit1() (any, error) {
+	return intLiteral(c)
+}
+
+func (p *parser) callonNegIntLit1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNegIntLit1()
+}
+
+func (c *current) onCharLit1(ch any) (any, error) {
+	var value rune
+	for _, r := range ch.(string) {
+		value = r
+		break
+	}
+	return &ast.CharLiteral{Value: value, Pos: c.span()}, nil
+}
+
+func (p *parser) callonCharLit1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onCharLit1(stack["ch"])
+}
+
+func (c *current) onCharBody3() (any, error) {
+	return string(c.text), nil
+}
+
+func (p *parser) callonCharBody3() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onCharBody3()
 }
 
 func (c *current) onBoolLit2() (any, error) {
-	return &ast.BoolLiteral{Value: true}, nil
+	return &ast.BoolLiteral{Value: true, Pos: c.span()}, nil
 }
 
 func (p *parser) callonBoolLit2() (any, error) {
@@ -5354,7 +8397,7 @@ func (p *parser) callonBoolLit2() (any, error) {
 }
 
 func (c *current) onBoolLit4() (any, error) {
-	return &ast.BoolLiteral{Value: false}, nil
+	return &ast.BoolLiteral{Value: false, Pos: c.span()}, nil
 }
 
 func (p *parser) callonBoolLit4() (any, error) {
@@ -5364,7 +8407,7 @@ func (p *parser) callonBoolLit4() (any, error) {
 }
 
 func (c *current) onNullLit1() (any, error) {
-	return &ast.NullLiteral{}, nil
+	return &ast.NullLiteral{Pos: c.span()}, nil
 }
 
 func (p *parser) callonNullLit1() (any, error) {
@@ -5373,36 +8416,166 @@ func (p *parser) callonNullLit1() (any, error) {
 	return p.cur.onNullLit1()
 }
 
-func (c *current) onStringLit1(v any) (any, error) {
-	switch val := v.(type) {
-	case []interface{}:
-		runes := make([]rune, 0, len(val))
-		for _, p := range val {
-			switch t := p.(type) {
-			case rune:
-				runes = append(runes, t)
-			case uint8:
-				runes = append(runes, rune(t))
-			case []uint8:
-				for _, b := range t {
-					runes = append(runes, rune(b))
-				}
-			default:
-				return nil, fmt.Errorf("unexpected string part type %T", t)
-			}
+func (c *current) onStringLit1(parts any) (any, error) {
+	list := parts.([]interface{})
+	switch len(list) {
+	case 0:
+		return &ast.StringLiteral{Value: "", Pos: c.span()}, nil
+	case 1:
+		if lit, ok := list[0].(*ast.StringLiteral); ok {
+			return &ast.StringLiteral{Value: lit.Value, Pos: c.span()}, nil
 		}
-		return &ast.StringLiteral{Value: string(runes)}, nil
-	case []byte:
-		return &ast.StringLiteral{Value: string(val)}, nil
-	default:
-		return nil, fmt.Errorf("unexpected string literal type %T", val)
 	}
+	exprs := make([]ast.Expr, len(list))
+	for i, part := range list {
+		exprs[i] = part.(ast.Expr)
+	}
+	return &ast.StringTemplate{Parts: exprs, Pos: c.span()}, nil
 }
 
 func (p *parser) callonStringLit1() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onStringLit1(stack["v"])
+	return p.cur.onStringLit1(stack["parts"])
+}
+
+func (c *current) onStringPart2(e any) (any, error) {
+	return e, nil
+}
+
+func (p *parser) callonStringPart2() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onStringPart2(stack["e"])
+}
+
+func (c *current) onStringText1(chunks any) (any, error) {
+	var buf strings.Builder
+	for _, chunk := range chunks.([]interface{}) {
+		buf.WriteString(chunk.(string))
+	}
+	return &ast.StringLiteral{Value: buf.String(), Pos: c.span()}, nil
+}
+
+func (p *parser) callonStringText1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onStringText1(stack["chunks"])
+}
+
+func (c *current) onStringChar3() (any, error) {
+	return string(c.text), nil
+}
+
+func (p *parser) callonStringChar3() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onStringChar3()
+}
+
+func (c *current) onEscapeSeq2() (any, error) {
+	return "\n", nil
+}
+
+func (p *parser) callonEscapeSeq2() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEscapeSeq2()
+}
+
+func (c *current) onEscapeSeq4() (any, error) {
+	return "\t", nil
+}
+
+func (p *parser) callonEscapeSeq4() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEscapeSeq4()
+}
+
+func (c *current) onEscapeSeq6() (any, error) {
+	return "\r", nil
+}
+
+func (p *parser) callonEscapeSeq6() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEscapeSeq6()
+}
+
+func (c *current) onEscapeSeq8() (any, error) {
+	return "\x00", nil
+}
+
+func (p *parser) callonEscapeSeq8() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEscapeSeq8()
+}
+
+func (c *current) onEscapeSeq10() (any, error) {
+	return "\"", nil
+}
+
+func (p *parser) callonEscapeSeq10() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEscapeSeq10()
+}
+
+func (c *current) onEscapeSeq12() (any, error) {
+	return "\\", nil
+}
+
+func (p *parser) callonEscapeSeq12() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEscapeSeq12()
+}
+
+func (c *current) onEscapeSeq14() (any, error) {
+	return "$", nil
+}
+
+func (p *parser) callonEscapeSeq14() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEscapeSeq14()
+}
+
+func (c *current) onEscapeSeq16() (any, error) {
+	return "'", nil
+}
+
+func (p *parser) callonEscapeSeq16() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEscapeSeq16()
+}
+
+func (c *current) onEscapeSeq18() (any, error) {
+	digits := string(c.text[3 : len(c.text)-1])
+	code, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil || code > unicode.MaxRune || (code >= 0xD800 && code <= 0xDFFF) {
+		return "", fmt.Errorf("invalid unicode escape %s", c.text)
+	}
+	return string(rune(code)), nil
+}
+
+func (p *parser) callonEscapeSeq18() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEscapeSeq18()
+}
+
+func (c *current) onEscapeSeq24() (any, error) {
+	return "", fmt.Errorf("invalid escape sequence %s", c.text)
+}
+
+func (p *parser) callonEscapeSeq24() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEscapeSeq24()
 }
 
 func (c *current) onType1(t, q any) (any, error) {
@@ -5428,14 +8601,14 @@ func (p *parser) callonSimpleType2() (any, error) {
 	return p.cur.onSimpleType2(stack["t"])
 }
 
-func (c *current) onSimpleType14(i any) (any, error) {
+func (c *current) onSimpleType17(i any) (any, error) {
 	return i.(string), nil
 }
 
-func (p *parser) callonSimpleType14() (any, error) {
+func (p *parser) callonSimpleType17() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onSimpleType14(stack["i"])
+	return p.cur.onSimpleType17(stack["i"])
 }
 
 func (c *current) onArrayType1(t any) (any, error) {
@@ -5553,6 +8726,85 @@ func (p *parser) callonCompareOp1() (any, error) {
 	return p.cur.onCompareOp1(stack["o"])
 }
 
+func (c *current) onIterMethod1(m any) (any, error) {
+	return string(m.([]byte)), nil
+}
+
+func (p *parser) callonIterMethod1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onIterMethod1(stack["m"])
+}
+
+func (c *current) onAndOp1() (any, error) {
+	return "&&", nil
+}
+
+func (p *parser) callonAndOp1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAndOp1()
+}
+
+func (c *current) onOrOp1() (any, error) {
+	return "||", nil
+}
+
+func (p *parser) callonOrOp1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onOrOp1()
+}
+
+func (c *current) onUnaryOp2() (any, error) {
+	return "!", nil
+}
+
+func (p *parser) callonUnaryOp2() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onUnaryOp2()
+}
+
+func (c *current) onUnaryOp7() (any, error) {
+	return "-", nil
+}
+
+func (p *parser) callonUnaryOp7() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onUnaryOp7()
+}
+
+func (c *current) onDocComment1(lines any) (any, error) {
+	list := lines.([]interface{})
+	out := make([]string, len(list))
+	for i, line := range list {
+		out[i] = line.(string)
+	}
+	return strings.Join(out, "\n"), nil
+}
+
+func (p *parser) callonDocComment1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDocComment1(stack["lines"])
+}
+
+func (c *current) onDocLine1(text any) (any, error) {
+	var buf []byte
+	for _, item := range text.([]interface{}) {
+		buf = append(buf, item.([]interface{})[1].([]byte)...)
+	}
+	return strings.TrimPrefix(string(buf), " "), nil
+}
+
+func (p *parser) callonDocLine1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDocLine1(stack["text"])
+}
+
 func (c *current) onVOID1() (any, error) {
 	return "void", nil
 }
@@ -5656,7 +8908,7 @@ func (c *current) onLambdaExpr1(ret, params, block any) (any, error) {
 	if ret != nil {
 		retType = ret.(string)
 	}
-	return &ast.LambdaExpr{Params: parameters, Body: block.(*ast.Block), ReturnType: retType}, nil
+	return &ast.LambdaExpr{Params: parameters, Body: block.(*ast.Block), ReturnType: retType, Pos: c.span()}, nil
 }
 
 func (p *parser) callonLambdaExpr1() (any, error) {
@@ -5665,6 +8917,95 @@ func (p *parser) callonLambdaExpr1() (any, error) {
 	return p.cur.onLambdaExpr1(stack["ret"], stack["params"], stack["block"])
 }
 
+func (c *current) onSumTypeDecl1(name, variants any) (any, error) {
+	return &ast.SumTypeDecl{Name: name.(string), Variants: variants.([]*ast.VariantDecl), Pos: c.span()}, nil
+}
+
+func (p *parser) callonSumTypeDecl1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onSumTypeDecl1(stack["name"], stack["variants"])
+}
+
+func (c *current) onVariantList2(head, tail any) (any, error) {
+	list := []*ast.VariantDecl{head.(*ast.VariantDecl)}
+	if tail != nil {
+		for _, item := range tail.([]interface{}) {
+			parts := item.([]interface{})
+			list = append(list, parts[len(parts)-1].(*ast.VariantDecl))
+		}
+	}
+	return list, nil
+}
+
+func (p *parser) callonVariantList2() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onVariantList2(stack["head"], stack["tail"])
+}
+
+func (c *current) onVariantList14(first, tail any) (any, error) {
+	list := []*ast.VariantDecl{first.(*ast.VariantDecl)}
+	if tail != nil {
+		for _, item := range tail.([]interface{}) {
+			parts := item.([]interface{})
+			list = append(list, parts[len(parts)-1].(*ast.VariantDecl))
+		}
+	}
+	return list, nil
+}
+
+func (p *parser) callonVariantList14() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onVariantList14(stack["first"], stack["tail"])
+}
+
+func (c *current) onVariantDecl1(name, fields any) (any, error) {
+	var fieldList []*ast.VariantField
+	if fields != nil {
+		raw := fields.([]interface{})
+		fieldList = make([]*ast.VariantField, len(raw))
+		for i, item := range raw {
+			fieldList[i] = item.(*ast.VariantField)
+		}
+	}
+	return &ast.VariantDecl{Name: name.(string), Fields: fieldList, Pos: c.span()}, nil
+}
+
+func (p *parser) callonVariantDecl1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onVariantDecl1(stack["name"], stack["fields"])
+}
+
+func (c *current) onVariantFieldList1(head, tail any) (any, error) {
+	out := []interface{}{head}
+	if tail != nil {
+		for _, item := range tail.([]interface{}) {
+			parts := item.([]interface{})
+			out = append(out, parts[len(parts)-1])
+		}
+	}
+	return out, nil
+}
+
+func (p *parser) callonVariantFieldList1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onVariantFieldList1(stack["head"], stack["tail"])
+}
+
+func (c *current) onVariantField1(name, t any) (any, error) {
+	return &ast.VariantField{Name: name.(string), Type: t.(string), Pos: c.span()}, nil
+}
+
+func (p *parser) callonVariantField1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onVariantField1(stack["name"], stack["t"])
+}
+
 var (
 	// errNoRule is returned when the grammar to parse has no rule.
 	errNoRule = errors.New("grammar has no rule")
@@ -2,22 +2,19 @@ package parser
 
 import (
 	"fmt"
+	"os"
 
 	"glyph-cli/ast"
 )
 
-// ParseProgramFile wraps the generated ParseFile to return a typed AST.
+// ParseProgramFile parses the file at path into a typed AST. Syntax errors
+// are reported as Diagnostics.
 func ParseProgramFile(path string) (*ast.Program, error) {
-	result, err := ParseFile(path)
+	source, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-
-	program, ok := result.(*ast.Program)
-	if !ok {
-		return nil, fmt.Errorf("unexpected parse result type %T", result)
-	}
-	return program, nil
+	return parseProgram(path, source)
 }
 
 // ParseProgramSource parses in-memory source (used for -e inline execution).
@@ -25,9 +22,13 @@ func ParseProgramSource(name string, source string) (*ast.Program, error) {
 	if name == "" {
 		name = "<inline>"
 	}
-	result, err := Parse(name, []byte(source))
+	return parseProgram(name, []byte(source))
+}
+
+func parseProgram(name string, source []byte) (*ast.Program, error) {
+	result, err := Parse(name, source, GlobalStore("file", name))
 	if err != nil {
-		return nil, err
+		return nil, toDiagnostics(name, source, err)
 	}
 	program, ok := result.(*ast.Program)
 	if !ok {
@@ -35,3 +36,24 @@ func ParseProgramSource(name string, source string) (*ast.Program, error) {
 	}
 	return program, nil
 }
+
+// ParseProgramFileRecovering is like ParseProgramFile but keeps going after
+// syntax errors. The returned program is never nil unless the file cannot be
+// read; when the source has syntax errors it holds every declaration that
+// could still be parsed and the error is a Diagnostics listing all of them.
+func ParseProgramFileRecovering(path string) (*ast.Program, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseProgramRecovering(path, source)
+}
+
+// ParseProgramSourceRecovering is the in-memory form of
+// ParseProgramFileRecovering.
+func ParseProgramSourceRecovering(name string, source string) (*ast.Program, error) {
+	if name == "" {
+		name = "<inline>"
+	}
+	return parseProgramRecovering(name, []byte(source))
+}
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"glyph-cli/ast"
+)
+
+func TestNumericLiterals(t *testing.T) {
+	source := "fun int main() {\n  print(1_000)\n  print(0xFFFF_FFFF)\n  print(0b101L)\n  print(2.5e-1)\n  print(1.5f)\n  print('\\n')\n  0\n}\n"
+	program, err := ParseProgramSource("nums.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	var got []ast.Expr
+	for _, stmt := range program.Functions[0].Body.Statements {
+		if p, ok := stmt.(*ast.PrintStmt); ok {
+			got = append(got, p.Expr)
+		}
+	}
+	if v := got[0].(*ast.IntLiteral).Value; v != 1000 {
+		t.Fatalf("decimal with separators = %d", v)
+	}
+	if v := got[1].(*ast.IntLiteral).Value; v != -1 {
+		t.Fatalf("0xFFFF_FFFF should wrap to -1, got %d", v)
+	}
+	if v := got[2].(*ast.LongLiteral).Value; v != 5 {
+		t.Fatalf("binary long = %d", v)
+	}
+	if v := got[3].(*ast.DoubleLiteral).Value; v != 0.25 {
+		t.Fatalf("double with exponent = %v", v)
+	}
+	if v := got[4].(*ast.FloatLiteral).Value; v != 1.5 {
+		t.Fatalf("float = %v", v)
+	}
+	if v := got[5].(*ast.CharLiteral).Value; v != '\n' {
+		t.Fatalf("char = %q", v)
+	}
+}
+
+func TestIntLiteralOutOfRange(t *testing.T) {
+	_, err := ParseProgramSource("big.gly", "fun int main() {\n  print(3000000000)\n  0\n}\n")
+	diags, ok := err.(Diagnostics)
+	if !ok || !strings.Contains(diags[0].Message, "use an L suffix") {
+		t.Fatalf("expected out of range diagnostic, got %v", err)
+	}
+}
+
+func TestNegativeLiteralsFoldTheSign(t *testing.T) {
+	source := "fun int main() {\n  print(-2147483648)\n  print(-9223372036854775808L)\n  print(-1.abs())\n  0\n}\n"
+	program, err := ParseProgramSource("neg.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	stmts := program.Functions[0].Body.Statements
+	if v := stmts[0].(*ast.PrintStmt).Expr.(*ast.IntLiteral).Value; v != -2147483648 {
+		t.Fatalf("smallest int = %d", v)
+	}
+	if v := stmts[1].(*ast.PrintStmt).Expr.(*ast.LongLiteral).Value; v != -9223372036854775808 {
+		t.Fatalf("smallest long = %d", v)
+	}
+	if _, ok := stmts[2].(*ast.PrintStmt).Expr.(*ast.UnaryOp); !ok {
+		t.Fatalf("-1.abs() should negate the call, got %T", stmts[2].(*ast.PrintStmt).Expr)
+	}
+	_, err = ParseProgramSource("neg.gly", "fun int main() {\n  print(-2147483649)\n  0\n}\n")
+	diags, ok := err.(Diagnostics)
+	if !ok || !strings.Contains(diags[0].Message, "int literal -2147483649 is out of range") {
+		t.Fatalf("expected out of range diagnostic, got %v", err)
+	}
+}
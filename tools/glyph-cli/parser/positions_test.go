@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"testing"
+
+	"glyph-cli/ast"
+)
+
+func TestNodesCarrySourcePositions(t *testing.T) {
+	source := "package demo\n\nrecord User {\n  string name\n}\n\nfun int main() {\n  val x = 2 + 3\n  print(u.name)\n  x\n}\n"
+	program, err := ParseProgramSource("demo.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	text := func(pos ast.SourcePos) string {
+		return source[pos.Offset:pos.EndOffset]
+	}
+
+	if got := program.Package.Pos; got.File != "demo.gly" || got.Line != 1 || got.Column != 1 {
+		t.Fatalf("unexpected package position %+v", got)
+	}
+
+	rec := program.Records[0]
+	if rec.Pos.Line != 3 || rec.Pos.Column != 1 {
+		t.Fatalf("unexpected record position %s", rec.Pos)
+	}
+	if field := rec.Fields[0]; field.Pos.Line != 4 || field.Pos.Column != 3 {
+		t.Fatalf("unexpected record field position %s", field.Pos)
+	}
+
+	fn := program.Functions[0]
+	if fn.Pos.String() != "demo.gly:7:1" {
+		t.Fatalf("unexpected function position %s", fn.Pos)
+	}
+
+	decl := fn.Body.Statements[0].(*ast.VarDecl)
+	if decl.Pos.Line != 8 || decl.Pos.Column != 3 {
+		t.Fatalf("unexpected var decl position %s", decl.Pos)
+	}
+	sum := decl.Value.(*ast.BinaryOp)
+	if got := text(sum.Pos); got != "2 + 3" {
+		t.Fatalf("binary op spans %q", got)
+	}
+
+	print := fn.Body.Statements[1].(*ast.PrintStmt)
+	access := print.Expr.(*ast.FieldAccess)
+	if got := text(access.Position()); got != "u.name" {
+		t.Fatalf("field access spans %q", got)
+	}
+
+	last := fn.Body.Statements[2].(*ast.ExprStmt)
+	if pos := last.Expr.Position(); pos.Line != 10 || pos.Column != 3 {
+		t.Fatalf("unexpected expression position %s", pos)
+	}
+}
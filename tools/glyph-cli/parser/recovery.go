@@ -0,0 +1,190 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+
+	"glyph-cli/ast"
+)
+
+// maxRecoveries bounds how many syntax errors are collected from one file.
+const maxRecoveries = 100
+
+// Recovery levels, tried in order until the parser gets past an error.
+const (
+	skipStatement   = iota // blank the source line holding the error
+	skipDeclaration        // blank the enclosing top-level declaration
+	skipRest               // blank everything from the declaration to EOF
+)
+
+// declKeywords start a top-level declaration when found at column 1.
+var declKeywords = [][]byte{
+	[]byte("fun "),
+	[]byte("extern "),
+	[]byte("record "),
+	[]byte("type "),
+	[]byte("import "),
+	[]byte("package "),
+}
+
+// parseProgramRecovering re-runs the generated parser, blanking out the
+// region around each syntax error until the remaining source parses.
+// Blanked bytes are replaced by spaces, keeping newlines, so positions in
+// the partial AST and in the diagnostics still match the original source.
+func parseProgramRecovering(name string, source []byte) (*ast.Program, error) {
+	work := append([]byte(nil), source...)
+	var diags Diagnostics
+	anchor := -1
+	level := skipStatement
+	for attempt := 0; attempt <= maxRecoveries; attempt++ {
+		program, err := parseProgram(name, work)
+		if err == nil {
+			if len(diags) == 0 {
+				return program, nil
+			}
+			return program, diags
+		}
+		found, ok := err.(Diagnostics)
+		if !ok {
+			return nil, err
+		}
+		first := found[0]
+		if first.Offset > anchor {
+			// A new error past the previous one: report it against the
+			// original source and start again with the narrowest skip.
+			diags = append(diags, newDiagnosticFrom(first, source))
+			anchor = first.Offset
+			level = skipStatement
+			if startsDeclaration(work, lineStart(work, anchor)) || !inDeclaration(work, anchor) {
+				level = skipDeclaration
+			}
+		} else {
+			level++
+		}
+		if level > skipRest {
+			break
+		}
+		start, end := recoveryRegion(work, anchor, level)
+		if !blank(work, start, end) && level < skipRest {
+			level++
+			start, end = recoveryRegion(work, anchor, level)
+			blank(work, start, end)
+		}
+	}
+	return &ast.Program{Pos: ast.SourcePos{File: name, Line: 1, Column: 1, EndOffset: len(source)}}, diags
+}
+
+// newDiagnosticFrom rebuilds d against the original source so its snippet
+// shows the text the user wrote rather than the blanked working copy.
+func newDiagnosticFrom(d *Diagnostic, source []byte) *Diagnostic {
+	line, column, text := locate(source, d.Offset)
+	out := *d
+	out.Line, out.Column = line, column
+	if len(out.Expected) > 0 {
+		out.Message = fmt.Sprintf("unexpected %s, expected %s", describeFound(source, d.Offset), joinAlternatives(out.Expected))
+	}
+	out.Snippet = renderSnippet(line, column, text)
+	return &out
+}
+
+func recoveryRegion(src []byte, offset int, level int) (int, int) {
+	switch level {
+	case skipStatement:
+		start := lineStart(src, offset)
+		// An error reported at a line break belongs to the line it ends.
+		if offset < len(src) && src[offset] == '\n' {
+			return start, offset
+		}
+		return start, lineEnd(src, offset)
+	case skipDeclaration:
+		return declStart(src, offset), nextDeclStart(src, offset)
+	default:
+		return declStart(src, offset), len(src)
+	}
+}
+
+// blank replaces src[start:end] with spaces, keeping line breaks. It reports
+// whether anything other than whitespace was removed.
+func blank(src []byte, start, end int) bool {
+	changed := false
+	for i := start; i < end; i++ {
+		switch src[i] {
+		case '\n', '\r':
+		case ' ', '\t':
+		default:
+			src[i] = ' '
+			changed = true
+		}
+	}
+	return changed
+}
+
+func lineStart(src []byte, offset int) int {
+	if offset > len(src) {
+		offset = len(src)
+	}
+	return bytes.LastIndexByte(src[:offset], '\n') + 1
+}
+
+func lineEnd(src []byte, offset int) int {
+	if offset >= len(src) {
+		return len(src)
+	}
+	if i := bytes.IndexByte(src[offset:], '\n'); i >= 0 {
+		return offset + i
+	}
+	return len(src)
+}
+
+func startsDeclaration(src []byte, start int) bool {
+	for _, kw := range declKeywords {
+		if bytes.HasPrefix(src[start:], kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// inDeclaration reports whether offset lies after the start of some
+// top-level declaration, i.e. inside a body that statements can be skipped in.
+func inDeclaration(src []byte, offset int) bool {
+	for start := lineStart(src, offset); ; start = lineStart(src, start-1) {
+		if startsDeclaration(src, start) {
+			return true
+		}
+		if start == 0 {
+			return false
+		}
+	}
+}
+
+func declStart(src []byte, offset int) int {
+	first := lineStart(src, offset)
+	for start := first; ; start = lineStart(src, start-1) {
+		if startsDeclaration(src, start) {
+			return start
+		}
+		if start == 0 {
+			return first
+		}
+	}
+}
+
+// nextDeclStart finds the next declaration after offset, backing up over any
+// /// lines so its documentation survives the skip.
+func nextDeclStart(src []byte, offset int) int {
+	first := lineEnd(src, offset) + 1
+	for start := first; start < len(src); start = lineEnd(src, start) + 1 {
+		if startsDeclaration(src, start) {
+			for start > first {
+				prev := lineStart(src, start-1)
+				if !bytes.HasPrefix(bytes.TrimLeft(src[prev:], " \t"), []byte("///")) {
+					break
+				}
+				start = prev
+			}
+			return start
+		}
+	}
+	return len(src)
+}
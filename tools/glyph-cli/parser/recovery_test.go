@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestRecoveringParseCollectsEveryError(t *testing.T) {
+	source := `record User {
+  string name
+}
+
+fun int broken x {
+  1
+}
+
+fun int main() {
+  val a = (1 + 2
+  val b = 3
+  print(b ++ 1)
+  b
+}
+
+fun int other() {
+  4
+}
+`
+	program, err := ParseProgramSourceRecovering("multi.gly", source)
+	diags, ok := err.(Diagnostics)
+	if !ok {
+		t.Fatalf("expected Diagnostics, got %v", err)
+	}
+	wantLines := []int{5, 10, 12}
+	if len(diags) != len(wantLines) {
+		t.Fatalf("expected %d diagnostics, got %d:\n%s", len(wantLines), len(diags), diags.Format())
+	}
+	for i, line := range wantLines {
+		if diags[i].Line != line {
+			t.Fatalf("diagnostic %d at line %d, want %d", i, diags[i].Line, line)
+		}
+	}
+
+	if program == nil {
+		t.Fatalf("expected a partial program")
+	}
+	if len(program.Records) != 1 {
+		t.Fatalf("expected record to survive recovery, got %d", len(program.Records))
+	}
+	names := map[string]int{}
+	for _, fn := range program.Functions {
+		names[fn.Name] = len(fn.Body.Statements)
+	}
+	if _, ok := names["broken"]; ok {
+		t.Fatalf("broken declaration should have been skipped")
+	}
+	if names["main"] != 2 || names["other"] != 1 {
+		t.Fatalf("unexpected recovered functions %v", names)
+	}
+	if pos := program.Functions[len(program.Functions)-1].Pos; pos.Line != 16 {
+		t.Fatalf("recovered positions should match the original source, got %s", pos)
+	}
+}
+
+func TestRecoveringParseOfValidSource(t *testing.T) {
+	program, err := ParseProgramSourceRecovering("ok.gly", "fun int main() {\n  1\n}\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(program.Functions) != 1 {
+		t.Fatalf("expected 1 function, got %d", len(program.Functions))
+	}
+}
+
+func TestRecoveryResumesAtExternDeclarations(t *testing.T) {
+	source := `fun int broken x {
+  1
+}
+extern fun int width(string s)
+`
+	program, err := ParseProgramSourceRecovering("extern.gly", source)
+	diags, ok := err.(Diagnostics)
+	if !ok || len(diags) != 1 || diags[0].Line != 1 {
+		t.Fatalf("expected one diagnostic on line 1, got %v", err)
+	}
+	if program == nil || len(program.Functions) != 1 || program.Functions[0].Name != "width" || !program.Functions[0].Extern {
+		t.Fatalf("extern fun width should survive recovery, got %+v", program)
+	}
+}
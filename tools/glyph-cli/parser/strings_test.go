@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"glyph-cli/ast"
+)
+
+func TestStringTemplateParts(t *testing.T) {
+	source := "fun int main() {\n  print(\"a\\n${x}b\")\n  0\n}\n"
+	program, err := ParseProgramSource("tpl.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	print := program.Functions[0].Body.Statements[0].(*ast.PrintStmt)
+	tpl, ok := print.Expr.(*ast.StringTemplate)
+	if !ok {
+		t.Fatalf("expected StringTemplate, got %T", print.Expr)
+	}
+	if len(tpl.Parts) != 3 {
+		t.Fatalf("expected 3 parts, got %d", len(tpl.Parts))
+	}
+	if lit := tpl.Parts[0].(*ast.StringLiteral); lit.Value != "a\n" {
+		t.Fatalf("unexpected leading text %q", lit.Value)
+	}
+	if ref := tpl.Parts[1].(*ast.VarRef); source[ref.Pos.Offset:ref.Pos.EndOffset] != "x" {
+		t.Fatalf("interpolated expression spans %q", source[ref.Pos.Offset:ref.Pos.EndOffset])
+	}
+	if got := source[tpl.Pos.Offset:tpl.Pos.EndOffset]; got != `"a\n${x}b"` {
+		t.Fatalf("template spans %q", got)
+	}
+}
+
+func TestInvalidEscapeIsDiagnosed(t *testing.T) {
+	_, err := ParseProgramSource("esc.gly", "fun int main() {\n  print(\"\\q\")\n  0\n}\n")
+	diags, ok := err.(Diagnostics)
+	if !ok || len(diags) != 1 {
+		t.Fatalf("expected one diagnostic, got %v", err)
+	}
+	if diags[0].Line != 2 || !strings.Contains(diags[0].Message, `invalid escape sequence \q`) {
+		t.Fatalf("unexpected diagnostic %s", diags[0])
+	}
+}
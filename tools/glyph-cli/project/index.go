@@ -5,6 +5,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"glyph-cli/ast"
 	"glyph-cli/parser"
@@ -15,19 +16,29 @@ type Index struct {
 	Functions map[string]*ast.FunctionDecl
 	Records   map[string]*ast.RecordDecl
 	Aliases   map[string]*ast.TypeAliasDecl
+	SumTypes  map[string]*ast.SumTypeDecl
 	Programs  map[string]*ast.Program
+	// Natives, when set, backs the extern fun declarations of the project
+	// and the natives its programs import directly. Resolve passes it on
+	// in Symbols, so it is the only registry a run uses.
+	Natives Natives
 }
 
 // BuildIndex scans rootDir (and optional library directories) for .gly files.
+// Files with syntax errors are parsed in recovery mode so that the rest of
+// the project is still indexed; in that case the partial index is returned
+// together with a parser.Diagnostics error covering every file.
 func BuildIndex(rootDir string, libDirs ...string) (*Index, error) {
 	idx := &Index{
 		Functions: make(map[string]*ast.FunctionDecl),
 		Records:   make(map[string]*ast.RecordDecl),
 		Aliases:   make(map[string]*ast.TypeAliasDecl),
+		SumTypes:  make(map[string]*ast.SumTypeDecl),
 		Programs:  make(map[string]*ast.Program),
 	}
 
-	if err := scanDir(rootDir, idx); err != nil {
+	var diags parser.Diagnostics
+	if err := scanDir(rootDir, idx, &diags); err != nil {
 		return nil, err
 	}
 	for _, lib := range libDirs {
@@ -37,15 +48,18 @@ func BuildIndex(rootDir string, libDirs ...string) (*Index, error) {
 		if info, err := os.Stat(lib); err != nil || !info.IsDir() {
 			continue
 		}
-		if err := scanDir(lib, idx); err != nil {
+		if err := scanDir(lib, idx, &diags); err != nil {
 			return nil, err
 		}
 	}
 
+	if len(diags) > 0 {
+		return idx, diags
+	}
 	return idx, nil
 }
 
-func scanDir(rootDir string, idx *Index) error {
+func scanDir(rootDir string, idx *Index, diags *parser.Diagnostics) error {
 	return filepath.WalkDir(rootDir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -57,9 +71,13 @@ func scanDir(rootDir string, idx *Index) error {
 			return nil
 		}
 
-		program, parseErr := parser.ParseProgramFile(path)
+		program, parseErr := parser.ParseProgramFileRecovering(path)
 		if parseErr != nil {
-			return fmt.Errorf("parse %s: %w", path, parseErr)
+			found, ok := parseErr.(parser.Diagnostics)
+			if !ok {
+				return fmt.Errorf("parse %s: %w", path, parseErr)
+			}
+			*diags = append(*diags, found...)
 		}
 		abs, absErr := filepath.Abs(path)
 		if absErr != nil {
@@ -74,21 +92,28 @@ func scanDir(rootDir string, idx *Index) error {
 		for _, alias := range program.TypeAliases {
 			fqn := qualify(pkg, alias.Name)
 			if _, exists := idx.Aliases[fqn]; exists {
-				return fmt.Errorf("duplicate type alias %s", fqn)
+				return fmt.Errorf("%s: duplicate type alias %s", alias.Pos, fqn)
 			}
 			idx.Aliases[fqn] = alias
 		}
+		for _, sum := range program.SumTypes {
+			fqn := qualify(pkg, sum.Name)
+			if _, exists := idx.SumTypes[fqn]; exists {
+				return fmt.Errorf("%s: duplicate sum type %s", sum.Pos, fqn)
+			}
+			idx.SumTypes[fqn] = sum
+		}
 		for _, rec := range program.Records {
 			fqn := qualify(pkg, rec.Name)
 			if _, exists := idx.Records[fqn]; exists {
-				return fmt.Errorf("duplicate record %s", fqn)
+				return fmt.Errorf("%s: duplicate record %s", rec.Pos, fqn)
 			}
 			idx.Records[fqn] = rec
 		}
 		for _, fn := range program.Functions {
 			fqn := qualify(pkg, fn.Name)
 			if _, exists := idx.Functions[fqn]; exists {
-				return fmt.Errorf("duplicate function %s", fqn)
+				return fmt.Errorf("%s: duplicate function %s", fn.Pos, fqn)
 			}
 			idx.Functions[fqn] = fn
 		}
@@ -96,17 +121,50 @@ func scanDir(rootDir string, idx *Index) error {
 	})
 }
 
-// Resolve constructs the visible symbol set for the provided program.
+// Declaring returns the program of the index that declares fn, or nil.
+func (idx *Index) Declaring(fn *ast.FunctionDecl) *ast.Program {
+	for _, program := range idx.Programs {
+		for _, decl := range program.Functions {
+			if decl == fn {
+				return program
+			}
+		}
+	}
+	return nil
+}
+
+// Resolve constructs the visible symbol set for the provided program,
+// binds every visible extern fun, and any function a native replaces, to
+// its native and resolves the captures of the lambdas in every visible
+// function. The programs declaring the functions it imports are resolved
+// too, as their bodies see their own symbols; see Declaring.
 func Resolve(program *ast.Program, idx *Index) (*Symbols, error) {
 	if idx == nil {
 		return nil, fmt.Errorf("index is nil")
 	}
+	programs := make(map[*ast.FunctionDecl]*ast.Program)
+	for _, p := range idx.Programs {
+		for _, fn := range p.Functions {
+			programs[fn] = p
+		}
+	}
+	return resolve(program, idx, &resolution{programs: programs, symbols: map[*ast.Program]*Symbols{}})
+}
+
+// resolution is what the Symbols resolved from one entry program share.
+type resolution struct {
+	programs map[*ast.FunctionDecl]*ast.Program // the program declaring each indexed function
+	symbols  map[*ast.Program]*Symbols
+}
+
+func resolve(program *ast.Program, idx *Index, res *resolution) (*Symbols, error) {
 	pkg := packageName(program)
 	functions := make(map[string]*ast.FunctionDecl)
 	records := make(map[string]*ast.RecordDecl)
 	aliases := make(map[string]*ast.TypeAliasDecl)
+	sumTypes := make(map[string]*ast.SumTypeDecl)
 
-	addPackageSymbols(pkg, idx, functions, records, aliases)
+	addPackageSymbols(pkg, idx, functions, records, aliases, sumTypes)
 
 	for _, rec := range program.Records {
 		records[rec.Name] = rec
@@ -117,39 +175,89 @@ func Resolve(program *ast.Program, idx *Index) (*Symbols, error) {
 	for _, alias := range program.TypeAliases {
 		aliases[alias.Name] = alias
 	}
+	for _, sum := range program.SumTypes {
+		sumTypes[sum.Name] = sum
+	}
 
+	imported := make(map[string]string) // qualified names of imported functions
 	for _, imp := range program.Imports {
 		simple := simpleName(imp.Name)
-		if fn, ok := idx.Functions[imp.Name]; ok {
+		fn, ok := idx.Functions[imp.Name]
+		if !ok {
+			fn, ok = nativeDecl(idx.Natives, imp)
+		}
+		if ok {
 			if _, exists := functions[simple]; exists {
-				return nil, fmt.Errorf("symbol %s already defined", simple)
+				return nil, fmt.Errorf("%s: symbol %s already defined", imp.Pos, simple)
 			}
 			functions[simple] = fn
+			imported[simple] = imp.Name
 			continue
 		}
 		if rec, ok := idx.Records[imp.Name]; ok {
 			if _, exists := records[simple]; exists {
-				return nil, fmt.Errorf("symbol %s already defined", simple)
+				return nil, fmt.Errorf("%s: symbol %s already defined", imp.Pos, simple)
 			}
 			records[simple] = rec
 			continue
 		}
 		if alias, ok := idx.Aliases[imp.Name]; ok {
 			if _, exists := aliases[simple]; exists {
-				return nil, fmt.Errorf("symbol %s already defined", simple)
+				return nil, fmt.Errorf("%s: symbol %s already defined", imp.Pos, simple)
 			}
 			aliases[simple] = alias
 			continue
 		}
-		return nil, fmt.Errorf("symbol not found: %s", imp.Name)
+		if sum, ok := idx.SumTypes[imp.Name]; ok {
+			if _, exists := sumTypes[simple]; exists {
+				return nil, fmt.Errorf("%s: symbol %s already defined", imp.Pos, simple)
+			}
+			sumTypes[simple] = sum
+			continue
+		}
+		return nil, fmt.Errorf("%s: symbol not found: %s", imp.Pos, imp.Name)
 	}
 
-	return &Symbols{
+	symbols := &Symbols{
 		Package:   pkg,
 		Functions: functions,
 		Records:   records,
 		Aliases:   aliases,
-	}, nil
+		SumTypes:  sumTypes,
+		Natives:   idx.Natives,
+		res:       res,
+	}
+	res.symbols[program] = symbols
+	if err := symbols.BuildConstructors(); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(functions))
+	for name := range functions {
+		names = append(names, name)
+	}
+	sort.Strings(names) // report the first unbound extern by name
+	for _, name := range names {
+		fn := functions[name]
+		fqn, ok := imported[name]
+		if !ok {
+			fqn = qualify(pkg, name)
+		}
+		if err := symbols.bindNative(fn, fqn, idx.Natives); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range names {
+		fn := functions[name]
+		ResolveScopes(fn)
+		declaring, ok := res.programs[fn]
+		if _, done := res.symbols[declaring]; !ok || done {
+			continue
+		}
+		if _, err := resolve(declaring, idx, res); err != nil {
+			return nil, err
+		}
+	}
+	return symbols, nil
 }
 
 type Symbols struct {
@@ -157,6 +265,67 @@ type Symbols struct {
 	Functions map[string]*ast.FunctionDecl
 	Records   map[string]*ast.RecordDecl
 	Aliases   map[string]*ast.TypeAliasDecl
+	SumTypes  map[string]*ast.SumTypeDecl
+	// Constructors maps each variant name to its declaration, as
+	// BuildConstructors fills it from SumTypes.
+	Constructors map[string]Constructor
+	// Natives are those of the Index, which Resolve bound functions to and
+	// which a runtime calls them through.
+	Natives Natives
+
+	res *resolution
+}
+
+// Declaring returns the symbols the body of fn sees: those of the program
+// that declares it, which differ from s for a function imported from
+// another package. Functions outside the index, such as those of a
+// program parsed on its own, see s.
+func (s *Symbols) Declaring(fn *ast.FunctionDecl) *Symbols {
+	if s.res != nil {
+		if found, ok := s.res.symbols[s.res.programs[fn]]; ok {
+			return found
+		}
+	}
+	return s
+}
+
+// Constructor is a variant and the sum type that declares it.
+type Constructor struct {
+	Sum     *ast.SumTypeDecl
+	Variant *ast.VariantDecl
+}
+
+// Variant finds the sum type that declares the constructor name. Variant
+// constructors share the function namespace, so a visible sum type makes
+// all of its variants callable.
+func (s *Symbols) Variant(name string) (*ast.SumTypeDecl, *ast.VariantDecl, bool) {
+	ctor, ok := s.Constructors[name]
+	return ctor.Sum, ctor.Variant, ok
+}
+
+// BuildConstructors fills Constructors from SumTypes, rejecting variant
+// names that collide with a function or with a variant of another visible
+// sum type. Resolve calls it; Symbols made by hand must too.
+func (s *Symbols) BuildConstructors() error {
+	names := make([]string, 0, len(s.SumTypes))
+	for name := range s.SumTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names) // report the same conflict on every run
+	s.Constructors = make(map[string]Constructor)
+	for _, name := range names {
+		sum := s.SumTypes[name]
+		for _, variant := range sum.Variants {
+			if other, ok := s.Constructors[variant.Name]; ok && other.Sum != sum {
+				return fmt.Errorf("%s: constructor %s already defined by %s", variant.Pos, variant.Name, other.Sum.Name)
+			}
+			if _, ok := s.Functions[variant.Name]; ok {
+				return fmt.Errorf("%s: constructor %s conflicts with function %s", variant.Pos, variant.Name, variant.Name)
+			}
+			s.Constructors[variant.Name] = Constructor{Sum: sum, Variant: variant}
+		}
+	}
+	return nil
 }
 
 func packageName(program *ast.Program) string {
@@ -196,7 +365,7 @@ func lastDot(s string) int {
 	return -1
 }
 
-func addPackageSymbols(pkg string, idx *Index, functions map[string]*ast.FunctionDecl, records map[string]*ast.RecordDecl, aliases map[string]*ast.TypeAliasDecl) {
+func addPackageSymbols(pkg string, idx *Index, functions map[string]*ast.FunctionDecl, records map[string]*ast.RecordDecl, aliases map[string]*ast.TypeAliasDecl, sumTypes map[string]*ast.SumTypeDecl) {
 	for fqn, fn := range idx.Functions {
 		if packagePart(fqn) != pkg {
 			continue
@@ -227,4 +396,14 @@ func addPackageSymbols(pkg string, idx *Index, functions map[string]*ast.Functio
 		}
 		aliases[name] = alias
 	}
+	for fqn, sum := range idx.SumTypes {
+		if packagePart(fqn) != pkg {
+			continue
+		}
+		name := simpleName(fqn)
+		if _, exists := sumTypes[name]; exists {
+			continue
+		}
+		sumTypes[name] = sum
+	}
 }
@@ -0,0 +1,179 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"glyph-cli/ast"
+	"glyph-cli/parser"
+)
+
+func TestSumTypesAreIndexedAndImportable(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, source string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("shapes/shapes.gly", "package demo.shapes\n\ntype Shape = Circle(r: int) | Square(s: int)\n")
+	write("app/main.gly", "package demo.app\n\nimport demo.shapes.Shape\n\nfun int main() {\n  0\n}\n")
+
+	idx, err := BuildIndex(dir)
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	if _, ok := idx.SumTypes["demo.shapes.Shape"]; !ok {
+		t.Fatalf("sum type missing from index: %v", idx.SumTypes)
+	}
+
+	program, err := parser.ParseProgramFile(filepath.Join(dir, "app/main.gly"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	symbols, err := Resolve(program, idx)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	sum, variant, ok := symbols.Variant("Square")
+	if !ok || sum.Name != "Shape" || variant.Fields[0].Name != "s" {
+		t.Fatalf("Square constructor not visible through the import")
+	}
+	if !symbols.Accepts("Shape?", "Shape") || symbols.Accepts("Shape", "null") {
+		t.Fatalf("unexpected Accepts results for Shape")
+	}
+}
+
+func TestImportedFunctionsSeeTheirOwnPackage(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, source string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("model/model.gly", "package demo.model\n\nrecord Address {\n  string city\n}\n")
+	write("data/seed.gly", "package demo.data\n\nimport demo.model.Address\n\nfun string home() {\n  city(Address { city = \"Lisbon\" })\n}\n\nfun string city(Address a) {\n  a.city\n}\n")
+	write("app/main.gly", "package demo.app\n\nimport demo.data.home\n\nfun int main() {\n  print(home())\n  0\n}\n")
+
+	idx, err := BuildIndex(dir)
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	symbols, err := Resolve(idx.Programs[filepath.Join(dir, "app/main.gly")], idx)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if _, ok := symbols.Records["Address"]; ok {
+		t.Fatalf("main should not see Address")
+	}
+	home := symbols.Functions["home"]
+	seed := symbols.Declaring(home)
+	if _, ok := seed.Records["Address"]; !ok {
+		t.Fatalf("home should see the Address its package imports")
+	}
+	if _, ok := seed.Functions["city"]; !ok {
+		t.Fatalf("home should see the functions of its package")
+	}
+	if symbols.Declaring(symbols.Functions["main"]) != symbols {
+		t.Fatalf("main should see the symbols of its own program")
+	}
+}
+
+func TestConstructorConflictsWithFunction(t *testing.T) {
+	dir := t.TempDir()
+	source := "type Shape = Circle(r: int)\n\nfun int Circle(int r) {\n  r\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "main.gly"), []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	idx, err := BuildIndex(dir)
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	program, err := parser.ParseProgramSource("main.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if _, err := Resolve(program, idx); err == nil {
+		t.Fatalf("expected constructor/function conflict")
+	}
+}
+
+func TestConstructorConflictsAreReportedInOrder(t *testing.T) {
+	sumTypes := map[string]*ast.SumTypeDecl{}
+	for _, name := range []string{"Delta", "Alpha", "Charlie", "Bravo"} {
+		sumTypes[name] = &ast.SumTypeDecl{Name: name, Variants: []*ast.VariantDecl{{Name: "Shared"}}}
+	}
+	for i := 0; i < 20; i++ {
+		symbols := &Symbols{SumTypes: sumTypes}
+		err := symbols.BuildConstructors()
+		if err == nil || !strings.Contains(err.Error(), "constructor Shared already defined by Alpha") {
+			t.Fatalf("got %v, want Bravo's Shared to conflict with Alpha's", err)
+		}
+	}
+}
+
+// natives is a registry of signatures written as "result(params)".
+type natives map[string][]string
+
+func (n natives) Signature(name string) ([]string, string, bool) {
+	sig, ok := n[name]
+	if !ok {
+		return nil, "", false
+	}
+	return sig[1:], sig[0], true
+}
+
+func TestResolveBindsExternFunctions(t *testing.T) {
+	registered := natives{
+		"demo.text.length": {"int", "string"},
+		"demo.text.repeat": {"string", "string", "int"},
+	}
+	cases := []struct {
+		name, source, want string
+	}{
+		{"declared", "package demo.text\n\nextern fun int length(string s)\n", ""},
+		{"alias", "package demo.text\n\ntype Text = string\n\nextern fun int length(Text s)\n", ""},
+		{"imported", "package demo.app\n\nimport demo.text.repeat\n", ""},
+		{"unregistered", "package demo.text\n\nextern fun int width(string s)\n", "no native is registered for extern fun demo.text.width"},
+		{"mismatch", "package demo.text\n\nextern fun long length(string s)\n", "extern fun demo.text.length is declared long(string) but the native is int(string)"},
+		{"missing import", "package demo.app\n\nimport demo.text.width\n", "symbol not found: demo.text.width"},
+		{"replaced body", "package demo.text\n\nfun int length(string s) {\n  0\n}\n\nfun int width(string s) {\n  0\n}\n", ""},
+		{"replaced mismatch", "package demo.text\n\nfun long length(string s) {\n  0L\n}\n", "fun demo.text.length is declared long(string) but the native is int(string)"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := parser.ParseProgramSource("main.gly", tc.source)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			symbols, err := Resolve(program, &Index{Natives: registered})
+			if tc.want != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.want) {
+					t.Fatalf("got error %v, want %q", err, tc.want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolve: %v", err)
+			}
+			for name, fn := range symbols.Functions {
+				want := ""
+				if _, ok := registered["demo.text."+name]; ok {
+					want = "demo.text." + name
+				}
+				if fn.Native != want || !fn.Resolved {
+					t.Fatalf("%s is bound to %q, want %q", name, fn.Native, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+
+	"glyph-cli/ast"
+)
+
+// LookupMember resolves the member call `recv.name(...)` to the visible free
+// function name, provided its first parameter accepts a receiver of type
+// receiverType.
+func (s *Symbols) LookupMember(name, receiverType string) (*ast.FunctionDecl, error) {
+	fn, ok := s.Functions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s", name)
+	}
+	if len(fn.Params) == 0 {
+		return nil, fmt.Errorf("function %s takes no parameters and cannot be called as a member", name)
+	}
+	if !s.Accepts(fn.Params[0].Type, receiverType) {
+		return nil, fmt.Errorf("function %s expects %s as its first parameter, not %s", name, fn.Params[0].Type, receiverType)
+	}
+	return fn, nil
+}
+
+// Accepts reports whether a value of type actual may be passed where
+// declared is expected. Type aliases are expanded and a trailing ? admits
+// null, and numbers widen as Widens allows. Runtime values only know they
+// are an "array" or a "map", so those match any array or map type.
+func (s *Symbols) Accepts(declared, actual string) bool {
+	declared = s.ExpandAlias(declared)
+	nullable := strings.HasSuffix(declared, "?")
+	declared = strings.TrimSuffix(declared, "?")
+	switch {
+	case actual == "null":
+		return nullable
+	case declared == actual, Widens(actual, declared):
+		return true
+	case strings.HasPrefix(declared, "["):
+		_, _, isMap := MapType(declared)
+		return (isMap && actual == "map") || (!isMap && actual == "array")
+	default:
+		return false
+	}
+}
+
+// Widens reports whether a value of the primitive type from may be used
+// where the primitive type to is expected: a char as an int, an int or
+// char as a long, and any number as a float or double.
+func Widens(from, to string) bool {
+	numeric := from == "int" || from == "long" || from == "float" || from == "double" || from == "char"
+	switch to {
+	case "int":
+		return from == "char"
+	case "long":
+		return from == "int" || from == "char"
+	case "float", "double":
+		return numeric
+	}
+	return false
+}
+
+// ExpandAlias replaces a type alias by the type it stands for, keeping a
+// trailing ?. Other types are returned as they are.
+func (s *Symbols) ExpandAlias(typeName string) string {
+	// Bounded by the number of aliases so that a cycle cannot loop forever.
+	for seen := 0; seen < len(s.Aliases); seen++ {
+		nullable := strings.HasSuffix(typeName, "?")
+		alias, ok := s.Aliases[strings.TrimSuffix(typeName, "?")]
+		if !ok {
+			break
+		}
+		typeName = alias.TargetType
+		if nullable && !strings.HasSuffix(typeName, "?") {
+			typeName += "?"
+		}
+	}
+	return typeName
+}
@@ -0,0 +1,86 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+
+	"glyph-cli/ast"
+)
+
+// Natives is the set of Go functions a host offers Glyph programs, by
+// qualified name. An `extern fun` is bound to the native registered under
+// its own qualified name, and a program may import a native that no .gly
+// file declares. A function written in Glyph is bound too when a native
+// has its name, so that a library shared with other toolchains can keep a
+// Glyph body that this host replaces.
+type Natives interface {
+	// Signature reports the Glyph types of the native's parameters and
+	// result, or false when no native is registered as name.
+	Signature(name string) (params []string, result string, ok bool)
+}
+
+// nativeDecl declares the native that imp names, as if a .gly file had
+// declared it extern.
+func nativeDecl(natives Natives, imp *ast.ImportDecl) (*ast.FunctionDecl, bool) {
+	if natives == nil {
+		return nil, false
+	}
+	types, result, ok := natives.Signature(imp.Name)
+	if !ok {
+		return nil, false
+	}
+	params := make([]*ast.Param, len(types))
+	for i, t := range types {
+		params[i] = &ast.Param{Name: fmt.Sprintf("arg%d", i), Type: t, Pos: imp.Pos}
+	}
+	return &ast.FunctionDecl{
+		Name:       simpleName(imp.Name),
+		Params:     params,
+		ReturnType: result,
+		Extern:     true,
+		Pos:        imp.Pos,
+	}, true
+}
+
+// bindNative binds fn, whose qualified name is fqn, to the native of that
+// name. Both must take and return the same types. An extern fun must have
+// a native; any other function keeps its body when there is none.
+func (s *Symbols) bindNative(fn *ast.FunctionDecl, fqn string, natives Natives) error {
+	var (
+		params []string
+		result string
+		ok     bool
+	)
+	if natives != nil {
+		params, result, ok = natives.Signature(fqn)
+	}
+	if !ok {
+		if fn.Extern {
+			return fmt.Errorf("%s: no native is registered for extern fun %s", fn.Pos, fqn)
+		}
+		fn.Native = ""
+		return nil
+	}
+	declared := make([]string, len(fn.Params))
+	for i, param := range fn.Params {
+		declared[i] = s.ExpandAlias(param.Type)
+	}
+	if s.ExpandAlias(fn.ReturnType) != result || strings.Join(declared, ", ") != strings.Join(params, ", ") {
+		kind := "fun"
+		if fn.Extern {
+			kind = "extern fun"
+		}
+		return fmt.Errorf("%s: %s %s is declared %s(%s) but the native is %s(%s)",
+			fn.Pos, kind, fqn, fn.ReturnType, joinParamTypes(fn.Params), result, strings.Join(params, ", "))
+	}
+	fn.Native = fqn
+	return nil
+}
+
+func joinParamTypes(params []*ast.Param) string {
+	types := make([]string, len(params))
+	for i, param := range params {
+		types[i] = param.Type
+	}
+	return strings.Join(types, ", ")
+}
@@ -0,0 +1,332 @@
+package project
+
+import (
+	"sort"
+
+	"glyph-cli/ast"
+)
+
+// ResolveScopes resolves every local variable of fn to a slot, so a
+// backend can keep a scope's variables in an array instead of looking them
+// up by name:
+//
+//   - A call's scope holds the parameters, then the variables the body
+//     declares outside nested blocks (FunctionDecl.Slots in all).
+//   - A block that declares variables opens a scope of Block.Slots; one
+//     that declares none shares the scope around it. The body of an
+//     iteration shares the scope that binds `it`.
+//   - A match case with pattern variables opens a scope for them, so they
+//     are gone once the case has run.
+//   - Each VarRef, CallExpr callee and VarDecl records its slot. A name
+//     declared again in the same scope reuses the slot, while one declared
+//     in an inner scope shadows the outer variable until the scope ends.
+//     Assigning an undeclared name declares a var in the innermost scope.
+//
+// A lambda closes over only the variables its body uses. LambdaExpr.Captures
+// lists them in order of first use, and CaptureSlots says where each is
+// found when the lambda is created. A call of the lambda gets a fresh scope
+// of the parameters, the body's variables and then copies of the captured
+// values, so assignments to a captured variable are not seen by the
+// enclosing scope or by later calls. A lambda nested in another adds its
+// captures to the outer one, which carries them to where the inner lambda
+// is created. Lambdas that capture nothing get an empty, non-nil Captures.
+func ResolveScopes(fn *ast.FunctionDecl) {
+	r := &scopeResolver{}
+	frame := r.push()
+	for _, param := range fn.Params {
+		r.declare(param.Name, "parameter")
+	}
+	r.body(fn.Body)
+	r.pop()
+	fn.Slots = frame.size
+	fn.Resolved = true
+}
+
+type binding struct {
+	index int
+	kind  string
+}
+
+type resolverScope struct {
+	names map[string]binding
+	size  int
+	depth int // number of enclosing lambdas
+}
+
+// lambdaFrame tracks a lambda being resolved. Its scope is the one its
+// calls get.
+type lambdaFrame struct {
+	expr     *ast.LambdaExpr
+	scope    int
+	captured map[string]int
+	// refs are the slots that refer to captures by their position in
+	// Captures; they are moved past the body's variables once those are
+	// known.
+	refs []*ast.Slot
+}
+
+type scopeResolver struct {
+	scopes  []*resolverScope
+	lambdas []*lambdaFrame // innermost last
+}
+
+func (r *scopeResolver) push() *resolverScope {
+	s := &resolverScope{names: map[string]binding{}, depth: len(r.lambdas)}
+	r.scopes = append(r.scopes, s)
+	return s
+}
+
+func (r *scopeResolver) pop() {
+	r.scopes = r.scopes[:len(r.scopes)-1]
+}
+
+// declare binds name in the innermost scope and returns its slot index.
+func (r *scopeResolver) declare(name, kind string) int {
+	s := r.scopes[len(r.scopes)-1]
+	b, ok := s.names[name]
+	if !ok {
+		b.index = s.size
+		s.size++
+	}
+	b.kind = kind
+	s.names[name] = b
+	return b.index
+}
+
+// lookup returns the index in scopes of the scope that declares name, or
+// -1 for a name that is not a local variable, such as a function.
+func (r *scopeResolver) lookup(name string) (int, binding) {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if b, ok := r.scopes[i].names[name]; ok {
+			return i, b
+		}
+	}
+	return -1, binding{}
+}
+
+// use resolves a reference to name from the innermost scope, capturing it
+// in every lambda between its declaration and the reference. It returns
+// nil when name is not a local variable.
+func (r *scopeResolver) use(name string) *ast.Slot {
+	at, b := r.lookup(name)
+	if at < 0 {
+		return nil
+	}
+	index := b.index
+	var owner *lambdaFrame
+	for _, l := range r.lambdas[r.scopes[at].depth:] {
+		i, seen := l.captured[name]
+		if !seen {
+			i = len(l.expr.Captures)
+			l.captured[name] = i
+			l.expr.Captures = append(l.expr.Captures, name)
+			l.expr.CaptureSlots = append(l.expr.CaptureSlots, newSlot(l.scope-1-at, index, b.kind, owner))
+		}
+		at, index, owner = l.scope, i, l
+	}
+	return newSlot(len(r.scopes)-1-at, index, b.kind, owner)
+}
+
+// newSlot makes a slot; owner is the lambda whose capture it refers to, if
+// any.
+func newSlot(depth, index int, kind string, owner *lambdaFrame) *ast.Slot {
+	s := &ast.Slot{Depth: depth, Index: index, Kind: kind}
+	if owner != nil {
+		owner.refs = append(owner.refs, s)
+	}
+	return s
+}
+
+func (r *scopeResolver) lambda(ex *ast.LambdaExpr) {
+	ex.Captures = []string{}
+	ex.CaptureSlots = nil
+	l := &lambdaFrame{expr: ex, scope: len(r.scopes), captured: map[string]int{}}
+	r.lambdas = append(r.lambdas, l)
+	frame := r.push()
+	for _, param := range ex.Params {
+		r.declare(param.Name, "parameter")
+	}
+	r.body(ex.Body)
+	r.pop()
+	r.lambdas = r.lambdas[:len(r.lambdas)-1]
+	for _, s := range l.refs {
+		s.Index += frame.size
+	}
+	ex.Slots = frame.size + len(ex.Captures)
+}
+
+// body resolves a block that shares the innermost scope.
+func (r *scopeResolver) body(b *ast.Block) {
+	if b == nil {
+		return
+	}
+	b.Slots = 0
+	for _, stmt := range b.Statements {
+		r.stmt(stmt)
+	}
+}
+
+// block resolves a block, in a scope of its own if it declares variables.
+func (r *scopeResolver) block(b *ast.Block) {
+	if b == nil || !r.declares(b) {
+		r.body(b)
+		return
+	}
+	s := r.push()
+	r.body(b)
+	r.pop()
+	b.Slots = s.size
+}
+
+// declares reports whether running b declares a variable in b's scope.
+func (r *scopeResolver) declares(b *ast.Block) bool {
+	for _, stmt := range b.Statements {
+		switch s := stmt.(type) {
+		case *ast.VarDecl:
+			return true
+		case *ast.AssignStmt:
+			if r.declaresTarget(s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// declaresTarget reports whether s assigns a name that is not yet declared.
+func (r *scopeResolver) declaresTarget(s *ast.AssignStmt) bool {
+	ref, ok := s.Target.(*ast.VarRef)
+	if !ok || s.Op != "" {
+		return false
+	}
+	at, _ := r.lookup(ref.Name)
+	return at < 0
+}
+
+func (r *scopeResolver) stmt(stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case *ast.VarDecl:
+		r.expr(s.Value)
+		s.Slot = r.declare(s.Name, s.Mutability)
+	case *ast.AssignStmt:
+		r.expr(s.Value)
+		if r.declaresTarget(s) {
+			ref := s.Target.(*ast.VarRef)
+			ref.Slot = &ast.Slot{Index: r.declare(ref.Name, "var"), Kind: "var"}
+			return
+		}
+		r.expr(s.Target)
+	case *ast.IncDecStmt:
+		r.expr(s.Target)
+	case *ast.PrintStmt:
+		r.expr(s.Expr)
+	case *ast.ExprStmt:
+		r.expr(s.Expr)
+	case *ast.ReturnStmt:
+		r.expr(s.Expr)
+	case *ast.WhileStmt:
+		r.expr(s.Condition)
+		r.block(s.Body)
+	}
+}
+
+func (r *scopeResolver) expr(e ast.Expr) {
+	switch ex := e.(type) {
+	case *ast.VarRef:
+		ex.Slot = r.use(ex.Name)
+	case *ast.CallExpr:
+		// A local holding a lambda shadows functions of the same name.
+		ex.Slot = r.use(ex.Callee)
+		r.exprs(ex.Arguments)
+	case *ast.LambdaExpr:
+		r.lambda(ex)
+	case *ast.IfExpr:
+		r.expr(ex.Condition)
+		r.block(ex.ThenBlock)
+		r.block(ex.ElseBlock)
+	case *ast.IterateExpr:
+		r.expr(ex.Target)
+		s := r.push()
+		r.declare("it", "parameter")
+		r.body(ex.Body)
+		r.pop()
+		ex.Slots = s.size
+	case *ast.MatchExpr:
+		r.expr(ex.Target)
+		for _, mc := range ex.Cases {
+			r.matchCase(mc)
+		}
+		r.expr(ex.ElseExpr)
+	case *ast.StringTemplate:
+		r.exprs(ex.Parts)
+	case *ast.BinaryOp:
+		r.expr(ex.Left)
+		r.expr(ex.Right)
+	case *ast.UnaryOp:
+		r.expr(ex.Operand)
+	case *ast.TernaryExpr:
+		r.expr(ex.Condition)
+		r.expr(ex.IfTrue)
+		r.expr(ex.IfFalse)
+	case *ast.ElvisExpr:
+		r.expr(ex.Left)
+		r.expr(ex.Right)
+	case *ast.RecordLiteral:
+		names := make([]string, 0, len(ex.Fields))
+		for name := range ex.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			r.expr(ex.Fields[name])
+		}
+	case *ast.FieldAccess:
+		r.expr(ex.Target)
+	case *ast.SafeFieldAccess:
+		r.expr(ex.Target)
+	case *ast.IndexAccess:
+		r.expr(ex.Target)
+		r.expr(ex.Index)
+	case *ast.ArrayAllocExpr:
+		r.expr(ex.Size)
+	case *ast.MapAllocExpr:
+		r.expr(ex.Capacity)
+	case *ast.MapLiteralExpr:
+		for _, entry := range ex.Entries {
+			r.expr(entry.Key)
+			r.expr(entry.Value)
+		}
+	case *ast.MethodCall:
+		r.expr(ex.Receiver)
+		r.exprs(ex.Arguments)
+	}
+}
+
+func (r *scopeResolver) exprs(list []ast.Expr) {
+	for _, e := range list {
+		r.expr(e)
+	}
+}
+
+// matchCase binds the pattern variables of mc in a scope of their own.
+func (r *scopeResolver) matchCase(mc *ast.MatchCase) {
+	var vars []*ast.VarPattern
+	ast.Inspect(mc.Pattern, func(n ast.Node) bool {
+		if v, ok := n.(*ast.VarPattern); ok {
+			vars = append(vars, v)
+		}
+		return true
+	})
+	if len(vars) == 0 {
+		mc.Slots = 0
+		r.expr(mc.Value)
+		return
+	}
+	s := r.push()
+	for _, v := range vars {
+		v.Slot = r.declare(v.Name, "pattern variable")
+	}
+	r.expr(mc.Value)
+	r.pop()
+	mc.Slots = s.size
+}
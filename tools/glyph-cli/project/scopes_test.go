@@ -0,0 +1,158 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"glyph-cli/ast"
+	"glyph-cli/parser"
+)
+
+// lambdaCaptures resolves the scopes of program's functions and lists
+// them per lambda in source order, as "name1,name2".
+func lambdaCaptures(t *testing.T, program *ast.Program) []string {
+	t.Helper()
+	var out []string
+	for _, fn := range program.Functions {
+		ResolveScopes(fn)
+		ast.Inspect(fn, func(n ast.Node) bool {
+			if lambda, ok := n.(*ast.LambdaExpr); ok {
+				if lambda.Captures == nil {
+					t.Fatalf("lambda at %v left unresolved", lambda.Pos)
+				}
+				out = append(out, strings.Join(lambda.Captures, ","))
+			}
+			return true
+		})
+	}
+	return out
+}
+
+func TestClosurePlaygroundCaptures(t *testing.T) {
+	path := filepath.Join("..", "..", "..", "examples", "closure-playground", "src", "main", "glyph", "com", "example", "closures", "main.gly")
+	source, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	program, err := parser.ParseProgramSource("main.gly", string(source))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := []string{
+		"globalOffset",               // calibrate, for injectOffset
+		"globalOffset,baselineValue", // shift
+		"globalOffset",               // injectOffset
+		"globalOffset",               // pipeline
+		"globalOffset",               // first
+		"",                           // second
+		"",                           // doubleIt
+		"globalOffset",               // third
+		"globalOffset",               // dampen
+		"globalOffset",               // nested
+		"seedValue,globalOffset",     // stage
+		"seedValue",                  // localBoost
+	}
+	if got := lambdaCaptures(t, program); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("got %q\nwant %q", got, want)
+	}
+}
+
+func TestCapturesFollowScoping(t *testing.T) {
+	source := `fun void main(int n) {
+  val xs = [int](1000)
+  var count = 0
+  val twice = fun int (int x) { x * 2 }
+  val f = fun void () {
+    count++
+    print(twice(n))
+    val xs = 1
+    print(xs)
+    fresh = 1
+    print(fresh)
+  }
+  val g = fun void () {
+    xs.each { print(it) }
+    match n {
+      xs -> xs
+    } else 0
+    if true {
+      val later = 1
+    }
+    print(later)
+  }
+}
+`
+	program, err := parser.ParseProgramSource("main.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := []string{"", "count,twice,n", "xs,n"}
+	if got := lambdaCaptures(t, program); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("got %q\nwant %q", got, want)
+	}
+}
+
+func TestSlotsFollowScoping(t *testing.T) {
+	source := `fun void main(int n) {
+  val a = 1
+  val a = 2
+  if true {
+    print(a)
+  }
+  if true {
+    val b = n
+    print(b)
+  }
+  val f = fun int (int x) {
+    val y = x + a
+    fun () { n + y }
+  }
+}
+`
+	program, err := parser.ParseProgramSource("main.gly", source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	fn := program.Functions[0]
+	ResolveScopes(fn)
+	if !fn.Resolved || fn.Slots != 3 {
+		t.Fatalf("function frame: resolved %v, %d slots", fn.Resolved, fn.Slots)
+	}
+	var refs []string
+	var lambdas []*ast.LambdaExpr
+	var blocks []int
+	ast.Inspect(fn, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.VarRef:
+			refs = append(refs, fmt.Sprintf("%s@%d:%d", n.Name, n.Slot.Depth, n.Slot.Index))
+		case *ast.LambdaExpr:
+			lambdas = append(lambdas, n)
+		case *ast.IfExpr:
+			blocks = append(blocks, n.ThenBlock.Slots)
+		}
+		return true
+	})
+	want := []string{"a@0:1", "n@1:0", "b@0:0", "x@0:0", "a@0:2", "n@0:0", "y@0:1"}
+	if strings.Join(refs, " ") != strings.Join(want, " ") {
+		t.Errorf("got slots %q\nwant %q", refs, want)
+	}
+	if len(blocks) != 2 || blocks[0] != 0 || blocks[1] != 1 {
+		t.Errorf("got block sizes %v, want [0 1]", blocks)
+	}
+	captures := func(l *ast.LambdaExpr) string {
+		var out []string
+		for _, s := range l.CaptureSlots {
+			out = append(out, fmt.Sprintf("%d:%d", s.Depth, s.Index))
+		}
+		return fmt.Sprintf("%d slots, captures %s", l.Slots, strings.Join(out, " "))
+	}
+	if got := captures(lambdas[0]); got != "4 slots, captures 0:1 0:0" {
+		t.Errorf("outer lambda: %s", got)
+	}
+	if got := captures(lambdas[1]); got != "2 slots, captures 0:3 0:1" {
+		t.Errorf("inner lambda: %s", got)
+	}
+}
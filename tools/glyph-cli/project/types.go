@@ -0,0 +1,51 @@
+package project
+
+import "strings"
+
+// ArrayType returns the element type of the array type [E].
+func ArrayType(t string) (elem string, ok bool) {
+	inner, ok := brackets(t)
+	if !ok || topLevelColon(inner) >= 0 {
+		return "", false
+	}
+	return inner, true
+}
+
+// MapType returns the key and value types of the map type [K: V].
+func MapType(t string) (key, value string, ok bool) {
+	inner, ok := brackets(t)
+	if !ok {
+		return "", "", false
+	}
+	colon := topLevelColon(inner)
+	if colon < 0 {
+		return "", "", false
+	}
+	return inner[:colon], inner[colon+1:], true
+}
+
+func brackets(t string) (string, bool) {
+	if !strings.HasPrefix(t, "[") || !strings.HasSuffix(t, "]") {
+		return "", false
+	}
+	return t[1 : len(t)-1], true
+}
+
+// topLevelColon finds the ':' separating the key and value of a map type,
+// skipping any nested inside the key, or returns -1.
+func topLevelColon(s string) int {
+	depth := 0
+	for i, r := range s {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ':':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
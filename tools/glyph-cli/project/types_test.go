@@ -0,0 +1,22 @@
+package project
+
+import "testing"
+
+func TestCollectionTypesSplitAtTheTopLevel(t *testing.T) {
+	if key, value, ok := MapType("[[int]:[string: int]]"); !ok || key != "[int]" || value != "[string: int]" {
+		t.Fatalf("MapType = %q, %q, %v", key, value, ok)
+	}
+	if elem, ok := ArrayType("[[string: int]]"); !ok || elem != "[string: int]" {
+		t.Fatalf("ArrayType = %q, %v", elem, ok)
+	}
+	if _, ok := ArrayType("[string: int]"); ok {
+		t.Fatal("a map type is not an array type")
+	}
+	if _, _, ok := MapType("int"); ok {
+		t.Fatal("int is not a map type")
+	}
+	s := &Symbols{}
+	if !s.Accepts("[[string: int]]", "array") || s.Accepts("[[string: int]]", "map") {
+		t.Fatal("an array of maps should accept an array, not a map")
+	}
+}
@@ -0,0 +1,601 @@
+// Package typecheck statically checks a parsed Glyph program before it is
+// run: call arity and argument types, record fields, conditions, operators,
+// assignments and missing returns. It mirrors the rules of the Groovy
+// TypeChecker, adjusted to what the Go interpreter accepts at runtime.
+package typecheck
+
+import (
+	"fmt"
+	"strings"
+
+	"glyph-cli/ast"
+	"glyph-cli/project"
+)
+
+// Check type-checks the declarations and function bodies of program against
+// the resolved symbols. Declarations that program imports are only consulted
+// for their signatures. The returned error is an Errors listing every
+// problem found, or nil when the program is well typed. Like Infer, Check
+// fills in the types that untyped declarations leave out.
+func Check(program *ast.Program, symbols *project.Symbols) error {
+	_, err := Infer(program, symbols)
+	return err
+}
+
+func check(program *ast.Program, symbols *project.Symbols) (*checker, error) {
+	if symbols == nil {
+		return nil, fmt.Errorf("symbols must not be nil")
+	}
+	c := &checker{
+		symbols:       symbols,
+		signatures:    map[*ast.FunctionDecl]Function{},
+		mapLookups:    map[*ast.IndexAccess]bool{},
+		narrowedReads: map[ast.Expr]bool{},
+		exhaustive:    map[*ast.MatchExpr]bool{},
+		reported:      map[string]bool{},
+		inferred:      &Inferred{Vars: map[*ast.VarDecl]TypeRef{}, Lambdas: map[*ast.LambdaExpr]TypeRef{}},
+	}
+	for _, alias := range program.TypeAliases {
+		c.resolveType(alias.TargetType, alias)
+	}
+	for _, rec := range program.Records {
+		for _, field := range rec.Fields {
+			c.resolveType(field.Type, field)
+		}
+	}
+	for _, sum := range program.SumTypes {
+		for _, variant := range sum.Variants {
+			for _, field := range variant.Fields {
+				c.resolveType(field.Type, field)
+			}
+		}
+	}
+	for _, fn := range program.Functions {
+		c.checkFunction(fn)
+	}
+	if len(c.errs) > 0 {
+		return c, c.errs
+	}
+	return c, nil
+}
+
+type checker struct {
+	symbols       *project.Symbols
+	signatures    map[*ast.FunctionDecl]Function
+	mapLookups    map[*ast.IndexAccess]bool // index expressions that read a map
+	narrowedReads map[ast.Expr]bool         // reads narrowed from T? to T
+	exhaustive    map[*ast.MatchExpr]bool   // matches that cover every value
+	inferred      *Inferred
+	fn            *funcContext
+	errs          Errors
+	reported      map[string]bool
+}
+
+// funcContext tracks the function or lambda whose body is being checked.
+type funcContext struct {
+	name      string  // function name, "" for a lambda
+	ret       TypeRef // declared return type, nil while inferring a lambda's
+	returns   []TypeRef
+	sawReturn bool
+	loops     int
+}
+
+// errorf records an error at node. The same message at the same position
+// is only reported once, since signatures are resolved at every call.
+func (c *checker) errorf(node ast.Node, format string, args ...interface{}) {
+	e := &Error{Pos: node.Position(), Message: fmt.Sprintf(format, args...)}
+	key := e.Error()
+	if c.reported[key] {
+		return
+	}
+	c.reported[key] = true
+	c.errs = append(c.errs, e)
+}
+
+// scope mirrors the interpreter's environment chain. facts holds the
+// paths known to be non-null in this scope (see nullness.go).
+type scope struct {
+	vars map[string]TypeRef
+	// immutable holds the kind of binding ("val", "const", "parameter" or
+	// "pattern variable") of each name in vars that cannot be reassigned.
+	immutable map[string]string
+	facts     map[string]bool
+	parent    *scope
+}
+
+func newScope(parent *scope) *scope {
+	return &scope{vars: map[string]TypeRef{}, parent: parent}
+}
+
+func (s *scope) lookup(name string) (TypeRef, bool) {
+	for cur := s; cur != nil; cur = cur.parent {
+		if t, ok := cur.vars[name]; ok {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// define binds name in s like the interpreter's environment.define: kind
+// is "var" for a reassignable binding.
+func (s *scope) define(name string, t TypeRef, kind string) {
+	s.vars[name] = t
+	if kind == "var" {
+		delete(s.immutable, name)
+		return
+	}
+	if s.immutable == nil {
+		s.immutable = map[string]string{}
+	}
+	s.immutable[name] = kind
+}
+
+// immutableKind returns the kind of binding name refers to if it cannot be
+// reassigned.
+func (s *scope) immutableKind(name string) (string, bool) {
+	for cur := s; cur != nil; cur = cur.parent {
+		if _, ok := cur.vars[name]; ok {
+			kind, imm := cur.immutable[name]
+			return kind, imm
+		}
+	}
+	return "", false
+}
+
+func (c *checker) checkFunction(fn *ast.FunctionDecl) {
+	sig := c.signature(fn)
+	if fn.Extern {
+		return // no body; project.Resolve matched it to the native
+	}
+	params := newScope(nil)
+	for i, param := range fn.Params {
+		params.define(param.Name, sig.Params[i], "parameter")
+	}
+	outer := c.fn
+	c.fn = &funcContext{name: fn.Name, ret: sig.Return}
+	defer func() { c.fn = outer }()
+
+	result := c.checkBlock(fn.Body, params, !isKind(sig.Return, Void))
+	c.checkReturns(fn, fn.Body, result)
+}
+
+// checkReturns reports a non-void body that can finish without producing
+// a value. A trailing expression counts as the return value.
+func (c *checker) checkReturns(node ast.Node, body *ast.Block, result TypeRef) {
+	ret := c.fn.ret
+	if isKind(ret, Void) || isUnknown(ret) || c.guaranteesReturn(body) || result == nil {
+		return
+	}
+	if !isKind(result, Void) {
+		if !Assignable(result, ret) {
+			last := body.Statements[len(body.Statements)-1]
+			c.errorf(last, "return type mismatch: expected %s but found %s", ret, result)
+		}
+		return
+	}
+	switch {
+	case c.fn.name == "":
+		c.errorf(node, "lambda is missing a return of %s", ret)
+	case c.fn.sawReturn:
+		c.errorf(node, "not all code paths return a value in function %s: expected %s", c.fn.name, ret)
+	default:
+		c.errorf(node, "missing return in function %s: expected %s", c.fn.name, ret)
+	}
+}
+
+// guaranteesReturn reports whether every path through block reaches a
+// return statement or never finishes. A `while true` loop without a break
+// never finishes, and neither ends a path normally.
+func (c *checker) guaranteesReturn(block *ast.Block) bool {
+	if block == nil {
+		return false
+	}
+	for _, stmt := range block.Statements {
+		switch s := stmt.(type) {
+		case *ast.ReturnStmt:
+			return true
+		case *ast.ExprStmt:
+			if c.exprReturns(s.Expr) {
+				return true
+			}
+		case *ast.WhileStmt:
+			if cond, ok := s.Condition.(*ast.BoolLiteral); ok && cond.Value && !breaks(s.Body) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// exprReturns reports whether every path through an if/else or an
+// exhaustive match reaches a return.
+func (c *checker) exprReturns(expr ast.Expr) bool {
+	switch ex := expr.(type) {
+	case *ast.IfExpr:
+		return ex.ElseBlock != nil && c.guaranteesReturn(ex.ThenBlock) && c.guaranteesReturn(ex.ElseBlock)
+	case *ast.MatchExpr:
+		if !c.exhaustive[ex] || (ex.ElseExpr != nil && !c.exprReturns(ex.ElseExpr)) {
+			return false
+		}
+		for _, mc := range ex.Cases {
+			if !c.exprReturns(mc.Value) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// breaks reports whether body has a break that leaves the loop it belongs
+// to, rather than a loop or lambda inside it.
+func breaks(body *ast.Block) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.BreakStmt:
+			found = true
+		case *ast.WhileStmt, *ast.IterateExpr, *ast.LambdaExpr:
+			return false
+		}
+		return !found
+	})
+	return found
+}
+
+// signature resolves the parameter and return types of fn.
+func (c *checker) signature(fn *ast.FunctionDecl) Function {
+	if sig, ok := c.signatures[fn]; ok {
+		return sig
+	}
+	sig := Function{Params: make([]TypeRef, len(fn.Params)), Return: c.resolveType(fn.ReturnType, fn)}
+	for i, param := range fn.Params {
+		sig.Params[i] = c.resolveType(param.Type, param)
+	}
+	c.signatures[fn] = sig
+	return sig
+}
+
+// checkBlock checks the statements of block in a new scope and returns the
+// type of its value: that of a trailing expression, void when there is
+// none, or nil when the block always returns, breaks or continues. The
+// trailing expression is only required to have a single type when
+// wantValue is set.
+func (c *checker) checkBlock(block *ast.Block, parent *scope, wantValue bool) TypeRef {
+	local := newScope(parent)
+	var result TypeRef = voidType
+	for i, stmt := range block.Statements {
+		result = c.checkStmt(stmt, local, wantValue && i == len(block.Statements)-1)
+	}
+	return result
+}
+
+func (c *checker) checkStmt(stmt ast.Statement, sc *scope, wantValue bool) TypeRef {
+	switch s := stmt.(type) {
+	case *ast.VarDecl:
+		c.checkVarDecl(s, sc)
+	case *ast.AssignStmt:
+		c.checkAssign(s, sc)
+	case *ast.IncDecStmt:
+		if t := c.assignTarget(s.Target, s, sc); !isUnknown(t) {
+			if _, ok := numericKind(t); !ok {
+				c.errorf(s, "operator %s expects a number, got %s", s.Op, t)
+			}
+		}
+		sc.forget(assignedPath(s.Target))
+	case *ast.WhileStmt:
+		sc.forgetAssigned(s.Body)
+		c.expectBool(s.Condition, sc, "while condition")
+		whenTrue, _ := condFacts(s.Condition)
+		c.fn.loops++
+		c.checkBlock(s.Body, sc.narrowed(whenTrue), false)
+		c.fn.loops--
+	case *ast.BreakStmt:
+		if c.fn.loops == 0 {
+			c.errorf(s, "break outside of a loop")
+		}
+		return nil
+	case *ast.ContinueStmt:
+		if c.fn.loops == 0 {
+			c.errorf(s, "continue outside of a loop")
+		}
+		return nil
+	case *ast.PrintStmt:
+		if t := c.infer(s.Expr, sc); isKind(t, Void) {
+			c.errorf(s.Expr, "cannot print a void value")
+		}
+	case *ast.ExprStmt:
+		return c.inferValue(s.Expr, sc, wantValue)
+	case *ast.ReturnStmt:
+		c.checkReturn(s, sc)
+		return nil
+	default:
+		c.errorf(stmt, "unsupported statement %T", stmt)
+	}
+	return voidType
+}
+
+func (c *checker) checkVarDecl(s *ast.VarDecl, sc *scope) {
+	var value, declared TypeRef
+	if s.Type != "" {
+		declared = c.resolveType(s.Type, s)
+		value = c.inferAs(s.Value, sc, declared)
+		if !Assignable(value, declared) {
+			c.errorf(s, "type mismatch for %s: expected %s but found %s", s.Name, declared, value)
+		}
+	} else {
+		value = c.inferVarType(s, sc)
+		declared = value
+	}
+	if s.Mutability == "const" && !isConstant(s.Value, sc) {
+		c.errorf(s.Value, "const %s must be initialised with a compile-time constant", s.Name)
+	}
+	sc.define(s.Name, declared, s.Mutability)
+	sc.forget(s.Name)
+	if isNullableType(declared) && isNonNullValue(value) {
+		sc.narrow([]string{s.Name})
+	}
+}
+
+// isConstant reports whether e can be evaluated without running the
+// program: literals, other consts and operators applied to constants.
+func isConstant(e ast.Expr, sc *scope) bool {
+	switch ex := e.(type) {
+	case *ast.IntLiteral, *ast.LongLiteral, *ast.FloatLiteral, *ast.DoubleLiteral,
+		*ast.CharLiteral, *ast.BoolLiteral, *ast.StringLiteral, *ast.NullLiteral:
+		return true
+	case *ast.VarRef:
+		kind, _ := sc.immutableKind(ex.Name)
+		return kind == "const"
+	case *ast.StringTemplate:
+		for _, part := range ex.Parts {
+			if !isConstant(part, sc) {
+				return false
+			}
+		}
+		return true
+	case *ast.UnaryOp:
+		return isConstant(ex.Operand, sc)
+	case *ast.BinaryOp:
+		return isConstant(ex.Left, sc) && isConstant(ex.Right, sc)
+	case *ast.TernaryExpr:
+		return isConstant(ex.Condition, sc) && isConstant(ex.IfTrue, sc) && isConstant(ex.IfFalse, sc)
+	case *ast.ElvisExpr:
+		return isConstant(ex.Left, sc) && isConstant(ex.Right, sc)
+	}
+	return false
+}
+
+// isNonNullValue reports whether a value of type t can never be null.
+func isNonNullValue(t TypeRef) bool {
+	switch t.(type) {
+	case Nullable, Null, Unknown:
+		return false
+	}
+	return true
+}
+
+func (c *checker) checkAssign(s *ast.AssignStmt, sc *scope) {
+	if ref, ok := s.Target.(*ast.VarRef); ok && s.Op == "" {
+		if _, declared := sc.lookup(ref.Name); !declared {
+			// Like the interpreter, assigning an unknown name declares it.
+			value := c.infer(s.Value, sc)
+			if isKind(value, Void) {
+				c.errorf(s, "cannot assign a void value to %s", ref.Name)
+				value = Unknown{}
+			}
+			sc.vars[ref.Name] = value
+			return
+		}
+	}
+	target := c.assignTarget(s.Target, s, sc)
+	value := c.inferAs(s.Value, sc, target)
+	if s.Op != "" {
+		value = c.arithmetic(s, s.Op, target, value)
+	}
+	if !Assignable(value, target) {
+		c.errorf(s, "assignment type mismatch for %s: expected %s but found %s", describeTarget(s.Target), target, value)
+	}
+	p := assignedPath(s.Target)
+	sc.forget(p)
+	if p == path(s.Target) && isNullableType(target) && isNonNullValue(value) {
+		sc.narrow([]string{p})
+	}
+}
+
+// assignTarget returns the type stored by an assignable expression.
+func (c *checker) assignTarget(target ast.Expr, stmt ast.Node, sc *scope) TypeRef {
+	switch t := target.(type) {
+	case *ast.VarRef:
+		if kind, imm := sc.immutableKind(t.Name); imm {
+			c.errorf(stmt, "cannot reassign %s %s", kind, t.Name)
+		}
+		return c.declaredType(t, sc)
+	case *ast.FieldAccess:
+		owner := c.deref(t.Target, sc, "assigning field "+t.Field)
+		fieldType, mutable := c.fieldType(owner, t.Field, t)
+		if !mutable {
+			c.errorf(stmt, "field %s is immutable", t.Field)
+		}
+		return fieldType
+	case *ast.IndexAccess:
+		// An element is assigned a value of the element type, even where
+		// reading it may find a missing key.
+		return c.inferIndex(t, sc)
+	default:
+		c.errorf(stmt, "invalid assignment target")
+		return Unknown{}
+	}
+}
+
+func describeTarget(target ast.Expr) string {
+	switch t := target.(type) {
+	case *ast.VarRef:
+		return t.Name
+	case *ast.FieldAccess:
+		return "field " + t.Field
+	default:
+		return "element"
+	}
+}
+
+func (c *checker) checkReturn(s *ast.ReturnStmt, sc *scope) {
+	c.fn.sawReturn = true
+	ret := c.fn.ret
+	var value TypeRef = voidType
+	if s.Expr != nil {
+		value = c.inferAs(s.Expr, sc, ret)
+	}
+	switch {
+	case ret == nil:
+		c.fn.returns = append(c.fn.returns, value)
+	case isKind(ret, Void):
+		if s.Expr != nil {
+			c.errorf(s, "void function cannot return a value")
+		}
+	case s.Expr == nil:
+		c.errorf(s, "function must return %s but returned nothing", ret)
+	case !Assignable(value, ret):
+		c.errorf(s, "return type mismatch: expected %s but found %s", ret, value)
+	}
+}
+
+func (c *checker) expectBool(cond ast.Expr, sc *scope, what string) {
+	if t := c.infer(cond, sc); !isKind(t, Bool) && !isUnknown(t) {
+		c.errorf(cond, "%s must be bool but found %s", what, t)
+	}
+}
+
+var primitiveKinds = map[string]Kind{
+	"int": Int, "long": Long, "float": Float, "double": Double, "char": Char,
+	"bool": Bool, "string": String, "bytes": Bytes, "void": Void,
+}
+
+// resolveType turns a type as written in the source into a TypeRef,
+// reporting unknown names and alias cycles at node.
+func (c *checker) resolveType(name string, node ast.Node) TypeRef {
+	t, err := c.parseType(name, map[string]bool{})
+	if err != nil {
+		c.errorf(node, "%v", err)
+		return Unknown{}
+	}
+	return t
+}
+
+func (c *checker) parseType(name string, visiting map[string]bool) (TypeRef, error) {
+	if strings.HasSuffix(name, "?") {
+		inner, err := c.parseType(strings.TrimSuffix(name, "?"), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return nullable(inner), nil
+	}
+	if strings.HasPrefix(name, "fun ") {
+		return c.parseFunctionType(name, visiting)
+	}
+	if key, value, ok := project.MapType(name); ok {
+		k, err := c.parseType(key, visiting)
+		if err != nil {
+			return nil, err
+		}
+		v, err := c.parseType(value, visiting)
+		if err != nil {
+			return nil, err
+		}
+		return Map{Key: k, Value: v}, nil
+	}
+	if elem, ok := project.ArrayType(name); ok {
+		e, err := c.parseType(elem, visiting)
+		if err != nil {
+			return nil, err
+		}
+		return Array{Element: e}, nil
+	}
+	if kind, ok := primitiveKinds[name]; ok {
+		return Primitive{kind}, nil
+	}
+	if _, ok := c.symbols.Records[name]; ok {
+		return Record{Name: name}, nil
+	}
+	if _, ok := c.symbols.SumTypes[name]; ok {
+		return SumType{Name: name}, nil
+	}
+	if alias, ok := c.symbols.Aliases[name]; ok {
+		if visiting[name] {
+			return nil, fmt.Errorf("circular type alias %s", name)
+		}
+		visiting[name] = true
+		defer delete(visiting, name)
+		return c.parseType(alias.TargetType, visiting)
+	}
+	return nil, fmt.Errorf("unknown type %s", name)
+}
+
+// parseFunctionType parses the "fun R (P1, P2)" form Function.String
+// produces. Glyph source has no function types, but inferred lambda
+// variables carry them on the AST.
+func (c *checker) parseFunctionType(name string, visiting map[string]bool) (TypeRef, error) {
+	body := strings.TrimPrefix(name, "fun ")
+	open := matchingParen(body)
+	if open < 1 || body[open-1] != ' ' {
+		return nil, fmt.Errorf("unknown type %s", name)
+	}
+	ret, err := c.parseType(body[:open-1], visiting)
+	if err != nil {
+		return nil, err
+	}
+	fn := Function{Return: ret}
+	for _, param := range splitTopLevel(body[open+1 : len(body)-1]) {
+		t, err := c.parseType(param, visiting)
+		if err != nil {
+			return nil, err
+		}
+		fn.Params = append(fn.Params, t)
+	}
+	return fn, nil
+}
+
+// matchingParen returns the index of the '(' matching the ')' that ends s,
+// or -1.
+func matchingParen(s string) int {
+	if !strings.HasSuffix(s, ")") {
+		return -1
+	}
+	depth := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		switch s[i] {
+		case ')':
+			depth++
+		case '(':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitTopLevel splits a comma-separated type list, keeping commas nested
+// in brackets or parentheses.
+func splitTopLevel(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var out []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				out = append(out, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(out, strings.TrimSpace(s[start:]))
+}
@@ -0,0 +1,219 @@
+package typecheck
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"glyph-cli/parser"
+	"glyph-cli/project"
+)
+
+// checkSource indexes source as the only file of a project and checks it.
+func checkSource(t *testing.T, source string) error {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.gly")
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	idx, err := project.BuildIndex(dir)
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	program, err := parser.ParseProgramFile(path)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	symbols, err := project.Resolve(program, idx)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	return Check(program, symbols)
+}
+
+func TestTypecheckErrorExamples(t *testing.T) {
+	cases := []struct {
+		file string
+		line int
+		want string
+	}{
+		{"assignment-type-error.gly", 5, "assignment type mismatch for counter: expected int but found string"},
+		{"field-not-found.gly", 9, "field name not found on type User"},
+		{"if-not-bool.gly", 4, "if condition must be bool but found string"},
+		{"missing-return.gly", 3, "not all code paths return a value in function absolute: expected int"},
+		{"wrong-arg-count.gly", 8, "function add expects 2 argument(s) but received 1"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.file, func(t *testing.T) {
+			source, err := os.ReadFile(filepath.Join("..", "..", "..", "examples", "typecheck-errors", tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var errs Errors
+			if err := checkSource(t, string(source)); !errors.As(err, &errs) {
+				t.Fatalf("expected type errors, got %v", err)
+			}
+			if len(errs) != 1 || errs[0].Pos.Line != tc.line || errs[0].Message != tc.want {
+				t.Fatalf("got %v, want line %d: %s", errs, tc.line, tc.want)
+			}
+		})
+	}
+}
+
+func TestWellTypedProgramPasses(t *testing.T) {
+	source := `record User {
+  val string name
+  int age
+  string? city
+}
+
+type Shape = Circle(r: double) | Square(s: int)
+
+fun double area(Shape s) {
+  match s {
+    Circle(r) -> r * r * 3.14
+    Square(n) -> n * n
+  } else 0.0
+}
+
+fun string greet(User u, string prefix) {
+  "${prefix} ${u.name}"
+}
+
+fun int sign(int x) {
+  if x < 0 {
+    return -1
+  } else {
+    return 1
+  }
+}
+
+fun void main() {
+  val u = User { name = "Ann", age = 3, city = null }
+  u.age += 1
+  print(u.greet("hi"))
+  print(u.city ?: "nowhere")
+  val [string:int] m = [string:int]{ "a": 1 }
+  m.each { print(it.key) }
+  range(0, 3).withIndex { print(it.index + it.value) }
+  var total = 0L
+  var i = 0
+  while i < 10 {
+    i++
+    if i % 2 == 0 { continue }
+    total += i
+  }
+  val twice = fun (int x) { x * 2 }
+  print(twice(total > 3L ? 1 : 2))
+  print(area(Circle(1.0)) + sign(-4))
+}
+`
+	if err := checkSource(t, source); err != nil {
+		t.Fatalf("unexpected errors:\n%v", err)
+	}
+}
+
+func TestReportsEveryErrorWithPosition(t *testing.T) {
+	source := `type Shape = Circle(r: double) | Square(s: int)
+
+fun void main() {
+  val long l = 1
+  var int i = l
+  break
+  print("a" + "b")
+  val g = fun int (int x) { print(x) }
+  match Circle(1.0) {
+    Square(a, b) -> 1
+  } else 0
+}
+`
+	err := checkSource(t, source)
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected type errors, got %v", err)
+	}
+	want := []string{
+		"main.gly:5:3: type mismatch for i: expected int but found long",
+		"main.gly:6:3: break outside of a loop",
+		"main.gly:7:9: binary op + expects numbers, got string and string",
+		"main.gly:8:11: lambda is missing a return of int",
+		"main.gly:10:5: pattern Square has 2 field(s) but the variant declares 1",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors, want %d:\n%v", len(errs), len(want), err)
+	}
+	for i, e := range errs {
+		if got := e.Error(); !strings.HasSuffix(got, want[i]) {
+			t.Errorf("error %d: got %q, want suffix %q", i, got, want[i])
+		}
+	}
+}
+
+func TestLoopsAndMatchesThatAlwaysReturn(t *testing.T) {
+	source := `type Shape = Circle(r: int) | Square(s: int)
+
+fun int find(int n) {
+  var i = 0
+  while true {
+    if i == n { return i }
+    i++
+  }
+}
+
+fun int area(Shape s) {
+  match s {
+    Circle(r) -> if r > 0 { return r * r } else { return 0 }
+    Square(x) -> if x > 0 { return x * x } else { return 0 }
+  }
+}
+
+fun int pick(bool b) {
+  match b {
+    true -> if b { return 1 } else { return 2 }
+  } else if b { return 3 } else { return 4 }
+}
+
+fun void main() {
+  print(find(3) + area(Circle(2)) + pick(true))
+}
+`
+	if err := checkSource(t, source); err != nil {
+		t.Fatalf("unexpected errors:\n%v", err)
+	}
+}
+
+func TestLoopsAndMatchesThatMayFinish(t *testing.T) {
+	source := `type Shape = Circle(r: int) | Square(s: int)
+
+fun int find(int n) {
+  var i = 0
+  while true {
+    if i == n { break }
+    i++
+  }
+}
+
+fun int area(Shape s) {
+  match s {
+    Circle(r) -> if r > 0 { return r * r } else { return 0 }
+    Square(x) -> if x > 0 { return x * x }
+  }
+}
+
+fun void main() {
+  print(find(3) + area(Circle(2)))
+}
+`
+	err := checkSource(t, source)
+	for _, want := range []string{
+		"main.gly:3:1: missing return in function find: expected int",
+		"main.gly:11:1: not all code paths return a value in function area: expected int",
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("got %v, want %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package typecheck
+
+import (
+	"fmt"
+	"strings"
+
+	"glyph-cli/ast"
+)
+
+// Error is a single type error, positioned at the offending node.
+type Error struct {
+	Pos     ast.SourcePos
+	Message string
+}
+
+func (e *Error) Error() string {
+	if !e.Pos.IsValid() {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Pos, e.Message)
+}
+
+// Errors is the error returned by Check when the program is ill-typed, in
+// the order the problems were found.
+type Errors []*Error
+
+func (es Errors) Error() string {
+	lines := make([]string, len(es))
+	for i, e := range es {
+		lines[i] = e.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Format renders every error in the compiler-style form used for syntax
+// diagnostics, one per line.
+func (es Errors) Format() string {
+	lines := make([]string, len(es))
+	for i, e := range es {
+		lines[i] = fmt.Sprintf("%s: error: %s", e.Pos, e.Message)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,256 @@
+package typecheck
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"glyph-cli/ast"
+)
+
+// Exhaustiveness and reachability of match cases are decided with the
+// usual pattern-matrix algorithm: each case is a row of patterns, and a
+// pattern vector is useful against the rows before it if some value
+// matches it but none of them. A case that is not useful can never run,
+// and a match is exhaustive when a row of wildcards is not useful.
+//
+// Only bool, sum-type, record and nullable values have a finite set of
+// shapes. Other types, such as int or string, need a catch-all case.
+
+// ctor is one shape of value: a variant, a record, null or a literal.
+type ctor struct {
+	key    string
+	name   string
+	fields []TypeRef
+}
+
+var wildcard ast.Pattern = &ast.WildcardPattern{}
+
+func wildcards(n int) []ast.Pattern {
+	out := make([]ast.Pattern, n)
+	for i := range out {
+		out[i] = wildcard
+	}
+	return out
+}
+
+// ctorOf returns the shape p matches on a value of type t and the patterns
+// for its fields. ok is false for patterns that match anything.
+func (c *checker) ctorOf(p ast.Pattern, t TypeRef) (k ctor, sub []ast.Pattern, ok bool) {
+	switch p := p.(type) {
+	case *ast.LiteralPattern:
+		switch lit := p.Literal.(type) {
+		case *ast.NullLiteral:
+			return ctor{key: "null", name: "null"}, nil, true
+		case *ast.BoolLiteral:
+			name := strconv.FormatBool(lit.Value)
+			return ctor{key: name, name: name}, nil, true
+		case *ast.StringLiteral:
+			return ctor{key: strconv.Quote(lit.Value)}, nil, true
+		case *ast.CharLiteral:
+			return ctor{key: strconv.QuoteRune(lit.Value)}, nil, true
+		case *ast.IntLiteral:
+			return ctor{key: fmt.Sprint(lit.Value)}, nil, true
+		case *ast.LongLiteral:
+			return ctor{key: fmt.Sprint(lit.Value)}, nil, true
+		case *ast.FloatLiteral:
+			return ctor{key: fmt.Sprint(lit.Value)}, nil, true
+		case *ast.DoubleLiteral:
+			return ctor{key: fmt.Sprint(lit.Value)}, nil, true
+		}
+	case *ast.VariantPattern:
+		if _, variant, found := c.symbols.Variant(p.Variant); found {
+			return c.variantCtor(variant), p.Fields, true
+		}
+		// Name() may match a record with no field patterns.
+		if rec, found := c.symbols.Records[p.Variant]; found {
+			k := c.recordCtor(rec)
+			return k, wildcards(len(k.fields)), true
+		}
+	case *ast.RecordPattern:
+		if rec, found := c.symbols.Records[p.TypeName]; found {
+			k := c.recordCtor(rec)
+			sub := wildcards(len(k.fields))
+			for _, fp := range p.Fields {
+				for i, field := range rec.Fields {
+					if field.Name == fp.Field {
+						sub[i] = fp.Pattern
+					}
+				}
+			}
+			return k, sub, true
+		}
+	}
+	return ctor{}, nil, false
+}
+
+func (c *checker) variantCtor(variant *ast.VariantDecl) ctor {
+	k := ctor{key: "variant " + variant.Name, name: variant.Name}
+	for _, field := range variant.Fields {
+		k.fields = append(k.fields, c.resolveType(field.Type, field))
+	}
+	return k
+}
+
+func (c *checker) recordCtor(rec *ast.RecordDecl) ctor {
+	k := ctor{key: "record " + rec.Name, name: rec.Name}
+	for _, field := range rec.Fields {
+		k.fields = append(k.fields, c.resolveType(field.Type, field))
+	}
+	return k
+}
+
+// shapes lists the shapes a value of type t can take. complete is false
+// when the list cannot be exhausted without a catch-all case.
+func (c *checker) shapes(t TypeRef) (ctors []ctor, complete bool) {
+	switch t := t.(type) {
+	case Nullable:
+		ctors, complete = c.shapes(t.Inner)
+		return append([]ctor{{key: "null", name: "null"}}, ctors...), complete
+	case Primitive:
+		if t.Kind == Bool {
+			return []ctor{{key: "true", name: "true"}, {key: "false", name: "false"}}, true
+		}
+	case SumType:
+		if sum, ok := c.symbols.SumTypes[t.Name]; ok {
+			for _, variant := range sum.Variants {
+				ctors = append(ctors, c.variantCtor(variant))
+			}
+			return ctors, true
+		}
+	case Record:
+		if rec, ok := c.symbols.Records[t.Name]; ok && t.Fields == nil {
+			return []ctor{c.recordCtor(rec)}, true
+		}
+	}
+	return nil, false
+}
+
+// specialize keeps the rows that can match a value of shape k, replacing
+// their first pattern with patterns for the fields of k.
+func (c *checker) specialize(rows [][]ast.Pattern, k ctor, t TypeRef) [][]ast.Pattern {
+	var out [][]ast.Pattern
+	for _, row := range rows {
+		rk, sub, ok := c.ctorOf(row[0], t)
+		switch {
+		case !ok:
+			out = append(out, append(wildcards(len(k.fields)), row[1:]...))
+		case rk.key == k.key:
+			out = append(out, append(append([]ast.Pattern{}, sub...), row[1:]...))
+		}
+	}
+	return out
+}
+
+// defaultRows keeps the rows whose first pattern matches anything.
+func (c *checker) defaultRows(rows [][]ast.Pattern, t TypeRef) [][]ast.Pattern {
+	var out [][]ast.Pattern
+	for _, row := range rows {
+		if _, _, ok := c.ctorOf(row[0], t); !ok {
+			out = append(out, row[1:])
+		}
+	}
+	return out
+}
+
+// useful reports whether some value of types matches v but none of rows.
+func (c *checker) useful(rows [][]ast.Pattern, v []ast.Pattern, types []TypeRef) bool {
+	if len(v) == 0 {
+		return len(rows) == 0
+	}
+	t := types[0]
+	if k, sub, ok := c.ctorOf(v[0], t); ok {
+		return c.useful(c.specialize(rows, k, t), append(append([]ast.Pattern{}, sub...), v[1:]...), append(append([]TypeRef{}, k.fields...), types[1:]...))
+	}
+	ctors, complete := c.shapes(t)
+	if !complete {
+		return c.useful(c.defaultRows(rows, t), v[1:], types[1:])
+	}
+	for _, k := range ctors {
+		if c.useful(c.specialize(rows, k, t), append(wildcards(len(k.fields)), v[1:]...), append(append([]TypeRef{}, k.fields...), types[1:]...)) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCases reports cases that can never match and, for a match without
+// else, the shapes of target that no case covers.
+func (c *checker) checkCases(ex *ast.MatchExpr, target TypeRef) {
+	types := []TypeRef{target}
+	var rows [][]ast.Pattern
+	catchAll := -1
+	for i, mc := range ex.Cases {
+		row := []ast.Pattern{mc.Pattern}
+		if !c.useful(rows, row, types) {
+			if catchAll >= 0 {
+				prev := ex.Cases[catchAll].Pattern
+				c.errorf(mc.Pattern, "unreachable case: %s on line %d already matches every value", c.describePattern(prev, target), prev.Position().Line)
+			} else {
+				c.errorf(mc.Pattern, "unreachable case: earlier cases already cover %s", c.describePattern(mc.Pattern, target))
+			}
+		}
+		rows = append(rows, row)
+		if catchAll < 0 && !c.useful([][]ast.Pattern{row}, wildcards(1), types) {
+			catchAll = i
+		}
+	}
+	if ex.ElseExpr != nil {
+		c.exhaustive[ex] = true
+		if catchAll >= 0 {
+			prev := ex.Cases[catchAll].Pattern
+			c.errorf(ex.ElseExpr, "unreachable else branch: %s on line %d already matches every value", c.describePattern(prev, target), prev.Position().Line)
+		}
+		return
+	}
+	if !c.useful(rows, wildcards(1), types) {
+		c.exhaustive[ex] = true
+		return
+	}
+	var problems []string
+	if missing := c.missingCtors(rows, target); len(missing) > 0 {
+		problems = append(problems, "missing "+strings.Join(missing, ", "))
+	}
+	if _, complete := c.shapes(target); !complete && len(c.defaultRows(rows, target)) == 0 {
+		problems = append(problems, "add a _ case or an else branch")
+	}
+	c.errorf(ex, "match on %s is not exhaustive: %s", target, strings.Join(problems, "; "))
+}
+
+// missingCtors names the shapes of t that rows do not fully cover. A
+// shape some rows match only in part is written with (...).
+func (c *checker) missingCtors(rows [][]ast.Pattern, t TypeRef) []string {
+	ctors, _ := c.shapes(t)
+	var missing []string
+	for _, k := range ctors {
+		special := c.specialize(rows, k, t)
+		if !c.useful(special, wildcards(len(k.fields)), k.fields) {
+			continue
+		}
+		if len(special) > 0 && len(k.fields) > 0 {
+			missing = append(missing, k.name+"(...)")
+		} else {
+			missing = append(missing, k.name)
+		}
+	}
+	return missing
+}
+
+// describePattern names a pattern for a diagnostic.
+func (c *checker) describePattern(p ast.Pattern, t TypeRef) string {
+	switch p := p.(type) {
+	case *ast.WildcardPattern:
+		return "_"
+	case *ast.VarPattern:
+		return p.Name
+	case *ast.RecordPattern:
+		return p.TypeName + " {...}"
+	case *ast.VariantPattern:
+		if len(p.Fields) == 0 {
+			return p.Variant + "()"
+		}
+		return p.Variant + "(...)"
+	}
+	k, _, _ := c.ctorOf(p, t)
+	return k.key
+}
@@ -0,0 +1,123 @@
+package typecheck
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExhaustiveMatchesNeedNoElse(t *testing.T) {
+	source := `type Shape = Circle(r: double) | Square(s: int) | Empty()
+type Maybe = Some(value: bool) | None()
+
+record Point {
+  int x
+  int y
+}
+
+fun double area(Shape s) {
+  match s {
+    Circle(r) -> r * r * 3.14
+    Square(n) -> n * n
+    Empty() -> 0.0
+  }
+}
+
+fun string describe(bool? b) {
+  match b {
+    true -> "yes"
+    false -> "no"
+    null -> "unknown"
+  }
+}
+
+fun int flags(Maybe m) {
+  match m {
+    Some(true) -> 1
+    Some(false) -> 2
+    None() -> 0
+  }
+}
+
+fun int sum(Point p) {
+  match p {
+    Point { x = a, y = b } -> a + b
+  }
+}
+
+fun string label(int n) {
+  match n {
+    0 -> "zero"
+    other -> "many"
+  }
+}
+
+fun void main() {
+  print(area(Empty()))
+  print(describe(null))
+  print(flags(None()))
+  print(sum(Point { x = 1, y = 2 }))
+  print(label(3))
+}
+`
+	if err := checkSource(t, source); err != nil {
+		t.Fatalf("unexpected errors:\n%v", err)
+	}
+}
+
+func TestReportsMissingAndUnreachableCases(t *testing.T) {
+	source := `type Shape = Circle(r: double) | Square(s: int) | Empty()
+type Maybe = Some(value: bool) | None()
+
+fun void main() {
+  val Shape s = Empty()
+  val int area = match s {
+    Circle(r) -> 1
+  }
+  val bool? b = null
+  val string text = match b {
+    true -> "yes"
+  }
+  val int n = match Some(true) {
+    Some(true) -> 1
+    None() -> 0
+  }
+  val int code = match 3 {
+    1 -> 1
+  }
+  val int x = match s {
+    _ -> 1
+    Circle(r) -> 2
+  }
+  val int y = match s {
+    other -> 1
+  } else 2
+  val int z = match b {
+    true -> 1
+    true -> 2
+  } else 3
+}
+`
+	err := checkSource(t, source)
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected type errors, got %v", err)
+	}
+	want := []string{
+		"main.gly:6:18: match on Shape is not exhaustive: missing Square, Empty",
+		"main.gly:10:21: match on bool? is not exhaustive: missing null, false",
+		"main.gly:13:15: match on Maybe is not exhaustive: missing Some(...)",
+		"main.gly:17:18: match on int is not exhaustive: add a _ case or an else branch",
+		"main.gly:22:5: unreachable case: _ on line 21 already matches every value",
+		"main.gly:26:10: unreachable else branch: other on line 25 already matches every value",
+		"main.gly:29:5: unreachable case: earlier cases already cover true",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors, want %d:\n%v", len(errs), len(want), err)
+	}
+	for i, e := range errs {
+		if got := e.Error(); !strings.HasSuffix(got, want[i]) {
+			t.Errorf("error %d: got %q, want suffix %q", i, got, want[i])
+		}
+	}
+}
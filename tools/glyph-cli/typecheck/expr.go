@@ -0,0 +1,705 @@
+package typecheck
+
+import (
+	"sort"
+
+	"glyph-cli/ast"
+)
+
+// infer returns the static type of e, reporting any errors inside it.
+func (c *checker) infer(e ast.Expr, sc *scope) TypeRef {
+	return c.inferValue(e, sc, true)
+}
+
+// inferValue is infer for an expression whose value may be discarded. When
+// wantValue is false, an if or match whose branches have different types
+// is not an error; it simply has no usable value.
+func (c *checker) inferValue(e ast.Expr, sc *scope, wantValue bool) TypeRef {
+	switch ex := e.(type) {
+	case *ast.IntLiteral, *ast.LongLiteral, *ast.FloatLiteral, *ast.DoubleLiteral, *ast.CharLiteral,
+		*ast.BoolLiteral, *ast.NullLiteral, *ast.StringLiteral:
+		return literalType(ex)
+	case *ast.StringTemplate:
+		for _, part := range ex.Parts {
+			if t := c.infer(part, sc); isKind(t, Void) {
+				c.errorf(part, "cannot interpolate a void value")
+			}
+		}
+		return stringType
+	case *ast.VarRef:
+		t, ok := sc.lookup(ex.Name)
+		if !ok {
+			c.errorf(ex, "undefined variable %s", ex.Name)
+			return Unknown{}
+		}
+		return c.narrowRead(ex, t, sc)
+	case *ast.RecordLiteral:
+		return c.inferRecordLiteral(ex, sc)
+	case *ast.FieldAccess:
+		owner := c.deref(ex.Target, sc, "accessing field "+ex.Field)
+		t, _ := c.fieldType(owner, ex.Field, ex)
+		return c.narrowRead(ex, t, sc)
+	case *ast.SafeFieldAccess:
+		owner, n := c.inferNullness(ex.Target, sc)
+		t, _ := c.fieldType(owner, ex.Field, ex)
+		if n != notNull {
+			t = nullable(t)
+		}
+		return c.narrowRead(ex, t, sc)
+	case *ast.IndexAccess:
+		t := c.inferIndex(ex, sc)
+		if c.mapLookups[ex] {
+			t = nullable(t) // the key may be missing
+		}
+		return c.narrowRead(ex, t, sc)
+	case *ast.ArrayAllocExpr:
+		c.expectIntegral(ex.Size, sc, "array size")
+		return Array{Element: c.resolveType(ex.ElementType, ex)}
+	case *ast.MapAllocExpr:
+		c.expectIntegral(ex.Capacity, sc, "map capacity")
+		return Map{Key: c.resolveType(ex.KeyType, ex), Value: c.resolveType(ex.ValueType, ex)}
+	case *ast.MapLiteralExpr:
+		return c.inferMapLiteral(ex, sc)
+	case *ast.IfExpr:
+		return c.inferIf(ex, sc, wantValue)
+	case *ast.TernaryExpr:
+		c.expectBool(ex.Condition, sc, "ternary condition")
+		whenTrue, whenFalse := condFacts(ex.Condition)
+		ifTrue, ifFalse := c.infer(ex.IfTrue, sc.narrowed(whenTrue)), c.infer(ex.IfFalse, sc.narrowed(whenFalse))
+		t, ok := join(ifTrue, ifFalse)
+		if !ok {
+			c.errorf(ex, "ternary branches must match but found %s and %s", ifTrue, ifFalse)
+			return Unknown{}
+		}
+		return t
+	case *ast.ElvisExpr:
+		left, _ := c.inferNullness(ex.Left, sc)
+		right := c.infer(ex.Right, sc)
+		if _, isNull := left.(Null); isNull {
+			return right
+		}
+		t, ok := join(nonNull(left), right)
+		if !ok {
+			c.errorf(ex, "elvis branches must match but found %s and %s", left, right)
+			return Unknown{}
+		}
+		return t
+	case *ast.MatchExpr:
+		return c.inferMatch(ex, sc, wantValue)
+	case *ast.CallExpr:
+		return c.inferCall(ex, sc)
+	case *ast.MethodCall:
+		return c.inferMethodCall(ex, sc)
+	case *ast.IterateExpr:
+		c.checkIterate(ex, sc)
+		return voidType
+	case *ast.LambdaExpr:
+		return c.inferLambda(ex, sc)
+	case *ast.UnaryOp:
+		return c.inferUnary(ex, sc)
+	case *ast.BinaryOp:
+		return c.inferBinary(ex, sc)
+	default:
+		c.errorf(e, "unsupported expression %T", e)
+		return Unknown{}
+	}
+}
+
+func literalType(e ast.Expr) TypeRef {
+	switch e.(type) {
+	case *ast.IntLiteral:
+		return intType
+	case *ast.LongLiteral:
+		return longType
+	case *ast.FloatLiteral:
+		return floatType
+	case *ast.DoubleLiteral:
+		return doubleType
+	case *ast.CharLiteral:
+		return charType
+	case *ast.BoolLiteral:
+		return boolType
+	case *ast.StringLiteral:
+		return stringType
+	case *ast.NullLiteral:
+		return Null{}
+	}
+	return Unknown{}
+}
+
+func (c *checker) expectIntegral(e ast.Expr, sc *scope, what string) {
+	if t := c.infer(e, sc); !isIntegral(t) && !isUnknown(t) {
+		c.errorf(e, "%s must be int but found %s", what, t)
+	}
+}
+
+func (c *checker) inferRecordLiteral(ex *ast.RecordLiteral, sc *scope) TypeRef {
+	rec, ok := c.symbols.Records[ex.TypeName]
+	if !ok {
+		c.errorf(ex, "unknown record %s", ex.TypeName)
+		for _, value := range ex.Fields {
+			c.infer(value, sc)
+		}
+		return Unknown{}
+	}
+	declared := map[string]bool{}
+	for _, field := range rec.Fields {
+		declared[field.Name] = true
+		valueExpr, ok := ex.Fields[field.Name]
+		if !ok {
+			c.errorf(ex, "missing field %s in %s", field.Name, rec.Name)
+			continue
+		}
+		expected := c.resolveType(field.Type, field)
+		if actual := c.inferAs(valueExpr, sc, expected); !Assignable(actual, expected) {
+			c.errorf(valueExpr, "field %s of %s expects %s but found %s", field.Name, rec.Name, expected, actual)
+		}
+	}
+	var extra []string
+	for name := range ex.Fields {
+		if !declared[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		c.errorf(ex, "field %s not found on type %s", name, rec.Name)
+		c.infer(ex.Fields[name], sc)
+	}
+	return Record{Name: rec.Name}
+}
+
+// fieldType looks up a field of a record or sum type value and reports
+// whether it may be assigned to. A field of a sum type must be declared by
+// every variant, since the variant is only known at runtime.
+func (c *checker) fieldType(owner TypeRef, field string, node ast.Node) (TypeRef, bool) {
+	switch t := nonNull(owner).(type) {
+	case Unknown:
+		return Unknown{}, true
+	case Record:
+		if t.Fields != nil {
+			if ft, ok := t.Fields[field]; ok {
+				return ft, false
+			}
+			break
+		}
+		rec := c.symbols.Records[t.Name]
+		for _, f := range rec.Fields {
+			if f.Name == field {
+				return c.resolveType(f.Type, f), f.Mutability != "val"
+			}
+		}
+	case SumType:
+		sum := c.symbols.SumTypes[t.Name]
+		var found *ast.VariantField
+		for _, variant := range sum.Variants {
+			var match *ast.VariantField
+			for _, f := range variant.Fields {
+				if f.Name == field {
+					match = f
+				}
+			}
+			if match == nil {
+				c.errorf(node, "field %s is not declared by variant %s of %s", field, variant.Name, t.Name)
+				return Unknown{}, false
+			}
+			if found == nil {
+				found = match
+			}
+		}
+		if found != nil {
+			return c.resolveType(found.Type, found), false
+		}
+	default:
+		c.errorf(node, "cannot access field %s on %s", field, owner)
+		return Unknown{}, true
+	}
+	c.errorf(node, "field %s not found on type %s", field, nonNull(owner))
+	return Unknown{}, true
+}
+
+func (c *checker) inferIndex(ex *ast.IndexAccess, sc *scope) TypeRef {
+	target := c.deref(ex.Target, sc, "indexing it")
+	index := c.infer(ex.Index, sc)
+	switch t := target.(type) {
+	case Unknown:
+		return Unknown{}
+	case Array:
+		if !isIntegral(index) && !isUnknown(index) {
+			c.errorf(ex.Index, "array index must be int but found %s", index)
+		}
+		return t.Element
+	case Map:
+		c.mapLookups[ex] = true
+		// Keys are compared by their runtime representation, so an int
+		// cannot look up a long key.
+		if !Same(index, t.Key) && !isUnknown(index) && !isUnknown(t.Key) {
+			c.errorf(ex.Index, "map key type mismatch: expected %s but found %s", t.Key, index)
+		}
+		return t.Value
+	default:
+		c.errorf(ex, "index access on non-collection type %s", target)
+		return Unknown{}
+	}
+}
+
+func (c *checker) inferMapLiteral(ex *ast.MapLiteralExpr, sc *scope) TypeRef {
+	if ex.KeyType == "" {
+		c.errorf(ex, "cannot infer the key and value types of an empty map literal: write [K:V]{} or use it where a map type is declared")
+		return Unknown{}
+	}
+	m := Map{Key: c.resolveType(ex.KeyType, ex), Value: c.resolveType(ex.ValueType, ex)}
+	for _, entry := range ex.Entries {
+		key, value := c.infer(entry.Key, sc), c.inferAs(entry.Value, sc, m.Value)
+		if !Same(key, m.Key) && !isUnknown(key) && !isUnknown(m.Key) {
+			c.errorf(entry.Key, "map entry key type mismatch: expected %s but found %s", m.Key, key)
+		}
+		if !Assignable(value, m.Value) {
+			c.errorf(entry.Value, "map entry value type mismatch: expected %s but found %s", m.Value, value)
+		}
+	}
+	return m
+}
+
+// inferIf checks both branches with the facts the condition establishes.
+// When one branch cannot complete, the code after the if only runs when
+// the other was taken, so its facts carry over into sc.
+func (c *checker) inferIf(ex *ast.IfExpr, sc *scope, wantValue bool) TypeRef {
+	c.expectBool(ex.Condition, sc, "if condition")
+	whenTrue, whenFalse := condFacts(ex.Condition)
+	thenType := c.checkBlock(ex.ThenBlock, sc.narrowed(whenTrue), wantValue)
+	if ex.ElseBlock == nil {
+		if thenType == nil {
+			sc.narrow(whenFalse)
+		}
+		return voidType
+	}
+	elseType := c.checkBlock(ex.ElseBlock, sc.narrowed(whenFalse), wantValue)
+	switch {
+	case thenType == nil && elseType != nil:
+		sc.narrow(whenFalse)
+	case elseType == nil && thenType != nil:
+		sc.narrow(whenTrue)
+	}
+	t, ok := join(thenType, elseType)
+	if !ok {
+		if wantValue {
+			c.errorf(ex, "if branches must return the same type but found %s and %s", thenType, elseType)
+			return Unknown{}
+		}
+		return voidType
+	}
+	return t
+}
+
+func (c *checker) inferMatch(ex *ast.MatchExpr, sc *scope, wantValue bool) TypeRef {
+	target := c.infer(ex.Target, sc)
+	subjectType := target
+	var result TypeRef
+	mismatch := false
+	merge := func(node ast.Node, t TypeRef) {
+		joined, ok := join(result, t)
+		if !ok {
+			if wantValue && !mismatch {
+				c.errorf(node, "match branches must return the same type but found %s and %s", result, t)
+			}
+			mismatch = true
+			return
+		}
+		result = joined
+	}
+	// Once a case has matched null, later cases and the else branch only
+	// see non-null subjects.
+	subject := sc
+	patternErrs := 0
+	for _, mc := range ex.Cases {
+		branch := newScope(subject)
+		before := len(c.errs)
+		c.bindPattern(mc.Pattern, target, branch)
+		patternErrs += len(c.errs) - before
+		merge(mc, c.inferValue(mc.Value, branch, wantValue))
+		if isNullPattern(mc.Pattern) {
+			target = nonNull(target)
+			subject = subject.narrowed(nonNullPaths(ex.Target))
+		}
+	}
+	// Coverage is only meaningful once the subject and every pattern
+	// type-check.
+	if !isUnknown(subjectType) && patternErrs == 0 {
+		c.checkCases(ex, subjectType)
+	}
+	if ex.ElseExpr != nil {
+		merge(ex.ElseExpr, c.inferValue(ex.ElseExpr, subject, wantValue))
+	}
+	switch {
+	case mismatch && wantValue:
+		return Unknown{}
+	case mismatch, result == nil:
+		return voidType
+	}
+	return result
+}
+
+func isNullPattern(p ast.Pattern) bool {
+	lit, ok := p.(*ast.LiteralPattern)
+	if !ok {
+		return false
+	}
+	_, isNull := lit.Literal.(*ast.NullLiteral)
+	return isNull
+}
+
+// bindPattern checks that pattern can match a value of type target and
+// declares the variables it binds in branch.
+func (c *checker) bindPattern(pattern ast.Pattern, target TypeRef, branch *scope) {
+	switch p := pattern.(type) {
+	case *ast.WildcardPattern:
+	case *ast.VarPattern:
+		if _, dup := branch.vars[p.Name]; dup {
+			c.errorf(p, "pattern variable %s is already bound", p.Name)
+		}
+		branch.define(p.Name, target, "pattern variable")
+	case *ast.LiteralPattern:
+		lit := literalType(p.Literal)
+		if !comparable(lit, target) {
+			c.errorf(p, "pattern of type %s cannot match a value of type %s", lit, target)
+		}
+	case *ast.RecordPattern:
+		if _, ok := c.symbols.Records[p.TypeName]; !ok {
+			c.errorf(p, "unknown record %s", p.TypeName)
+			return
+		}
+		if !c.patternTargets(p, p.TypeName, Record{Name: p.TypeName}, target) {
+			return
+		}
+		for _, fp := range p.Fields {
+			fieldType, _ := c.fieldType(Record{Name: p.TypeName}, fp.Field, fp)
+			c.bindPattern(fp.Pattern, fieldType, branch)
+		}
+	case *ast.VariantPattern:
+		sum, variant, ok := c.symbols.Variant(p.Variant)
+		if !ok {
+			// As at runtime, Name() with no fields may match a record.
+			if _, isRecord := c.symbols.Records[p.Variant]; isRecord && len(p.Fields) == 0 {
+				c.patternTargets(p, p.Variant, Record{Name: p.Variant}, target)
+				return
+			}
+			c.errorf(p, "unknown variant %s", p.Variant)
+			return
+		}
+		if p.TypeName != "" && p.TypeName != sum.Name {
+			c.errorf(p, "%s is not a variant of %s", p.Variant, p.TypeName)
+			return
+		}
+		if !c.patternTargets(p, p.Variant, SumType{Name: sum.Name}, target) {
+			return
+		}
+		if len(p.Fields) != len(variant.Fields) {
+			c.errorf(p, "pattern %s has %d field(s) but the variant declares %d", p.Variant, len(p.Fields), len(variant.Fields))
+			return
+		}
+		for i, fp := range p.Fields {
+			c.bindPattern(fp, c.resolveType(variant.Fields[i].Type, variant.Fields[i]), branch)
+		}
+	default:
+		c.errorf(pattern, "unsupported pattern %T", pattern)
+	}
+}
+
+// patternTargets reports whether a pattern for values of type want can
+// match a subject of type target.
+func (c *checker) patternTargets(p ast.Pattern, name string, want, target TypeRef) bool {
+	if isUnknown(target) || Same(nonNull(target), want) {
+		return true
+	}
+	c.errorf(p, "pattern %s cannot match a value of type %s", name, target)
+	return false
+}
+
+func (c *checker) inferCall(ex *ast.CallExpr, sc *scope) TypeRef {
+	if t, ok := sc.lookup(ex.Callee); ok {
+		switch fn := t.(type) {
+		case Function:
+			c.checkArgs("callable "+ex.Callee, fn.Params, ex.Arguments, ex, sc)
+			return fn.Return
+		case Unknown:
+			c.inferArgs(ex.Arguments, sc)
+			return Unknown{}
+		}
+		if _, isFunc := c.symbols.Functions[ex.Callee]; !isFunc {
+			c.errorf(ex, "%s is not callable", ex.Callee)
+			c.inferArgs(ex.Arguments, sc)
+			return Unknown{}
+		}
+	}
+	if sum, variant, ok := c.symbols.Variant(ex.Callee); ok {
+		fields := make([]TypeRef, len(variant.Fields))
+		for i, field := range variant.Fields {
+			fields[i] = c.resolveType(field.Type, field)
+		}
+		c.checkArgs("constructor "+ex.Callee, fields, ex.Arguments, ex, sc)
+		return SumType{Name: sum.Name}
+	}
+	if fn, ok := c.symbols.Functions[ex.Callee]; ok {
+		sig := c.signature(fn)
+		c.checkArgs("function "+ex.Callee, sig.Params, ex.Arguments, ex, sc)
+		return sig.Return
+	}
+	if ex.Callee == "range" {
+		if n := len(ex.Arguments); n != 2 && n != 3 {
+			c.errorf(ex, "range expects 2 or 3 argument(s) but received %d", n)
+		}
+		for _, arg := range ex.Arguments {
+			if t := c.infer(arg, sc); !isKind(t, Int) && !isUnknown(t) {
+				c.errorf(arg, "range expects int arguments, got %s", t)
+			}
+		}
+		return Array{Element: intType}
+	}
+	c.errorf(ex, "unknown function %s", ex.Callee)
+	c.inferArgs(ex.Arguments, sc)
+	return Unknown{}
+}
+
+// checkArgs checks a call's arguments against the parameter types of the
+// callee, described for messages as e.g. "function add".
+func (c *checker) checkArgs(callee string, params []TypeRef, args []ast.Expr, call ast.Node, sc *scope) {
+	if len(params) != len(args) {
+		c.errorf(call, "%s expects %d argument(s) but received %d", callee, len(params), len(args))
+		c.inferArgs(args, sc)
+		return
+	}
+	for i, arg := range args {
+		if actual := c.inferAs(arg, sc, params[i]); !Assignable(actual, params[i]) {
+			c.errorf(arg, "argument %d of %s expects %s but found %s", i+1, callee, params[i], actual)
+		}
+	}
+}
+
+func (c *checker) inferArgs(args []ast.Expr, sc *scope) {
+	for _, arg := range args {
+		c.infer(arg, sc)
+	}
+}
+
+// inferMethodCall checks recv.name(args) as the call name(recv, args). A
+// receiver that may be null needs ?. unless the first parameter accepts
+// null.
+func (c *checker) inferMethodCall(ex *ast.MethodCall, sc *scope) TypeRef {
+	fn, ok := c.symbols.Functions[ex.Name]
+	if !ok {
+		c.errorf(ex, "unknown function %s", ex.Name)
+		c.infer(ex.Receiver, sc)
+		c.inferArgs(ex.Arguments, sc)
+		return Unknown{}
+	}
+	sig := c.signature(fn)
+	if len(sig.Params) == 0 {
+		c.errorf(ex, "function %s takes no parameters and cannot be called as a member", ex.Name)
+		c.infer(ex.Receiver, sc)
+		c.inferArgs(ex.Arguments, sc)
+		return Unknown{}
+	}
+	var self TypeRef
+	n := notNull
+	switch {
+	case ex.Safe:
+		self, n = c.inferNullness(ex.Receiver, sc)
+	case isNullableType(sig.Params[0]):
+		self = c.infer(ex.Receiver, sc)
+	default:
+		self = c.deref(ex.Receiver, sc, "calling "+ex.Name)
+	}
+	if !Assignable(self, sig.Params[0]) {
+		c.errorf(ex, "function %s expects %s as its first parameter, not %s", ex.Name, sig.Params[0], self)
+	}
+	c.checkArgs("member "+ex.Name, sig.Params[1:], ex.Arguments, ex, sc)
+	if n != notNull && !isKind(sig.Return, Void) {
+		return nullable(sig.Return)
+	}
+	return sig.Return
+}
+
+// checkIterate checks an each/withIndex block, typing `it` like the
+// values evalIterate binds.
+func (c *checker) checkIterate(ex *ast.IterateExpr, sc *scope) {
+	var it TypeRef = Unknown{}
+	switch t := c.deref(ex.Target, sc, "iterating over it").(type) {
+	case Unknown:
+	case Array:
+		it = t.Element
+		if ex.Method == "withIndex" {
+			it = Record{Name: "IndexedValue", Fields: map[string]TypeRef{"index": intType, "value": t.Element}}
+		}
+	case Map:
+		if ex.Method == "withIndex" {
+			c.errorf(ex, "withIndex is only supported on arrays")
+			break
+		}
+		it = Record{Name: "MapEntry", Fields: map[string]TypeRef{"key": t.Key, "value": t.Value}}
+	default:
+		c.errorf(ex, "%s expects an array or map, got %s", ex.Method, t)
+	}
+	sc.forgetAssigned(ex.Body)
+	body := newScope(sc)
+	body.define("it", it, "parameter")
+	c.fn.loops++
+	c.checkBlock(ex.Body, body, false)
+	c.fn.loops--
+}
+
+// inferLambda checks a lambda body in a scope that sees every enclosing
+// variable. Without a declared return type, the return type is inferred
+// from its return statements and trailing expression.
+func (c *checker) inferLambda(ex *ast.LambdaExpr, sc *scope) TypeRef {
+	fn := Function{Params: make([]TypeRef, len(ex.Params))}
+	params := newScope(sc)
+	for i, param := range ex.Params {
+		fn.Params[i] = c.resolveType(param.Type, param)
+		params.define(param.Name, fn.Params[i], "parameter")
+	}
+	ctx := &funcContext{}
+	if ex.ReturnType != "" {
+		ctx.ret = c.resolveType(ex.ReturnType, ex)
+	}
+	outer := c.fn
+	c.fn = ctx
+	defer func() { c.fn = outer }()
+
+	result := c.checkBlock(ex.Body, params, ctx.ret == nil || !isKind(ctx.ret, Void))
+	if ctx.ret != nil {
+		c.checkReturns(ex, ex.Body, result)
+		fn.Return = ctx.ret
+		return fn
+	}
+	var ret TypeRef
+	if result != nil && (len(ctx.returns) == 0 || !isKind(result, Void)) {
+		ret = result
+	}
+	for _, t := range ctx.returns {
+		joined, ok := join(ret, t)
+		if !ok {
+			c.errorf(ex, "lambda return types differ: %s and %s", ret, t)
+			ret = Unknown{}
+			break
+		}
+		ret = joined
+	}
+	if ret == nil {
+		ret = voidType
+	}
+	if isNullType(ret) {
+		c.errorf(ex, "cannot infer the return type of a lambda that only returns null: declare it, e.g. fun string? (...)")
+		ret = Unknown{}
+	}
+	if denotable(ret) {
+		c.inferred.Lambdas[ex] = ret
+	}
+	fn.Return = ret
+	return fn
+}
+
+func (c *checker) inferUnary(ex *ast.UnaryOp, sc *scope) TypeRef {
+	operand := c.infer(ex.Operand, sc)
+	if isUnknown(operand) {
+		return operand
+	}
+	switch ex.Op {
+	case "!":
+		if !isKind(operand, Bool) {
+			c.errorf(ex, "operator ! expects bool, got %s", operand)
+		}
+		return boolType
+	case "-":
+		kind, ok := numericKind(operand)
+		if !ok {
+			c.errorf(ex, "operator - expects a number, got %s", operand)
+			return Unknown{}
+		}
+		return Primitive{kind}
+	}
+	c.errorf(ex, "unknown operator %s", ex.Op)
+	return Unknown{}
+}
+
+func (c *checker) inferBinary(ex *ast.BinaryOp, sc *scope) TypeRef {
+	switch ex.Op {
+	case "&&", "||":
+		// The right operand only runs when the left one did not decide
+		// the result, so it sees the facts that outcome implies.
+		whenTrue, whenFalse := condFacts(ex.Left)
+		if ex.Op == "||" {
+			whenTrue = whenFalse
+		}
+		left, right := c.infer(ex.Left, sc), c.infer(ex.Right, sc.narrowed(whenTrue))
+		for _, side := range []struct {
+			node ast.Expr
+			t    TypeRef
+		}{{ex.Left, left}, {ex.Right, right}} {
+			if !isKind(side.t, Bool) && !isUnknown(side.t) {
+				c.errorf(side.node, "operator %s expects bool, got %s", ex.Op, side.t)
+			}
+		}
+		return boolType
+	case "+", "-", "*", "/", "%":
+		return c.arithmetic(ex, ex.Op, c.infer(ex.Left, sc), c.infer(ex.Right, sc))
+	case "<", "<=", ">", ">=":
+		c.arithmetic(ex, ex.Op, c.infer(ex.Left, sc), c.infer(ex.Right, sc))
+		return boolType
+	case "==", "!=":
+		left, ln := c.inferNullness(ex.Left, sc)
+		right, rn := c.inferNullness(ex.Right, sc)
+		if ln != notNull || c.narrowedReads[ex.Left] {
+			left = nullable(left)
+		}
+		if rn != notNull || c.narrowedReads[ex.Right] {
+			right = nullable(right)
+		}
+		if !comparable(left, right) {
+			c.errorf(ex, "cannot compare %s with %s", left, right)
+		}
+		return boolType
+	}
+	c.errorf(ex, "unknown operator %s", ex.Op)
+	return Unknown{}
+}
+
+// arithmetic returns the promoted type of a numeric binary operation:
+// the wider of the two operands, with a char counting as an int.
+func (c *checker) arithmetic(node ast.Node, op string, left, right TypeRef) TypeRef {
+	if isUnknown(left) || isUnknown(right) {
+		return Unknown{}
+	}
+	lk, lok := numericKind(left)
+	rk, rok := numericKind(right)
+	if !lok || !rok {
+		c.errorf(node, "binary op %s expects numbers, got %s and %s", op, left, right)
+		return Unknown{}
+	}
+	if lk > rk {
+		return Primitive{lk}
+	}
+	return Primitive{rk}
+}
+
+// comparable reports whether == between values of types a and b can be
+// true: numbers compare by value, null only with a nullable type, and
+// anything else with a value of a compatible type.
+func comparable(a, b TypeRef) bool {
+	if isUnknown(a) || isUnknown(b) {
+		return true
+	}
+	_, aNull := a.(Null)
+	_, bNull := b.(Null)
+	if aNull || bNull {
+		_, aOpt := a.(Nullable)
+		_, bOpt := b.(Nullable)
+		return aNull && bNull || aOpt || bOpt
+	}
+	_, lok := numericKind(nonNull(a))
+	_, rok := numericKind(nonNull(b))
+	if lok && rok {
+		return true
+	}
+	return Assignable(nonNull(a), nonNull(b)) || Assignable(nonNull(b), nonNull(a))
+}
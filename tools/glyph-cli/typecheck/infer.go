@@ -0,0 +1,98 @@
+package typecheck
+
+import (
+	"glyph-cli/ast"
+	"glyph-cli/project"
+)
+
+// Inferred records the types the checker inferred where the source leaves
+// them out, for tooling that wants to show them.
+type Inferred struct {
+	// Vars holds the type of each val, var or const declared without one.
+	Vars map[*ast.VarDecl]TypeRef
+	// Lambdas holds the return type of each lambda declared without one.
+	Lambdas map[*ast.LambdaExpr]TypeRef
+}
+
+// Infer type-checks program like Check and fills in VarDecl.Type and
+// LambdaExpr.ReturnType on the AST wherever they were left out and could
+// be inferred. An empty `[:]` literal gets the key and value types of the
+// declaration it initialises. The error is the same as Check's; the
+// inferred types are returned even when the program has errors.
+func Infer(program *ast.Program, symbols *project.Symbols) (*Inferred, error) {
+	c, err := check(program, symbols)
+	if c == nil {
+		return nil, err
+	}
+	for decl, t := range c.inferred.Vars {
+		decl.Type = t.String()
+	}
+	for lambda, t := range c.inferred.Lambdas {
+		lambda.ReturnType = t.String()
+	}
+	return c.inferred, err
+}
+
+// inferVarType infers the type of a declaration without one. null and an
+// empty `[:]` say nothing about the type the variable should hold.
+func (c *checker) inferVarType(s *ast.VarDecl, sc *scope) TypeRef {
+	if lit, ok := s.Value.(*ast.MapLiteralExpr); ok && lit.KeyType == "" {
+		c.errorf(s, "cannot infer the type of %s from an empty map literal: declare it, e.g. %s [string:int] %s = [:]", s.Name, s.Mutability, s.Name)
+		return Unknown{}
+	}
+	value := c.infer(s.Value, sc)
+	switch {
+	case isKind(value, Void):
+		c.errorf(s, "cannot initialise %s with a void value", s.Name)
+		return Unknown{}
+	case isNullType(value):
+		c.errorf(s, "cannot infer the type of %s from null: declare it, e.g. %s string? %s = null", s.Name, s.Mutability, s.Name)
+		return Unknown{}
+	}
+	if denotable(value) {
+		c.inferred.Vars[s] = value
+	}
+	return value
+}
+
+// inferAs infers e where a value of type want is expected. An empty `[:]`
+// takes its key and value types from want.
+func (c *checker) inferAs(e ast.Expr, sc *scope, want TypeRef) TypeRef {
+	if lit, ok := e.(*ast.MapLiteralExpr); ok && lit.KeyType == "" && want != nil {
+		if m, ok := nonNull(want).(Map); ok && denotable(m) {
+			lit.KeyType, lit.ValueType = m.Key.String(), m.Value.String()
+		}
+	}
+	return c.infer(e, sc)
+}
+
+func isNullType(t TypeRef) bool {
+	_, ok := t.(Null)
+	return ok
+}
+
+// denotable reports whether t can be written back as a type name that
+// parseType reads, which rules out the element records of each and
+// withIndex as well as Unknown and null.
+func denotable(t TypeRef) bool {
+	switch t := t.(type) {
+	case Primitive, SumType:
+		return true
+	case Record:
+		return t.Fields == nil
+	case Nullable:
+		return denotable(t.Inner)
+	case Array:
+		return denotable(t.Element)
+	case Map:
+		return denotable(t.Key) && denotable(t.Value)
+	case Function:
+		for _, p := range t.Params {
+			if !denotable(p) {
+				return false
+			}
+		}
+		return denotable(t.Return)
+	}
+	return false
+}
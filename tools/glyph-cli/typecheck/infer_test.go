@@ -0,0 +1,136 @@
+package typecheck
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"glyph-cli/ast"
+	"glyph-cli/parser"
+	"glyph-cli/project"
+)
+
+func TestInferFillsDeclaredTypes(t *testing.T) {
+	source := `record User {
+  string name
+  [string:int] scores
+}
+
+fun void main() {
+  var name = "Glyph"
+  val twice = fun (int x) { x * 2L }
+  val pick = fun (bool b) {
+    if b {
+      return User { name = "a", scores = [:] }
+    }
+    return null
+  }
+  val [string:int] counts = [:]
+  val entries = counts
+  val found = counts["a"]
+  val count = counts["a"] ?: 0
+  const limit = 10.5
+}
+`
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.gly")
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	idx, err := project.BuildIndex(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	program, err := parser.ParseProgramFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	symbols, err := project.Resolve(program, idx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inferred, err := Infer(program, symbols)
+	if err != nil {
+		t.Fatalf("unexpected errors:\n%v", err)
+	}
+
+	decls := map[string]*ast.VarDecl{}
+	var lambdas []*ast.LambdaExpr
+	var emptyMaps []*ast.MapLiteralExpr
+	ast.Inspect(program, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.VarDecl:
+			decls[n.Name] = n
+		case *ast.LambdaExpr:
+			lambdas = append(lambdas, n)
+		case *ast.MapLiteralExpr:
+			emptyMaps = append(emptyMaps, n)
+		}
+		return true
+	})
+	want := map[string]string{
+		"name":    "string",
+		"twice":   "fun long (int)",
+		"pick":    "fun User? (bool)",
+		"counts":  "[string:int]",
+		"entries": "[string:int]",
+		"found":   "int?",
+		"count":   "int",
+		"limit":   "double",
+	}
+	for name, typ := range want {
+		if got := decls[name].Type; got != typ {
+			t.Errorf("%s: got type %q, want %q", name, got, typ)
+		}
+	}
+	if got := inferred.Vars[decls["name"]]; got == nil || got.String() != "string" {
+		t.Errorf("Inferred.Vars[name] = %v, want string", got)
+	}
+	if _, ok := inferred.Vars[decls["counts"]]; ok {
+		t.Errorf("declared types must not be reported as inferred")
+	}
+	if len(lambdas) != 2 || lambdas[0].ReturnType != "long" || lambdas[1].ReturnType != "User?" {
+		t.Errorf("lambda return types not filled in: %+v", lambdas)
+	}
+	for _, m := range emptyMaps {
+		if m.KeyType != "string" || m.ValueType != "int" {
+			t.Errorf("empty map literal at %v typed [%s:%s]", m.Pos, m.KeyType, m.ValueType)
+		}
+	}
+
+	// The annotated program must still type-check.
+	if err := Check(program, symbols); err != nil {
+		t.Fatalf("annotated program fails to check:\n%v", err)
+	}
+}
+
+func TestReportsAmbiguousInference(t *testing.T) {
+	source := `fun void main() {
+  val nothing = null
+  var m = [:]
+  val f = fun (int x) { return null }
+  print([:])
+}
+`
+	err := checkSource(t, source)
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected type errors, got %v", err)
+	}
+	want := []string{
+		"main.gly:2:3: cannot infer the type of nothing from null: declare it, e.g. val string? nothing = null",
+		"main.gly:3:3: cannot infer the type of m from an empty map literal: declare it, e.g. var [string:int] m = [:]",
+		"main.gly:4:11: cannot infer the return type of a lambda that only returns null: declare it, e.g. fun string? (...)",
+		"main.gly:5:9: cannot infer the key and value types of an empty map literal: write [K:V]{} or use it where a map type is declared",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors, want %d:\n%v", len(errs), len(want), err)
+	}
+	for i, e := range errs {
+		if got := e.Error(); !strings.HasSuffix(got, want[i]) {
+			t.Errorf("error %d: got %q, want suffix %q", i, got, want[i])
+		}
+	}
+}
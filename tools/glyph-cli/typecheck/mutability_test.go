@@ -0,0 +1,53 @@
+package typecheck
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRejectsReassigningImmutableBindings(t *testing.T) {
+	source := `fun int twice(int n) {
+  n = n * 2
+  n
+}
+
+fun void main() {
+  val x = 1
+  x = 2
+  const int limit = 3
+  limit++
+  var total = 0
+  total += x
+  val f = fun (int a) { a += 1 }
+  range(0, 2).each { it = 1 }
+  const banner = "v${limit + 1}"
+  const int size = twice(2)
+  const [int] xs = [int](1)
+  const derived = total
+}
+`
+	err := checkSource(t, source)
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected type errors, got %v", err)
+	}
+	want := []string{
+		"main.gly:2:3: cannot reassign parameter n",
+		"main.gly:8:3: cannot reassign val x",
+		"main.gly:10:3: cannot reassign const limit",
+		"main.gly:13:25: cannot reassign parameter a",
+		"main.gly:14:22: cannot reassign parameter it",
+		"main.gly:16:20: const size must be initialised with a compile-time constant",
+		"main.gly:17:20: const xs must be initialised with a compile-time constant",
+		"main.gly:18:19: const derived must be initialised with a compile-time constant",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors, want %d:\n%v", len(errs), len(want), err)
+	}
+	for i, e := range errs {
+		if got := e.Error(); !strings.HasSuffix(got, want[i]) {
+			t.Errorf("error %d: got %q, want suffix %q", i, got, want[i])
+		}
+	}
+}
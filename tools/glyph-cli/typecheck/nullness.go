@@ -0,0 +1,301 @@
+package typecheck
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"glyph-cli/ast"
+)
+
+// Null safety is flow sensitive. A check such as `x != null` records the
+// fact that the path "x" is non-null in the scope of the code it guards,
+// and reading a path with such a fact narrows its type from T? to T. Paths
+// name variables, field chains ("u.address.city") and map or array
+// elements with a literal index (`m["k"]`).
+//
+// Facts only live as long as nothing assigns to the path: an assignment
+// forgets them, and so does any assignment inside a loop body before the
+// loop is checked. Calls are assumed not to mutate the fields of their
+// arguments.
+
+// path names the storage location e reads, or "" if e cannot be narrowed.
+func path(e ast.Expr) string {
+	switch ex := e.(type) {
+	case *ast.VarRef:
+		return ex.Name
+	case *ast.FieldAccess:
+		if base := path(ex.Target); base != "" {
+			return base + "." + ex.Field
+		}
+	case *ast.SafeFieldAccess:
+		if base := path(ex.Target); base != "" {
+			return base + "." + ex.Field
+		}
+	case *ast.IndexAccess:
+		base := path(ex.Target)
+		if base == "" {
+			return ""
+		}
+		switch idx := ex.Index.(type) {
+		case *ast.IntLiteral:
+			return fmt.Sprintf("%s[%d]", base, idx.Value)
+		case *ast.StringLiteral:
+			return base + "[" + strconv.Quote(idx.Value) + "]"
+		case *ast.CharLiteral:
+			return base + "[" + strconv.QuoteRune(idx.Value) + "]"
+		}
+	}
+	return ""
+}
+
+// pathRoot returns the variable a path starts from.
+func pathRoot(p string) string {
+	if i := strings.IndexAny(p, ".["); i >= 0 {
+		return p[:i]
+	}
+	return p
+}
+
+// isNonNull reports whether a fact about p is in effect. Facts about a
+// variable never outlive the scope that declares it.
+func (s *scope) isNonNull(p string) bool {
+	root := pathRoot(p)
+	for cur := s; cur != nil; cur = cur.parent {
+		if cur.facts[p] {
+			return true
+		}
+		if _, declares := cur.vars[root]; declares {
+			return false
+		}
+	}
+	return false
+}
+
+func (s *scope) narrow(paths []string) {
+	for _, p := range paths {
+		if s.facts == nil {
+			s.facts = map[string]bool{}
+		}
+		s.facts[p] = true
+	}
+}
+
+// narrowed returns a child of s in which paths are known to be non-null.
+func (s *scope) narrowed(paths []string) *scope {
+	if len(paths) == 0 {
+		return s
+	}
+	child := newScope(s)
+	child.narrow(paths)
+	return child
+}
+
+// forget drops every fact about p and the paths below it, up to the scope
+// that declares its variable.
+func (s *scope) forget(p string) {
+	if p == "" {
+		return
+	}
+	root := pathRoot(p)
+	for cur := s; cur != nil; cur = cur.parent {
+		for fact := range cur.facts {
+			if coversPath(p, fact) {
+				delete(cur.facts, fact)
+			}
+		}
+		if _, declares := cur.vars[root]; declares {
+			return
+		}
+	}
+}
+
+// coversPath reports whether assigning p may change the value at fact.
+// p may end in "[]" to stand for every element of a collection.
+func coversPath(p, fact string) bool {
+	if elems := strings.TrimSuffix(p, "]"); elems != p && strings.HasSuffix(elems, "[") {
+		return strings.HasPrefix(fact, elems)
+	}
+	return fact == p || strings.HasPrefix(fact, p+".") || strings.HasPrefix(fact, p+"[")
+}
+
+// narrowRead returns t as non-null if e is a path with a fact in effect.
+// The checker remembers the narrowing, since assigning to e or comparing
+// it with null still deals in its declared type.
+func (c *checker) narrowRead(e ast.Expr, t TypeRef, sc *scope) TypeRef {
+	if n, ok := t.(Nullable); ok {
+		if p := path(e); p != "" && sc.isNonNull(p) {
+			c.narrowedReads[e] = true
+			return n.Inner
+		}
+	}
+	return t
+}
+
+// condFacts returns the paths known to be non-null when cond evaluates to
+// true and when it evaluates to false.
+func condFacts(cond ast.Expr) (whenTrue, whenFalse []string) {
+	switch ex := cond.(type) {
+	case *ast.BinaryOp:
+		switch ex.Op {
+		case "!=", "==":
+			var checked ast.Expr
+			if _, ok := ex.Right.(*ast.NullLiteral); ok {
+				checked = ex.Left
+			} else if _, ok := ex.Left.(*ast.NullLiteral); ok {
+				checked = ex.Right
+			}
+			if checked == nil {
+				return nil, nil
+			}
+			if ex.Op == "!=" {
+				return nonNullPaths(checked), nil
+			}
+			return nil, nonNullPaths(checked)
+		case "&&":
+			lt, _ := condFacts(ex.Left)
+			rt, _ := condFacts(ex.Right)
+			return append(lt, rt...), nil
+		case "||":
+			_, lf := condFacts(ex.Left)
+			_, rf := condFacts(ex.Right)
+			return nil, append(lf, rf...)
+		}
+	case *ast.UnaryOp:
+		if ex.Op == "!" {
+			t, f := condFacts(ex.Operand)
+			return f, t
+		}
+	}
+	return nil, nil
+}
+
+// nonNullPaths lists the paths that must be non-null for e to be non-null:
+// e itself and, since `a.b` or `a?.b` can only be non-null when a is, every
+// path it is read through.
+func nonNullPaths(e ast.Expr) []string {
+	var out []string
+	for e != nil {
+		if p := path(e); p != "" {
+			out = append(out, p)
+		}
+		switch ex := e.(type) {
+		case *ast.FieldAccess:
+			e = ex.Target
+		case *ast.SafeFieldAccess:
+			e = ex.Target
+		case *ast.IndexAccess:
+			e = ex.Target
+		default:
+			e = nil
+		}
+	}
+	return out
+}
+
+// assignedPaths lists the paths assigned anywhere in block. Lambda bodies
+// are skipped: a closure works on its own copy of the variables it sees.
+func assignedPaths(block *ast.Block) []string {
+	var out []string
+	ast.Inspect(block, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.LambdaExpr:
+			return false
+		case *ast.AssignStmt:
+			out = append(out, assignedPath(s.Target))
+		case *ast.IncDecStmt:
+			out = append(out, assignedPath(s.Target))
+		}
+		return true
+	})
+	return out
+}
+
+// assignedPath is the path invalidated by storing into target. Storing
+// into an element with a computed index may change any element.
+func assignedPath(target ast.Expr) string {
+	if p := path(target); p != "" {
+		return p
+	}
+	if idx, ok := target.(*ast.IndexAccess); ok {
+		if base := path(idx.Target); base != "" {
+			return base + "[]"
+		}
+	}
+	return ""
+}
+
+// forgetAssigned drops the facts about everything a loop body assigns,
+// since a later iteration sees those assignments.
+func (s *scope) forgetAssigned(body *ast.Block) {
+	for _, p := range assignedPaths(body) {
+		s.forget(p)
+	}
+}
+
+// nullness says whether the value of an expression may be null.
+type nullness int
+
+const (
+	notNull nullness = iota
+	mayBeNull
+	mayBeMissing // a map lookup whose key may be absent
+)
+
+// inferNullness infers the target of a dereference, returning its non-null
+// type and whether it may be null. A map lookup is nullable even when the
+// value type is not, because the key may be missing.
+func (c *checker) inferNullness(target ast.Expr, sc *scope) (TypeRef, nullness) {
+	t := c.infer(target, sc)
+	switch tt := t.(type) {
+	case Nullable:
+		if idx, ok := target.(*ast.IndexAccess); ok && c.mapLookups[idx] {
+			return tt.Inner, mayBeMissing
+		}
+		return tt.Inner, mayBeNull
+	case Null:
+		return t, mayBeNull
+	}
+	return t, notNull
+}
+
+// deref infers the target of a field access, member call, index or
+// iteration, reporting it if the target may be null. action describes
+// the dereference for the message, e.g. "accessing field city".
+func (c *checker) deref(target ast.Expr, sc *scope, action string) TypeRef {
+	t, n := c.inferNullness(target, sc)
+	switch n {
+	case mayBeMissing:
+		c.errorf(target, "map lookup %s may be missing: check it for null or use ?. before %s", describe(target, t), action)
+	case mayBeNull:
+		if _, isNull := t.(Null); isNull {
+			c.errorf(target, "null cannot be dereferenced by %s", action)
+			return Unknown{}
+		}
+		c.errorf(target, "%s may be null: check it for null or use ?. before %s", describe(target, nullable(t)), action)
+	}
+	return t
+}
+
+func isNullableType(t TypeRef) bool {
+	_, ok := t.(Nullable)
+	return ok
+}
+
+// declaredType infers e without the narrowing of its own read.
+func (c *checker) declaredType(e ast.Expr, sc *scope) TypeRef {
+	t := c.infer(e, sc)
+	if c.narrowedReads[e] {
+		return nullable(t)
+	}
+	return t
+}
+
+// describe names an expression for a diagnostic: its path when it has one,
+// otherwise its type.
+func describe(e ast.Expr, t TypeRef) string {
+	if p := path(e); p != "" {
+		return p
+	}
+	return "a value of type " + t.String()
+}
@@ -0,0 +1,147 @@
+package typecheck
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNullChecksNarrowNullableTypes(t *testing.T) {
+	source := `record Address {
+  string city
+}
+
+record User {
+  string name
+  Address? address
+}
+
+fun bool isSet(string? s) {
+  s != null
+}
+
+fun int cityLength(User u) {
+  if u.address == null {
+    return 0
+  }
+  u.address.city.length()
+}
+
+fun int length(string s) {
+  0
+}
+
+fun void main() {
+  val User? maybe = null
+  if maybe != null {
+    print(maybe.name)
+  }
+  val name = maybe != null ? maybe.name : "nobody"
+  if maybe != null && maybe.address != null {
+    print(maybe.address.city)
+  }
+  if maybe == null || maybe.address == null {
+    print("incomplete")
+  } else {
+    print(maybe.address.city)
+  }
+  val city = match maybe {
+    null -> "none"
+  } else maybe.name
+  print(maybe?.address?.city ?: "Remote")
+  print(maybe.isSet2())
+  val [string:User] byName = [string:User]{}
+  if byName["ann"] != null {
+    print(byName["ann"].name)
+  }
+  val found = byName["bob"]
+  if found != null {
+    print(found.name)
+  }
+  val User known = byName["cy"] ?: User { name = "Cy", address = null }
+  byName["cy"] = known
+  print(known.name)
+}
+
+fun bool isSet2(User? u) {
+  u != null
+}
+`
+	if err := checkSource(t, source); err != nil {
+		t.Fatalf("unexpected errors:\n%v", err)
+	}
+}
+
+func TestRejectsUncheckedDereference(t *testing.T) {
+	source := `record Address {
+  string city
+}
+
+record User {
+  string name
+  Address? address
+}
+
+fun void main() {
+  var User? maybe = null
+  print(maybe.name)
+  val u = User { name = "Ann", address = null }
+  print(u.address.city)
+  val [string:User] byName = [string:User]{}
+  print(byName["ann"].name)
+  if maybe != null {
+    maybe = null
+    print(maybe.name)
+  }
+  print(null.name)
+  val f = byName["bob"]
+  print(f.name)
+}
+`
+	err := checkSource(t, source)
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected type errors, got %v", err)
+	}
+	want := []string{
+		"main.gly:12:9: maybe may be null: check it for null or use ?. before accessing field name",
+		"main.gly:14:9: u.address may be null: check it for null or use ?. before accessing field city",
+		"main.gly:16:9: map lookup byName[\"ann\"] may be missing: check it for null or use ?. before accessing field name",
+		"main.gly:19:11: maybe may be null: check it for null or use ?. before accessing field name",
+		"main.gly:21:9: null cannot be dereferenced by accessing field name",
+		"main.gly:23:9: f may be null: check it for null or use ?. before accessing field name",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors, want %d:\n%v", len(errs), len(want), err)
+	}
+	for i, e := range errs {
+		if got := e.Error(); !strings.HasSuffix(got, want[i]) {
+			t.Errorf("error %d: got %q, want suffix %q", i, got, want[i])
+		}
+	}
+}
+
+func TestLoopAssignmentsInvalidateFacts(t *testing.T) {
+	source := `record User {
+  string name
+}
+
+fun void main() {
+  var User? current = User { name = "a" }
+  if current == null {
+    return
+  }
+  var i = 0
+  while i < 3 {
+    print(current.name)
+    current = null
+    i++
+  }
+}
+`
+	err := checkSource(t, source)
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Pos.Line != 12 {
+		t.Fatalf("expected one error on line 12, got %v", err)
+	}
+}
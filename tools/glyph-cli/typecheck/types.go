@@ -0,0 +1,292 @@
+package typecheck
+
+import (
+	"strings"
+
+	"glyph-cli/project"
+)
+
+// TypeRef is the static type of a Glyph expression or declaration.
+type TypeRef interface {
+	String() string
+	typeRef()
+}
+
+// Kind enumerates the primitive types.
+type Kind int
+
+const (
+	Int Kind = iota
+	Long
+	Float
+	Double
+	Char
+	Bool
+	String
+	Bytes
+	Void
+)
+
+var kindNames = [...]string{"int", "long", "float", "double", "char", "bool", "string", "bytes", "void"}
+
+func (k Kind) String() string { return kindNames[k] }
+
+// Primitive is one of the built-in scalar types.
+type Primitive struct {
+	Kind Kind
+}
+
+// Nullable is T?: a T or null.
+type Nullable struct {
+	Inner TypeRef
+}
+
+// Null is the type of the null literal.
+type Null struct{}
+
+// Record is a declared record type. Fields is only set for the synthetic
+// records bound to `it` by each/withIndex, which have no declaration.
+type Record struct {
+	Name   string
+	Fields map[string]TypeRef
+}
+
+// SumType is a declared `type T = A | B` sum type.
+type SumType struct {
+	Name string
+}
+
+// Array is [T].
+type Array struct {
+	Element TypeRef
+}
+
+// Map is [K:V].
+type Map struct {
+	Key   TypeRef
+	Value TypeRef
+}
+
+// Function is the type of a lambda value.
+type Function struct {
+	Params []TypeRef
+	Return TypeRef
+}
+
+// Unknown stands in for the type of an expression that already produced an
+// error. It is compatible with everything so that one mistake is reported
+// once rather than at every use.
+type Unknown struct{}
+
+func (Primitive) typeRef() {}
+func (Nullable) typeRef()  {}
+func (Null) typeRef()      {}
+func (Record) typeRef()    {}
+func (SumType) typeRef()   {}
+func (Array) typeRef()     {}
+func (Map) typeRef()       {}
+func (Function) typeRef()  {}
+func (Unknown) typeRef()   {}
+
+func (t Primitive) String() string { return t.Kind.String() }
+func (t Nullable) String() string  { return t.Inner.String() + "?" }
+func (Null) String() string        { return "null" }
+func (t Record) String() string    { return t.Name }
+func (t SumType) String() string   { return t.Name }
+func (t Array) String() string     { return "[" + t.Element.String() + "]" }
+func (t Map) String() string       { return "[" + t.Key.String() + ":" + t.Value.String() + "]" }
+func (Unknown) String() string     { return "unknown" }
+
+func (t Function) String() string {
+	params := make([]string, len(t.Params))
+	for i, p := range t.Params {
+		params[i] = p.String()
+	}
+	return "fun " + t.Return.String() + " (" + strings.Join(params, ", ") + ")"
+}
+
+var (
+	intType    TypeRef = Primitive{Int}
+	longType   TypeRef = Primitive{Long}
+	floatType  TypeRef = Primitive{Float}
+	doubleType TypeRef = Primitive{Double}
+	charType   TypeRef = Primitive{Char}
+	boolType   TypeRef = Primitive{Bool}
+	stringType TypeRef = Primitive{String}
+	voidType   TypeRef = Primitive{Void}
+)
+
+// Same reports whether a and b denote the same type.
+func Same(a, b TypeRef) bool {
+	switch at := a.(type) {
+	case Primitive:
+		bt, ok := b.(Primitive)
+		return ok && at.Kind == bt.Kind
+	case Nullable:
+		bt, ok := b.(Nullable)
+		return ok && Same(at.Inner, bt.Inner)
+	case Null:
+		_, ok := b.(Null)
+		return ok
+	case Record:
+		bt, ok := b.(Record)
+		return ok && at.Name == bt.Name
+	case SumType:
+		bt, ok := b.(SumType)
+		return ok && at.Name == bt.Name
+	case Array:
+		bt, ok := b.(Array)
+		return ok && Same(at.Element, bt.Element)
+	case Map:
+		bt, ok := b.(Map)
+		return ok && Same(at.Key, bt.Key) && Same(at.Value, bt.Value)
+	case Function:
+		bt, ok := b.(Function)
+		if !ok || len(at.Params) != len(bt.Params) || !Same(at.Return, bt.Return) {
+			return false
+		}
+		for i := range at.Params {
+			if !Same(at.Params[i], bt.Params[i]) {
+				return false
+			}
+		}
+		return true
+	case Unknown:
+		_, ok := b.(Unknown)
+		return ok
+	}
+	return false
+}
+
+// Assignable reports whether a value of type actual may be stored where
+// expected is declared. Besides identical types it allows null and T into
+// T?, and the numeric conversions the interpreter performs for typed
+// declarations: widening (with char counting as an int) and double to float.
+func Assignable(actual, expected TypeRef) bool {
+	if isUnknown(actual) || isUnknown(expected) {
+		return true
+	}
+	if exp, ok := expected.(Nullable); ok {
+		switch act := actual.(type) {
+		case Null:
+			return true
+		case Nullable:
+			return Assignable(act.Inner, exp.Inner)
+		default:
+			return Assignable(actual, exp.Inner)
+		}
+	}
+	switch act := actual.(type) {
+	case Null, Nullable:
+		return false
+	case Primitive:
+		exp, ok := expected.(Primitive)
+		if !ok {
+			return false
+		}
+		return act.Kind == exp.Kind || widens(act.Kind, exp.Kind)
+	case Function:
+		exp, ok := expected.(Function)
+		if !ok || len(act.Params) != len(exp.Params) {
+			return false
+		}
+		for i := range act.Params {
+			if !Same(act.Params[i], exp.Params[i]) {
+				return false
+			}
+		}
+		return Assignable(act.Return, exp.Return)
+	}
+	return Same(actual, expected)
+}
+
+func widens(from, to Kind) bool {
+	return project.Widens(from.String(), to.String())
+}
+
+func isNumericKind(k Kind) bool {
+	return k == Int || k == Long || k == Float || k == Double || k == Char
+}
+
+// numericKind returns the arithmetic kind of t; a char takes part as an int.
+func numericKind(t TypeRef) (Kind, bool) {
+	p, ok := t.(Primitive)
+	if !ok || !isNumericKind(p.Kind) {
+		return 0, false
+	}
+	if p.Kind == Char {
+		return Int, true
+	}
+	return p.Kind, true
+}
+
+func isKind(t TypeRef, k Kind) bool {
+	p, ok := t.(Primitive)
+	return ok && p.Kind == k
+}
+
+func isIntegral(t TypeRef) bool {
+	return isKind(t, Int) || isKind(t, Long)
+}
+
+func isUnknown(t TypeRef) bool {
+	_, ok := t.(Unknown)
+	return ok
+}
+
+// nonNull strips one level of nullability.
+func nonNull(t TypeRef) TypeRef {
+	if n, ok := t.(Nullable); ok {
+		return n.Inner
+	}
+	return t
+}
+
+func nullable(t TypeRef) TypeRef {
+	switch t.(type) {
+	case Nullable, Null, Unknown:
+		return t
+	}
+	return Nullable{t}
+}
+
+// join computes the type of an expression whose value comes from one of two
+// branches, such as the arms of an if or a match. A nil type stands for a
+// branch that never produces a value (it returns or breaks) and is ignored.
+func join(a, b TypeRef) (TypeRef, bool) {
+	switch {
+	case a == nil:
+		return b, true
+	case b == nil, isUnknown(b):
+		return a, true
+	case isUnknown(a):
+		return b, true
+	}
+	if _, ok := a.(Null); ok {
+		return nullable(b), true
+	}
+	if _, ok := b.(Null); ok {
+		return nullable(a), true
+	}
+	_, aNull := a.(Nullable)
+	_, bNull := b.(Nullable)
+	if aNull || bNull {
+		inner, ok := join(nonNull(a), nonNull(b))
+		if !ok {
+			return nil, false
+		}
+		return nullable(inner), true
+	}
+	if ak, ok := numericKind(a); ok {
+		if bk, ok := numericKind(b); ok && !isKind(a, Char) && !isKind(b, Char) {
+			if ak > bk {
+				return a, true
+			}
+			return b, true
+		}
+	}
+	if Same(a, b) {
+		return a, true
+	}
+	return nil, false
+}